@@ -0,0 +1,173 @@
+// Package cli is trello-cli, a command line client of the API that manages
+// projects, columns, tasks and comments from the terminal:
+//
+//	trello-cli projects create -description "Q4 launch" launch
+//	trello-cli columns add 1 doing
+//	trello-cli tasks add -due 2026-11-02 1 doing "write the post"
+//	trello-cli -o json tasks ls 1
+//
+// It calls the server through package client, with the models of package dal.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/client"
+)
+
+const name = "trello-cli"
+
+// command is a verb of a group, e.g. ls of projects.
+type command struct {
+	// usage lists the flags and arguments of the verb
+	usage   string
+	summary string
+	run     func(r *runner, args []string) error
+}
+
+// commands lists the verbs of every group.
+var commands = map[string]map[string]command{
+	"projects": {
+		"ls":     {"", "list the projects", listProjects},
+		"create": {"[-description text] NAME", "create a project with a default column", createProject},
+		"rm":     {"PROJECT...", "delete projects with their columns, tasks and comments", removeProjects},
+	},
+	"columns": {
+		"ls":      {"PROJECT", "list the columns of a project in order", listColumns},
+		"add":     {"[-order n] [-status text] PROJECT NAME", "add a column, after the last one unless -order is given", addColumn},
+		"reorder": {"PROJECT COLUMN...", "put the columns first, in the order given", reorderColumns},
+	},
+	"tasks": {
+		"ls":   {"[-column COLUMN] [-open] PROJECT", "list the tasks of a project", listTasks},
+		"add":  {"[-description text] [-due date] PROJECT COLUMN NAME", "add a task to a column", addTask},
+		"mv":   {"PROJECT TASK COLUMN", "move a task to another column", moveTask},
+		"done": {"[-undo] PROJECT TASK", "mark a task done", markTaskDone},
+	},
+	"comments": {
+		"add": {"PROJECT TASK TEXT", "comment a task", addComment},
+	},
+}
+
+// runner runs a command against the server of a profile.
+type runner struct {
+	ctx    context.Context
+	client *client.Client
+	output string
+	stdout io.Writer
+	// usage is the usage line of the running command
+	usage string
+}
+
+// Run runs the command of args, e.g. "projects ls", and prints its result to
+// stdout. The profile is read from -config, or else from DefaultConfigPath;
+// -url and -o override what it says. Asking for help returns an error
+// wrapping flag.ErrHelp that carries the usage text.
+func Run(ctx context.Context, args []string, getenv func(string) string, stdout io.Writer) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var (
+		path    = fs.String("config", DefaultConfigPath(getenv), "path of the profile file")
+		profile = fs.String("profile", getenv("TRELLO_CLI_PROFILE"), "profile to use, the default one of the file if empty")
+		baseURL = fs.String("url", "", "URL of the server, instead of the one of the profile")
+		output  = fs.String("o", "", "output format: table or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n\n%s", err, usage(fs))
+	}
+	if fs.NArg() < 2 {
+		return fmt.Errorf("%w\n\n%s", flag.ErrHelp, usage(fs))
+	}
+	group, ok := commands[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", fs.Arg(0), usage(fs))
+	}
+	cmd, ok := group[fs.Arg(1)]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", fs.Arg(0)+" "+fs.Arg(1), usage(fs))
+	}
+
+	p, err := LoadProfile(*path, *profile)
+	if err != nil {
+		return err
+	}
+	if *baseURL != "" {
+		p.URL = *baseURL
+	}
+	if *output != "" {
+		if err := checkOutput(*output); err != nil {
+			return err
+		}
+		p.Output = *output
+	}
+	r := &runner{
+		ctx:    ctx,
+		client: client.New(p.URL).WithToken(p.Token).WithAPIKey(p.APIKey),
+		output: p.Output,
+		stdout: stdout,
+		usage:  strings.TrimSpace(name + " " + fs.Arg(0) + " " + fs.Arg(1) + " " + cmd.usage),
+	}
+	return cmd.run(r, fs.Args()[2:])
+}
+
+func usage(fs *flag.FlagSet) string {
+	var text bytes.Buffer
+	fmt.Fprintf(&text, "Usage: %s [flags] COMMAND\n\nCommands:\n", name)
+	groups := make([]string, 0, len(commands))
+	for group := range commands {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		verbs := make([]string, 0, len(commands[group]))
+		for verb := range commands[group] {
+			verbs = append(verbs, verb)
+		}
+		sort.Strings(verbs)
+		for _, verb := range verbs {
+			cmd := commands[group][verb]
+			fmt.Fprintf(&text, "  %s\n    \t%s\n", strings.TrimSpace(group+" "+verb+" "+cmd.usage), cmd.summary)
+		}
+	}
+	text.WriteString("\nPROJECT and TASK are ids, COLUMN is an id or a name.\n\nFlags:\n")
+	fs.SetOutput(&text)
+	fs.PrintDefaults()
+	fs.SetOutput(io.Discard)
+	return text.String()
+}
+
+//===========================================================================//
+
+// flags parses the flags of a command from args and checks that n arguments
+// follow them.
+func (r *runner) flags(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w\n\nUsage: %s", err, r.usage)
+	}
+	if fs.NArg() != n && !(n < 0 && fs.NArg() >= -n) {
+		return nil, fmt.Errorf("usage: %s", r.usage)
+	}
+	return fs.Args(), nil
+}
+
+// args checks that a command without flags got n arguments, at least -n
+// when n is negative.
+func (r *runner) args(args []string, n int) ([]string, error) {
+	return r.flags(flag.NewFlagSet(name, flag.ContinueOnError), args, n)
+}
+
+// id parses the argument what of a command as an id.
+func id(what, arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%s %q isn't an id", what, arg)
+	}
+	return id, nil
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/client"
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	t.Cleanup(func() { repo.Close() })
+	cfg := config.Default()
+	cfg.RateLimit.Enabled = false
+	server := httptest.NewServer(handler.NewRouter(cfg, repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop()))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func noEnv(string) string { return "" }
+
+// TestRun runs the commands in order against the same server, so that later
+// ones work on what earlier ones created.
+func TestRun(t *testing.T) {
+	server := newServer(t)
+	tests := []struct {
+		args   string
+		output string
+		err    string
+	}{
+		{
+			args:   "projects create -description launch board",
+			output: "ID  NAME   DESCRIPTION\n1   board  launch\n",
+		},
+		{
+			args:   "projects ls",
+			output: "ID  NAME   DESCRIPTION\n1   board  launch\n",
+		},
+		{
+			args:   "-o json projects ls",
+			output: "[\n  {\n    \"id\": 1,\n    \"name\": \"board\",\n    \"description\": \"launch\"\n  }\n]\n",
+		},
+		{
+			args:   "columns add 1 doing",
+			output: "ID  NAME   ORDER  STATUS  TASKS\n2   doing  1      -       0\n",
+		},
+		{
+			args:   "columns add -status closed 1 done",
+			output: "ID  NAME  ORDER  STATUS  TASKS\n3   done  2      closed  0\n",
+		},
+		{
+			args: "columns reorder 1 done doing",
+			output: "ID  NAME           ORDER  STATUS  TASKS\n" +
+				"3   done           1      closed  0\n" +
+				"2   doing          2      -       0\n" +
+				"1   board_default  3      -       0\n",
+		},
+		{
+			args:   "tasks add -description first -due 2026-11-02 1 doing write",
+			output: "ID  COLUMN  NAME   DONE  DUE\n1   doing   write  no    2026-11-02 00:00\n",
+		},
+		{
+			args:   "tasks add 1 2 review",
+			output: "ID  COLUMN  NAME    DONE  DUE\n2   doing   review  no    -\n",
+		},
+		{
+			args:   "tasks mv 1 2 done",
+			output: "ID  COLUMN  NAME    DONE  DUE\n2   done    review  no    -\n",
+		},
+		{
+			args:   "tasks done 1 1",
+			output: "ID  COLUMN  NAME   DONE  DUE\n1   doing   write  yes   2026-11-02 00:00\n",
+		},
+		{
+			args:   "tasks ls 1",
+			output: "ID  COLUMN  NAME    DONE  DUE\n2   done    review  no    -\n1   doing   write   yes   2026-11-02 00:00\n",
+		},
+		{
+			args:   "tasks ls -open -column doing 1",
+			output: "ID  COLUMN  NAME  DONE  DUE\n",
+		},
+		{
+			args:   "-o json tasks ls -column done 1",
+			output: "[\n  {\n    \"id\": 2,\n    \"name\": \"review\",\n    \"status\": false,\n    \"description\": \"\",\n    \"column_id\": 3,\n    \"due_date\": null\n  }\n]\n",
+		},
+		{
+			args:   "comments add 1 1 looks-good",
+			output: "ID  TASK  DESCRIPTION\n1   1     looks-good\n",
+		},
+		{
+			args: "tasks mv 1 1 nope",
+			err:  `no column "nope" in the project`,
+		},
+		{
+			args: "tasks done 1 9",
+			err:  "no task 9 in project 1",
+		},
+		{
+			args: "columns add 1",
+			err:  "usage: trello-cli columns add [-order n] [-status text] PROJECT NAME",
+		},
+		{
+			args: "projects rm x",
+			err:  `project "x" isn't an id`,
+		},
+		{
+			args: "projects rm 1",
+		},
+		{
+			args:   "projects ls",
+			output: "ID  NAME  DESCRIPTION\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			args := append([]string{"-config", "", "-url", server.URL}, strings.Fields(tt.args)...)
+			var stdout bytes.Buffer
+			err := Run(context.Background(), args, noEnv, &stdout)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, stdout.String())
+		})
+	}
+}
+
+// TestRunRemovesProjectContents checks that projects rm leaves nothing of the
+// project behind.
+func TestRunRemovesProjectContents(t *testing.T) {
+	server := newServer(t)
+	for _, args := range []string{
+		"projects create board",
+		"tasks add 1 board_default write",
+		"comments add 1 1 first",
+		"projects rm 1",
+	} {
+		err := Run(context.Background(), append([]string{"-config", "", "-url", server.URL}, strings.Fields(args)...), noEnv, &bytes.Buffer{})
+		assert.NoError(t, err, args)
+	}
+
+	c := client.New(server.URL)
+	columns, err := c.ListColumns(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, columns)
+	tasks, err := c.ListTasks(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+	comments, err := c.ListComments(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		help bool
+		err  string
+	}{
+		{name: "no command", args: nil, help: true},
+		{name: "help", args: []string{"-h"}, help: true},
+		{name: "unknown command", args: []string{"boards", "ls"}, err: `unknown command "boards"`},
+		{name: "unknown verb", args: []string{"projects", "mv"}, err: `unknown command "projects mv"`},
+		{name: "unknown output", args: []string{"-config", "", "-o", "yaml", "projects", "ls"}, err: `output "yaml" isn't table or json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(context.Background(), tt.args, noEnv, &bytes.Buffer{})
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.help, errors.Is(err, flag.ErrHelp))
+				assert.True(t, strings.HasPrefix(err.Error(), tt.err), err.Error())
+			}
+		})
+	}
+}
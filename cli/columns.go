@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+func columnTable(columns []dal.ExtendedColumn) *table {
+	t := &table{header: []string{"ID", "NAME", "ORDER", "STATUS", "TASKS"}}
+	for _, column := range columns {
+		status := column.Status
+		if status == "" {
+			status = "-"
+		}
+		t.add(column.ID, column.Name, column.OrderNum, status, len(column.Tasks))
+	}
+	return t
+}
+
+// projectColumns returns the columns of a project, ordered by their order
+// numbers.
+func (r *runner) projectColumns(projectID int) ([]dal.ExtendedColumn, error) {
+	columns, err := r.client.ListProjectColumns(r.ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		if columns[i].OrderNum != columns[j].OrderNum {
+			return columns[i].OrderNum < columns[j].OrderNum
+		}
+		return columns[i].ID < columns[j].ID
+	})
+	return columns, nil
+}
+
+// findColumn finds the column arg, an id or a name, among columns.
+func findColumn(columns []dal.ExtendedColumn, arg string) (*dal.ExtendedColumn, error) {
+	columnID, _ := strconv.Atoi(arg)
+	for i, column := range columns {
+		if column.ID == columnID || column.Name == arg {
+			return &columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no column %q in the project", arg)
+}
+
+// printColumns prints the columns of a project, or only those of ids.
+func (r *runner) printColumns(projectID int, ids ...int) error {
+	columns, err := r.projectColumns(projectID)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		var picked []dal.ExtendedColumn
+		for _, column := range columns {
+			for _, columnID := range ids {
+				if column.ID == columnID {
+					picked = append(picked, column)
+				}
+			}
+		}
+		columns = picked
+	}
+	plain := make([]dal.Column, len(columns))
+	for i, column := range columns {
+		plain[i] = column.Column
+	}
+	return r.print(plain, columnTable(columns))
+}
+
+//===========================================================================//
+
+func listColumns(r *runner, args []string) error {
+	args, err := r.args(args, 1)
+	if err != nil {
+		return err
+	}
+	projectID, err := id("project", args[0])
+	if err != nil {
+		return err
+	}
+	return r.printColumns(projectID)
+}
+
+func addColumn(r *runner, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	order := fs.Int("order", 0, "order number of the column, after the last one if 0")
+	status := fs.String("status", "", "status of the column")
+	args, err := r.flags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	projectID, err := id("project", args[0])
+	if err != nil {
+		return err
+	}
+	if *order == 0 {
+		columns, err := r.projectColumns(projectID)
+		if err != nil {
+			return err
+		}
+		*order = 1
+		if len(columns) > 0 {
+			*order = columns[len(columns)-1].OrderNum + 1
+		}
+	}
+	column := &dal.Column{Name: args[1], ProjectID: projectID, OrderNum: *order, Status: *status}
+	if err := r.client.CreateColumn(r.ctx, column); err != nil {
+		return err
+	}
+
+	// column names are unique, the server doesn't answer with the column
+	columns, err := r.projectColumns(projectID)
+	if err != nil {
+		return err
+	}
+	created, err := findColumn(columns, args[1])
+	if err != nil {
+		return err
+	}
+	return r.printColumns(projectID, created.ID)
+}
+
+// reorderColumns numbers the columns given from 1 in their order, and the
+// other columns of the project after them in the order they had.
+func reorderColumns(r *runner, args []string) error {
+	args, err := r.args(args, -2)
+	if err != nil {
+		return err
+	}
+	projectID, err := id("project", args[0])
+	if err != nil {
+		return err
+	}
+	columns, err := r.projectColumns(projectID)
+	if err != nil {
+		return err
+	}
+
+	var ordered []dal.Column
+	moved := map[int]bool{}
+	for _, arg := range args[1:] {
+		column, err := findColumn(columns, arg)
+		if err != nil {
+			return err
+		}
+		if moved[column.ID] {
+			return fmt.Errorf("column %q is given twice", arg)
+		}
+		moved[column.ID] = true
+		ordered = append(ordered, column.Column)
+	}
+	for _, column := range columns {
+		if !moved[column.ID] {
+			ordered = append(ordered, column.Column)
+		}
+	}
+	for i, column := range ordered {
+		if column.OrderNum == i+1 {
+			continue
+		}
+		column.OrderNum = i + 1
+		if err := r.client.UpdateColumn(r.ctx, &column); err != nil {
+			return err
+		}
+	}
+	return r.printColumns(projectID)
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+func commentTable(comments ...dal.Comment) *table {
+	t := &table{header: []string{"ID", "TASK", "DESCRIPTION"}}
+	for _, comment := range comments {
+		t.add(comment.ID, comment.TaskID, comment.Description)
+	}
+	return t
+}
+
+// addComment comments a task. The server creates comments without their text
+// and doesn't answer with them, so the text is set on the newest empty comment
+// of the task afterwards.
+func addComment(r *runner, args []string) error {
+	args, err := r.args(args, 3)
+	if err != nil {
+		return err
+	}
+	b, err := r.board(args[0])
+	if err != nil {
+		return err
+	}
+	task, err := b.task(args[1])
+	if err != nil {
+		return err
+	}
+	comment := &dal.Comment{TaskID: task.ID, Description: args[2]}
+	if err := r.client.CreateComment(r.ctx, b.ID, task.ColumnID, comment); err != nil {
+		return err
+	}
+
+	comments, err := r.client.ListTaskComments(r.ctx, b.ID, task.ColumnID, task.ID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range comments {
+		if existing.Description == "" && existing.ID > comment.ID {
+			comment.ID = existing.ID
+		}
+	}
+	if comment.ID == 0 {
+		return fmt.Errorf("the comment of task %d was created but isn't listed", task.ID)
+	}
+	if err := r.client.UpdateComment(r.ctx, b.ID, task.ColumnID, comment); err != nil {
+		return err
+	}
+	return r.print(comment, commentTable(*comment))
+}
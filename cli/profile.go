@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultURL     = "http://127.0.0.1:4040"
+	DefaultProfile = "default"
+
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Profile is a server the CLI calls and how it calls it. Profiles are kept in
+// a YAML file under a name each:
+//
+//	default: local
+//	profiles:
+//	  local:
+//	    url: http://127.0.0.1:4040
+//	  prod:
+//	    url: https://golang-school-project-boobuh.herokuapp.com
+//	    token: secret
+//	    api_key: team-a
+//	    output: json
+type Profile struct {
+	URL    string `yaml:"url"`
+	Token  string `yaml:"token"`
+	APIKey string `yaml:"api_key"`
+	// Output is the format results are printed in, table or json.
+	Output string `yaml:"output"`
+}
+
+type profileFile struct {
+	// Default is the profile used when none is asked for.
+	Default  string             `yaml:"default"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// DefaultConfigPath is the profile file read unless -config names another:
+// $TRELLO_CLI_CONFIG, or trello-cli/config.yaml in the user config directory.
+func DefaultConfigPath(getenv func(string) string) string {
+	if path := getenv("TRELLO_CLI_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "trello-cli", "config.yaml")
+}
+
+// LoadProfile reads the profile name from the file at path; an empty name is
+// the default profile of the file. Without a file, or without a default
+// profile in it, the CLI calls a local server.
+func LoadProfile(path, name string) (*Profile, error) {
+	file := profileFile{}
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("can't read profiles: %w", err)
+		default:
+			decoder := yaml.NewDecoder(bytes.NewReader(raw))
+			decoder.KnownFields(true)
+			if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("can't parse profiles %s: %w", path, err)
+			}
+		}
+	}
+
+	explicit := name != ""
+	if !explicit {
+		name = file.Default
+		if name == "" {
+			name = DefaultProfile
+		}
+	}
+	profile, ok := file.Profiles[name]
+	if !ok && (explicit || file.Default != "") {
+		return nil, fmt.Errorf("no profile %q in %s", name, path)
+	}
+	if profile.URL == "" {
+		profile.URL = DefaultURL
+	}
+	if profile.Output == "" {
+		profile.Output = OutputTable
+	}
+	if err := checkOutput(profile.Output); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return &profile, nil
+}
+
+func checkOutput(output string) error {
+	if output != OutputTable && output != OutputJSON {
+		return fmt.Errorf("output %q isn't table or json", output)
+	}
+	return nil
+}
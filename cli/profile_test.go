@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfile(t *testing.T) {
+	const profiles = `
+default: local
+profiles:
+  local:
+    url: http://127.0.0.1:5000
+  prod:
+    url: https://example.com
+    token: secret
+    api_key: team-a
+    output: json
+`
+	tests := []struct {
+		name     string
+		file     string
+		profile  string
+		expected *Profile
+		err      string
+	}{
+		{
+			name:     "no file",
+			expected: &Profile{URL: DefaultURL, Output: OutputTable},
+		},
+		{
+			name:     "default profile of the file",
+			file:     profiles,
+			expected: &Profile{URL: "http://127.0.0.1:5000", Output: OutputTable},
+		},
+		{
+			name:     "named profile",
+			file:     profiles,
+			profile:  "prod",
+			expected: &Profile{URL: "https://example.com", Token: "secret", APIKey: "team-a", Output: OutputJSON},
+		},
+		{
+			name:     "file without a default",
+			file:     "profiles:\n  other:\n    url: http://other\n",
+			expected: &Profile{URL: DefaultURL, Output: OutputTable},
+		},
+		{
+			name:    "missing profile",
+			file:    profiles,
+			profile: "staging",
+			err:     `no profile "staging" in `,
+		},
+		{
+			name: "unknown field",
+			file: "profiles:\n  local:\n    addr: http://127.0.0.1\n",
+			err:  "can't parse profiles ",
+		},
+		{
+			name: "unknown output",
+			file: "profiles:\n  default:\n    output: yaml\n",
+			err:  `profile "default": output "yaml" isn't table or json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.file != "" {
+				assert.NoError(t, os.WriteFile(path, []byte(tt.file), 0o600))
+			}
+			profile, err := LoadProfile(path, tt.profile)
+			if tt.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, profile)
+		})
+	}
+}
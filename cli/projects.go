@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"flag"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+func projectTable(projects ...dal.Project) *table {
+	t := &table{header: []string{"ID", "NAME", "DESCRIPTION"}}
+	for _, project := range projects {
+		t.add(project.ID, project.Name, project.Description)
+	}
+	return t
+}
+
+func listProjects(r *runner, args []string) error {
+	if _, err := r.args(args, 0); err != nil {
+		return err
+	}
+	projects, err := r.client.ListProjects(r.ctx)
+	if err != nil {
+		return err
+	}
+	return r.print(projects, projectTable(projects...))
+}
+
+func createProject(r *runner, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	description := fs.String("description", "", "description of the project")
+	args, err := r.flags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	created := &dal.Project{Name: args[0], Description: *description}
+	if err := r.client.CreateProject(r.ctx, created); err != nil {
+		return err
+	}
+	return r.print(created, projectTable(*created))
+}
+
+func removeProjects(r *runner, args []string) error {
+	args, err := r.args(args, -1)
+	if err != nil {
+		return err
+	}
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		if ids[i], err = id("project", arg); err != nil {
+			return err
+		}
+	}
+	for _, projectID := range ids {
+		if err := r.client.DeleteProject(r.ctx, projectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
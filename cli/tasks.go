@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// board is a project with its columns in order, which task commands look up
+// columns and tasks in.
+type board struct {
+	*dal.ExtendedProjectEntities
+}
+
+func (r *runner) board(arg string) (*board, error) {
+	projectID, err := id("project", arg)
+	if err != nil {
+		return nil, err
+	}
+	project, err := r.client.GetProject(r.ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(project.Columns, func(i, j int) bool {
+		return project.Columns[i].OrderNum < project.Columns[j].OrderNum
+	})
+	return &board{project}, nil
+}
+
+func (b *board) column(arg string) (*dal.ExtendedColumn, error) {
+	return findColumn(b.Columns, arg)
+}
+
+func (b *board) task(arg string) (*dal.Task, error) {
+	taskID, err := id("task", arg)
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range b.Columns {
+		for _, task := range column.Tasks {
+			if task.ID == taskID {
+				found := task.Task
+				return &found, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no task %d in project %d", taskID, b.ID)
+}
+
+func (b *board) taskTable(tasks ...dal.Task) *table {
+	names := map[int]string{}
+	for _, column := range b.Columns {
+		names[column.ID] = column.Name
+	}
+	t := &table{header: []string{"ID", "COLUMN", "NAME", "DONE", "DUE"}}
+	for _, task := range tasks {
+		t.add(task.ID, names[task.ColumnID], task.Name, done(task.Status), date(task.DueDate))
+	}
+	return t
+}
+
+// apply runs operation through the task batch route, which answers with the
+// task, unlike the routes of single tasks.
+func (r *runner) apply(projectID int, op string, task dal.Task) (*dal.Task, error) {
+	results, err := r.client.BatchTasks(r.ctx, projectID, &dal.TaskBatch{
+		Mode:       dal.BatchModeAtomic,
+		Operations: []dal.TaskOperation{{Op: op, Task: task}},
+	})
+	if len(results) == 1 && results[0].Error != "" {
+		return nil, errors.New(results[0].Error)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 || results[0].Task == nil {
+		return nil, fmt.Errorf("the server didn't answer with the task")
+	}
+	return results[0].Task, nil
+}
+
+//===========================================================================//
+
+func listTasks(r *runner, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	columnArg := fs.String("column", "", "list only the tasks of this column")
+	open := fs.Bool("open", false, "list only the tasks that aren't done")
+	args, err := r.flags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	b, err := r.board(args[0])
+	if err != nil {
+		return err
+	}
+	columns := b.Columns
+	if *columnArg != "" {
+		column, err := b.column(*columnArg)
+		if err != nil {
+			return err
+		}
+		columns = []dal.ExtendedColumn{*column}
+	}
+
+	tasks := []dal.Task{}
+	for _, column := range columns {
+		for _, task := range column.Tasks {
+			if !*open || !task.Status {
+				tasks = append(tasks, task.Task)
+			}
+		}
+	}
+	return r.print(tasks, b.taskTable(tasks...))
+}
+
+func addTask(r *runner, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	description := fs.String("description", "", "description of the task")
+	due := fs.String("due", "", "due date, e.g. 2026-11-02 or 2026-11-02T10:00:00Z")
+	args, err := r.flags(fs, args, 3)
+	if err != nil {
+		return err
+	}
+	task := dal.Task{Name: args[2], Description: *description}
+	if *due != "" {
+		dueDate, err := parseDate(*due)
+		if err != nil {
+			return err
+		}
+		task.DueDate = &dueDate
+	}
+	b, err := r.board(args[0])
+	if err != nil {
+		return err
+	}
+	column, err := b.column(args[1])
+	if err != nil {
+		return err
+	}
+	task.ColumnID = column.ID
+
+	created, err := r.apply(b.ID, dal.TaskOpCreate, task)
+	if err != nil {
+		return err
+	}
+	return r.print(created, b.taskTable(*created))
+}
+
+func moveTask(r *runner, args []string) error {
+	args, err := r.args(args, 3)
+	if err != nil {
+		return err
+	}
+	b, err := r.board(args[0])
+	if err != nil {
+		return err
+	}
+	task, err := b.task(args[1])
+	if err != nil {
+		return err
+	}
+	column, err := b.column(args[2])
+	if err != nil {
+		return err
+	}
+	task.ColumnID = column.ID
+
+	moved, err := r.apply(b.ID, dal.TaskOpMove, *task)
+	if err != nil {
+		return err
+	}
+	return r.print(moved, b.taskTable(*moved))
+}
+
+func markTaskDone(r *runner, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	undo := fs.Bool("undo", false, "mark the task not done instead")
+	args, err := r.flags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	b, err := r.board(args[0])
+	if err != nil {
+		return err
+	}
+	task, err := b.task(args[1])
+	if err != nil {
+		return err
+	}
+	task.Status = !*undo
+
+	updated, err := r.apply(b.ID, dal.TaskOpUpdate, *task)
+	if err != nil {
+		return err
+	}
+	return r.print(updated, b.taskTable(*updated))
+}
+
+// parseDate parses a due date, a day or a time in RFC 3339.
+func parseDate(value string) (time.Time, error) {
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("due date %q isn't a day like 2026-11-02 or a time in RFC 3339", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,327 @@
+// Package client calls the API of the server in handler.NewRouter with the
+// models of package dal, so that tools don't have to write the requests by
+// hand.
+//
+//	c := client.New("http://127.0.0.1:4040").WithToken(token)
+//	board, err := c.GetProject(ctx, 1)
+//
+// Calls that are safe to send twice are retried on network errors, 429 and
+// 502 to 504 responses: GET, PUT and DELETE requests, and the POST requests
+// the server deduplicates by their Idempotency-Key header, which the client
+// sets. Error responses are returned as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Version is the prefix of the API version the client calls.
+	Version = "/v1"
+
+	DefaultRetries = 3
+	DefaultBackoff = 100 * time.Millisecond
+	// maxBackoff caps the growing delay between attempts, a Retry-After sent
+	// by the server is waited for in full.
+	maxBackoff = 5 * time.Second
+
+	jsonContentType = "application/json"
+	// idempotencyKeyHeader is the header the server deduplicates POST
+	// requests by.
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// Client calls the API. Its With methods configure it and return it, they
+// aren't meant to be called once it is in use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	apiKey     string
+	userAgent  string
+	retries    int
+	backoff    time.Duration
+}
+
+// New returns a client of the server at baseURL, e.g. http://127.0.0.1:4040.
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		userAgent:  "golang-school-project-client",
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+	}
+}
+
+// WithToken sends token as a bearer token in the Authorization header.
+func (c *Client) WithToken(token string) *Client {
+	c.token = token
+	return c
+}
+
+// WithAPIKey sends key in the X-API-Key header, which gives the client rate
+// limits of its own.
+func (c *Client) WithAPIKey(key string) *Client {
+	c.apiKey = key
+	return c
+}
+
+// WithHTTPClient sends the requests with httpClient instead of
+// http.DefaultClient, e.g. one with a timeout or a custom transport.
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
+// WithRetries sends a call that is safe to repeat up to retries more times,
+// waiting backoff before the first retry and twice as long before every
+// following one. Zero retries turns retrying off.
+func (c *Client) WithRetries(retries int, backoff time.Duration) *Client {
+	c.retries = retries
+	c.backoff = backoff
+	return c
+}
+
+//===========================================================================//
+
+// request is a call of the API. Its body is kept to send it again.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        []byte
+	// idempotencyKey is sent with POST requests the server deduplicates,
+	// the same key on every attempt.
+	idempotencyKey string
+	// once turns retrying off for a request whose failure is its answer.
+	once bool
+}
+
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func newRequest(method, path string) *request {
+	return &request{method: method, path: path}
+}
+
+// withJSON marshals v into the body.
+func (r *request) withJSON(v interface{}) (*request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal the request body: %w", err)
+	}
+	r.contentType = jsonContentType
+	r.body = body
+	return r, nil
+}
+
+// deduplicated marks a POST request the server deduplicates by its key.
+func (r *request) deduplicated() *request {
+	r.idempotencyKey = newIdempotencyKey()
+	return r
+}
+
+func (r *request) retryable() bool {
+	if r.once {
+		return false
+	}
+	switch r.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.idempotencyKey != ""
+}
+
+//---------------------------------------------------------------------------//
+
+// send sends req, again as long as that is safe and the failure is likely to
+// pass, and returns the last response. A response with an error status is
+// returned together with its *Error.
+func (c *Client) send(ctx context.Context, req *request) (*response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendOnce(ctx, req)
+		if err == nil && resp.status < http.StatusBadRequest {
+			return resp, nil
+		}
+		if err == nil {
+			err = newError(req, resp)
+		}
+		if attempt >= c.retries || !req.retryable() || !temporary(ctx, resp, err) {
+			return resp, err
+		}
+		if waitErr := sleep(ctx, c.delay(attempt, resp)); waitErr != nil {
+			return resp, err
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, req *request) (*response, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set(idempotencyKeyHeader, req.idempotencyKey)
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set("X-API-Key", c.apiKey)
+	}
+	httpReq.Header.Set("User-Agent", c.userAgent)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	payload, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the response of %s %s: %w", req.method, req.path, err)
+	}
+	return &response{status: httpResp.StatusCode, header: httpResp.Header, body: payload}, nil
+}
+
+// temporary reports whether a failed attempt may succeed when repeated.
+func temporary(ctx context.Context, resp *response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// the request didn't get an answer
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay is the wait before retry attempt+1: the Retry-After of the response
+// if it has one, or else a backoff doubling with every attempt, of which a
+// random half is waited for so that many clients don't retry in step.
+func (c *Client) delay(attempt int, resp *response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	backoff := c.backoff << uint(attempt)
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+	return backoff/2 + time.Duration(mathrand.Int63n(int64(backoff/2)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("can't generate an idempotency key: %v", err))
+	}
+	return hex.EncodeToString(key)
+}
+
+//---------------------------------------------------------------------------//
+
+// call sends req and decodes the JSON of the response into out, unless out is
+// nil.
+func (c *Client) call(ctx context.Context, req *request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	return decode(req, resp, out)
+}
+
+func decode(req *request, resp *response, out interface{}) error {
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.body, out); err != nil {
+		return fmt.Errorf("can't decode the response of %s %s: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// get sends a GET request for path and decodes the response into out.
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	return c.call(ctx, newRequest(http.MethodGet, path), out)
+}
+
+// sendJSON sends in as the body of a method request for path and decodes the
+// response into out.
+func (c *Client) sendJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	req, err := newRequest(method, path).withJSON(in)
+	if err != nil {
+		return err
+	}
+	if method == http.MethodPost {
+		req.deduplicated()
+	}
+	return c.call(ctx, req, out)
+}
+
+// raw sends a GET request for path and returns the body of the response as it
+// is, e.g. a rendered document.
+func (c *Client) raw(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	req := newRequest(http.MethodGet, path)
+	req.query = query
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// path is the versioned path of format with its string segments escaped.
+func path(format string, segments ...interface{}) string {
+	for i, segment := range segments {
+		if s, ok := segment.(string); ok {
+			segments[i] = url.PathEscape(s)
+		}
+	}
+	return Version + fmt.Sprintf(format, segments...)
+}
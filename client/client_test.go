@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
+	"github.com/Boobuh/golang-school-project/service/trello"
+)
+
+// testServer serves the real router and records the documented operations
+// the client calls.
+type testServer struct {
+	*httptest.Server
+	doc *openapi.Document
+
+	mu     sync.Mutex
+	called map[*openapi.Operation]bool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	t.Cleanup(func() { repo.Close() })
+	cfg := config.Default()
+	cfg.RateLimit.Enabled = false
+	router := handler.NewRouter(cfg, repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc openapi.Document
+	if err := json.Unmarshal(response.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("can't decode the document: %v", err)
+	}
+
+	s := &testServer{doc: &doc, called: map[*openapi.Operation]bool{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			template, _ := match.Route.GetPathTemplate()
+			s.mu.Lock()
+			s.called[doc.Operation(r.Method, template)] = true
+			s.mu.Unlock()
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// uncalled lists the documented operations the client didn't call.
+func (s *testServer) uncalled() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var missing []string
+	for path, item := range s.doc.Paths {
+		for method, operation := range item {
+			if !s.called[operation] {
+				missing = append(missing, strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+//===========================================================================//
+
+// TestClient calls every route of the router through the client, and fails
+// when a documented operation has no method calling it.
+func TestClient(t *testing.T) {
+	server := newTestServer(t)
+	c := New(server.URL)
+	ctx := context.Background()
+	due := time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC)
+
+	status, err := c.Health(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", status.Status)
+	status, err = c.Ready(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", status.Status)
+	_, err = c.Version(ctx)
+	assert.NoError(t, err)
+	doc, err := c.OpenAPI(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, doc.Paths)
+
+	// projects
+	created := &dal.Project{Name: "board", Description: "client"}
+	assert.NoError(t, c.CreateProject(ctx, created))
+	assert.Equal(t, 1, created.ID)
+	projects, err := c.ListProjects(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []dal.Project{{ID: 1, Name: "board", Description: "client"}}, projects)
+	assert.NoError(t, c.UpdateProject(ctx, &dal.Project{ID: 1, Name: "renamed", Description: "client"}))
+
+	// columns
+	assert.NoError(t, c.CreateColumn(ctx, &dal.Column{Name: "doing", ProjectID: 1, OrderNum: 2}))
+	assert.NoError(t, c.UpdateColumn(ctx, &dal.Column{ID: 2, Name: "doing", ProjectID: 1, OrderNum: 2, Status: "open"}))
+	columns, err := c.ListColumns(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, columns, 2)
+	projectColumns, err := c.ListProjectColumns(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, projectColumns, 2)
+	column, err := c.GetColumn(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "open", column.Status)
+
+	// tasks
+	assert.NoError(t, c.CreateTask(ctx, 1, &dal.Task{Name: "write", ColumnID: 2, DueDate: &due}))
+	tasks, err := c.ListTasks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	columnTasks, err := c.ListColumnTasks(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, columnTasks, 1)
+	task, err := c.GetTask(ctx, 1, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "write", task.Name)
+	if assert.NotNil(t, task.DueDate) {
+		assert.True(t, due.Equal(*task.DueDate))
+	}
+	assert.NoError(t, c.UpdateTask(ctx, 1, &dal.Task{ID: 1, Name: "written", ColumnID: 2}))
+	task, err = c.GetTask(ctx, 1, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "written", task.Name)
+
+	results, err := c.BatchTasks(ctx, 1, &dal.TaskBatch{Mode: dal.BatchModePerItem, Operations: []dal.TaskOperation{
+		{Op: dal.TaskOpCreate, Task: dal.Task{Name: "batched", ColumnID: 2}},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	results, err = c.BatchTasks(ctx, 1, &dal.TaskBatch{Mode: dal.BatchModeAtomic, Operations: []dal.TaskOperation{
+		{Op: dal.TaskOpCreate, Task: dal.Task{Name: "batched", ColumnID: 2}},
+		{Op: dal.TaskOpDelete, Task: dal.Task{ID: 999}},
+	}})
+	assert.True(t, errors.Is(err, ErrBadRequest), "%v", err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, dal.TaskOpStatusRolledBack, results[0].Status)
+	}
+
+	csv := []byte("column,name,description,status\ndoing,imported,from a sheet,false\n")
+	report, err := c.ImportTasksCSV(ctx, 1, csv, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &dal.TaskImportReport{DryRun: true, Created: 1, CreatedColumns: []string{}, Errors: []dal.TaskImportRowError{}}, report)
+	report, err = c.ImportTasksCSV(ctx, 1, []byte("column,name\ndoing,\n"), false)
+	assert.True(t, errors.Is(err, ErrBadRequest), "%v", err)
+	if assert.NotNil(t, report) {
+		assert.Len(t, report.Errors, 1)
+	}
+	_, err = c.ImportTasksCSV(ctx, 1, csv, false)
+	assert.NoError(t, err)
+	exported, err := c.ExportTasksCSV(ctx, 1)
+	assert.NoError(t, err)
+	assert.Contains(t, string(exported), "imported")
+
+	// comments
+	assert.NoError(t, c.CreateComment(ctx, 1, 2, &dal.Comment{Description: "first", TaskID: 1}))
+	assert.NoError(t, c.UpdateComment(ctx, 1, 2, &dal.Comment{ID: 1, Description: "edited", TaskID: 1}))
+	comments, err := c.ListComments(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	taskComments, err := c.ListTaskComments(ctx, 1, 2, 1)
+	assert.NoError(t, err)
+	assert.Len(t, taskComments, 1)
+	comment, err := c.GetComment(ctx, 1, 2, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", comment.Description)
+
+	// whole projects
+	project, err := c.GetProject(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", project.Name)
+	archive, err := c.ExportProject(ctx, 1)
+	assert.NoError(t, err)
+	rendered, err := c.RenderProject(ctx, 1, "md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "renamed")
+	feed, err := c.CreateCalendarFeed(ctx, 1)
+	assert.NoError(t, err)
+	calendar, err := c.CalendarFeed(ctx, feed.Token, "VTODO")
+	assert.NoError(t, err)
+	assert.Contains(t, string(calendar), "BEGIN:VCALENDAR")
+
+	// importing a board next to itself renames the columns, their names are taken
+	imported, err := c.ImportProject(ctx, archive)
+	assert.NoError(t, err)
+	if assert.NotNil(t, imported.Project) {
+		assert.NotEqual(t, 1, imported.Project.ID)
+	}
+	assert.Len(t, imported.Converted, len(archive.Project.Columns))
+	trelloReport, err := c.ImportTrelloBoard(ctx, &trello.Board{
+		ID: "b1", Name: "trello",
+		Lists: []trello.List{{ID: "l1", Name: "backlog", Pos: 1}},
+		Cards: []trello.Card{{ID: "c1", Name: "card", IDList: "l1", Pos: 1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trelloReport.Tasks)
+
+	// GraphQL
+	schema, err := c.GraphQLSchema(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, schema, "type Query")
+	var data struct {
+		Project struct {
+			Name    string
+			Columns []struct{ Name string }
+		}
+	}
+	assert.NoError(t, c.GraphQL(ctx, `query ($id: Int!) { project(id: $id) { name columns { name } } }`, map[string]interface{}{"id": 1}, &data))
+	assert.Equal(t, "renamed", data.Project.Name)
+	assert.Len(t, data.Project.Columns, 2)
+	var gqlErrs GraphQLErrors
+	assert.True(t, errors.As(c.GraphQL(ctx, `{ nope }`, nil, nil), &gqlErrs))
+
+	// deletes
+	assert.NoError(t, c.DeleteComment(ctx, 1, 2, 1, 1))
+	assert.NoError(t, c.DeleteTask(ctx, 1, 2, 1))
+	assert.NoError(t, c.DeleteColumn(ctx, 1, 2))
+	assert.NoError(t, c.DeleteProject(ctx, 1))
+	// the server answers a missing record with 400
+	_, err = c.GetProject(ctx, 1)
+	assert.True(t, errors.Is(err, ErrBadRequest), "%v", err)
+
+	metricsText, err := c.Metrics(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, metricsText)
+
+	for _, operation := range server.uncalled() {
+		// the documentation page is for browsers
+		if operation != "GET /docs" {
+			t.Errorf("%s isn't called by the client", operation)
+		}
+	}
+}
+
+//===========================================================================//
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		call     func(c *Client) error
+		failures int
+		attempts int
+		success  bool
+		// response is the body of a successful attempt, [] when empty
+		response string
+	}{
+		{
+			name:     "a GET is retried",
+			method:   http.MethodGet,
+			call:     func(c *Client) error { _, err := c.ListProjects(context.Background()); return err },
+			failures: 2,
+			attempts: 3,
+			success:  true,
+		},
+		{
+			name:     "a deduplicated POST is retried with the same key",
+			method:   http.MethodPost,
+			call:     func(c *Client) error { return c.CreateProject(context.Background(), &dal.Project{Name: "board"}) },
+			failures: 1,
+			attempts: 2,
+			success:  true,
+			response: `{"id":1,"name":"board","description":""}`,
+		},
+		{
+			name:   "a GraphQL query isn't retried",
+			method: http.MethodPost,
+			call: func(c *Client) error {
+				return c.GraphQL(context.Background(), "{ projects { id } }", nil, nil)
+			},
+			failures: 1,
+			attempts: 1,
+		},
+		{
+			name:     "retries give up",
+			method:   http.MethodDelete,
+			call:     func(c *Client) error { return c.DeleteProject(context.Background(), 1) },
+			failures: 10,
+			attempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			keys := map[string]bool{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.method, r.Method)
+				attempts++
+				keys[r.Header.Get(idempotencyKeyHeader)] = true
+				if attempts <= tt.failures {
+					http.Error(w, "try again", http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if tt.response == "" {
+					tt.response = `[]`
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			err := tt.call(New(server.URL).WithRetries(2, time.Millisecond))
+			assert.Equal(t, tt.attempts, attempts)
+			assert.Len(t, keys, 1)
+			if tt.success {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrUnavailable), "%v", err)
+			}
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		switch r.URL.Path {
+		case "/v1/projects/1":
+			http.Error(w, "record not found", http.StatusNotFound)
+		default:
+			w.Header().Set("Retry-After", "7")
+			http.Error(w, ratelimit.Message, http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+	c := New(server.URL).WithRetries(0, 0)
+
+	_, err := c.GetProject(context.Background(), 1)
+	var apiErr *Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.Equal(t, "record not found", apiErr.Message)
+		assert.Equal(t, "req-1", apiErr.RequestID)
+		assert.Equal(t, "GET /v1/projects/1: 404 record not found", err.Error())
+	}
+
+	_, err = c.ListProjects(context.Background())
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.True(t, errors.Is(err, ErrRateLimited))
+		assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "key-1", r.Header.Get("X-API-Key"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL).WithToken("secret").WithAPIKey("key-1").ListProjects(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// ListColumns returns the columns of every project.
+func (c *Client) ListColumns(ctx context.Context) ([]dal.Column, error) {
+	var columns []dal.Column
+	return columns, c.get(ctx, path("/columns/"), &columns)
+}
+
+// ListProjectColumns returns the columns of a project with their tasks.
+func (c *Client) ListProjectColumns(ctx context.Context, projectID int) ([]dal.ExtendedColumn, error) {
+	var columns []dal.ExtendedColumn
+	return columns, c.get(ctx, path("/projects/%d/columns/", projectID), &columns)
+}
+
+// GetColumn returns a column with its tasks.
+func (c *Client) GetColumn(ctx context.Context, projectID, columnID int) (*dal.ExtendedColumn, error) {
+	var column dal.ExtendedColumn
+	if err := c.get(ctx, path("/projects/%d/columns/%d", projectID, columnID), &column); err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// CreateColumn creates a column in the project of column.ProjectID.
+func (c *Client) CreateColumn(ctx context.Context, column *dal.Column) error {
+	return c.sendJSON(ctx, http.MethodPost, path("/projects/%d/columns/", column.ProjectID), column, nil)
+}
+
+// UpdateColumn renames or moves a column.
+func (c *Client) UpdateColumn(ctx context.Context, column *dal.Column) error {
+	return c.sendJSON(ctx, http.MethodPut, path("/projects/%d/columns/%d", column.ProjectID, column.ID), column, nil)
+}
+
+func (c *Client) DeleteColumn(ctx context.Context, projectID, columnID int) error {
+	return c.call(ctx, newRequest(http.MethodDelete, path("/projects/%d/columns/%d", projectID, columnID)), nil)
+}
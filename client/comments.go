@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// ListComments returns the comments of every project.
+func (c *Client) ListComments(ctx context.Context) ([]dal.Comment, error) {
+	var comments []dal.Comment
+	return comments, c.get(ctx, path("/comments/"), &comments)
+}
+
+func (c *Client) ListTaskComments(ctx context.Context, projectID, columnID, taskID int) ([]dal.Comment, error) {
+	var comments []dal.Comment
+	return comments, c.get(ctx, path("/projects/%d/columns/%d/tasks/%d/comments/", projectID, columnID, taskID), &comments)
+}
+
+func (c *Client) GetComment(ctx context.Context, projectID, columnID, taskID, commentID int) (*dal.Comment, error) {
+	var comment dal.Comment
+	if err := c.get(ctx, path("/projects/%d/columns/%d/tasks/%d/comments/%d", projectID, columnID, taskID, commentID), &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CreateComment comments the task of comment.TaskID, which is in columnID of
+// projectID.
+func (c *Client) CreateComment(ctx context.Context, projectID, columnID int, comment *dal.Comment) error {
+	return c.sendJSON(ctx, http.MethodPost, path("/projects/%d/columns/%d/tasks/%d/comments/", projectID, columnID, comment.TaskID), comment, nil)
+}
+
+func (c *Client) UpdateComment(ctx context.Context, projectID, columnID int, comment *dal.Comment) error {
+	return c.sendJSON(ctx, http.MethodPut,
+		path("/projects/%d/columns/%d/tasks/%d/comments/%d", projectID, columnID, comment.TaskID, comment.ID), comment, nil)
+}
+
+func (c *Client) DeleteComment(ctx context.Context, projectID, columnID, taskID, commentID int) error {
+	return c.call(ctx, newRequest(http.MethodDelete, path("/projects/%d/columns/%d/tasks/%d/comments/%d", projectID, columnID, taskID, commentID)), nil)
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is an error response of the API.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the text of the response; the server answers errors in
+	// plain text.
+	Message string
+	// RequestID identifies the request in the logs of the server.
+	RequestID string
+	// RetryAfter is how long the server asked to wait before trying again,
+	// with 429 and 503 responses.
+	RetryAfter time.Duration
+	// Body is the response as it is. Some calls answer errors with JSON, like
+	// the results of a failed task batch.
+	Body []byte
+}
+
+// The errors of the statuses calls are most often checked for, to use with
+// errors.Is; they match any *Error with the same status.
+var (
+	ErrBadRequest  = &Error{StatusCode: http.StatusBadRequest}
+	ErrNotFound    = &Error{StatusCode: http.StatusNotFound}
+	ErrTooLarge    = &Error{StatusCode: http.StatusRequestEntityTooLarge}
+	ErrRateLimited = &Error{StatusCode: http.StatusTooManyRequests}
+	ErrUnavailable = &Error{StatusCode: http.StatusServiceUnavailable}
+)
+
+func newError(req *request, resp *response) *Error {
+	err := &Error{
+		Method:     req.method,
+		Path:       req.path,
+		StatusCode: resp.status,
+		Message:    strings.TrimSpace(string(resp.body)),
+		RequestID:  resp.header.Get("X-Request-ID"),
+		Body:       resp.body,
+	}
+	if seconds, parseErr := strconv.Atoi(resp.header.Get("Retry-After")); parseErr == nil {
+		err.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return err
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if message == "" || strings.HasPrefix(message, "{") || strings.HasPrefix(message, "[") {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, message)
+}
+
+// Is matches the errors of the same status, e.g. errors.Is(err, ErrNotFound).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.StatusCode == e.StatusCode
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// The operations routes aren't versioned, their paths are used as they are.
+// Their responses are decoded into the types below rather than the ones of
+// the handlers, so that the client doesn't depend on the server.
+
+// Status is the answer of the health probes, with the result of every check
+// for readiness.
+type Status struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// BuildInfo is the build of the server.
+type BuildInfo struct {
+	Commit        string `json:"commit"`
+	BuildTime     string `json:"build_time"`
+	GoVersion     string `json:"go_version"`
+	SchemaVersion int    `json:"schema_version"`
+}
+
+// Document is the OpenAPI document of the server. Operations and components
+// are left undecoded, to be read with a library that knows OpenAPI.
+type Document struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	} `json:"info"`
+	// Paths holds the operations of a path by lower case method.
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components json.RawMessage                       `json:"components"`
+}
+
+// Health reports whether the server is up.
+func (c *Client) Health(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.get(ctx, "/healthz", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Ready reports whether the server can serve requests. While it can't, the
+// status with the failing checks comes with an *Error; the call isn't
+// retried, since the failure is its answer.
+func (c *Client) Ready(ctx context.Context) (*Status, error) {
+	req := newRequest(http.MethodGet, "/readyz")
+	req.once = true
+	var status Status
+	if err := c.callWithReport(ctx, req, &status); err != nil {
+		if status.Status == "" {
+			return nil, err
+		}
+		return &status, err
+	}
+	return &status, nil
+}
+
+// Version returns the build of the server.
+func (c *Client) Version(ctx context.Context) (*BuildInfo, error) {
+	var info BuildInfo
+	if err := c.get(ctx, "/version", &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// OpenAPI returns the OpenAPI document of the server.
+func (c *Client) OpenAPI(ctx context.Context) (*Document, error) {
+	var doc Document
+	if err := c.get(ctx, "/openapi.json", &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Metrics returns the Prometheus metrics of the server in the text format.
+func (c *Client) Metrics(ctx context.Context) ([]byte, error) {
+	return c.raw(ctx, "/metrics", nil)
+}
+
+//---------------------------------------------------------------------------//
+
+// GraphQLError is an error a GraphQL query was answered with. Path names the
+// field it happened in, by name and list index.
+type GraphQLError struct {
+	Message   string `json:"message"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors are the errors a GraphQL query was answered with. The data
+// the query could resolve is decoded all the same.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// GraphQL runs query with variables and decodes its data into data, unless it
+// is nil. Mutations may not be safe to repeat, so queries aren't retried.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, data interface{}) error {
+	body := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{query, variables}
+	req, err := newRequest(http.MethodPost, "/graphql").withJSON(&body)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := c.call(ctx, req, &result); err != nil {
+		return err
+	}
+	if data != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, data); err != nil {
+			return fmt.Errorf("can't decode the data of the query: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+	return nil
+}
+
+// GraphQLSchema returns the schema of the GraphQL endpoint in SDL.
+func (c *Client) GraphQLSchema(ctx context.Context) (string, error) {
+	schema, err := c.raw(ctx, "/graphql/schema.graphql", nil)
+	return string(schema), err
+}
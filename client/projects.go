@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/service/trello"
+)
+
+func (c *Client) ListProjects(ctx context.Context) ([]dal.Project, error) {
+	var projects []dal.Project
+	return projects, c.get(ctx, path("/projects/"), &projects)
+}
+
+// GetProject returns a project with its columns, tasks and comments.
+func (c *Client) GetProject(ctx context.Context, id int) (*dal.ExtendedProjectEntities, error) {
+	var project dal.ExtendedProjectEntities
+	if err := c.get(ctx, path("/projects/%d", id), &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// CreateProject creates a project with a default column, and sets the ID of
+// project to the one the server gave it.
+func (c *Client) CreateProject(ctx context.Context, project *dal.Project) error {
+	return c.sendJSON(ctx, http.MethodPost, path("/projects/"), project, project)
+}
+
+func (c *Client) UpdateProject(ctx context.Context, project *dal.Project) error {
+	return c.sendJSON(ctx, http.MethodPut, path("/projects/%d", project.ID), project, nil)
+}
+
+// DeleteProject deletes a project with its columns, tasks and comments.
+func (c *Client) DeleteProject(ctx context.Context, id int) error {
+	return c.call(ctx, newRequest(http.MethodDelete, path("/projects/%d", id)), nil)
+}
+
+//---------------------------------------------------------------------------//
+
+// ExportProject returns an archive of a project that ImportProject takes.
+func (c *Client) ExportProject(ctx context.Context, id int) (*dal.ProjectArchive, error) {
+	var archive dal.ProjectArchive
+	if err := c.get(ctx, path("/projects/%d/export", id), &archive); err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+// RenderProject renders a project as a Markdown (md), Mermaid (mmd) or
+// Graphviz (dot) document.
+func (c *Client) RenderProject(ctx context.Context, id int, format string) ([]byte, error) {
+	return c.raw(ctx, path("/projects/%d/export.%s", id, format), nil)
+}
+
+// ImportProject creates a new project from an archive. Columns whose names
+// are taken are renamed, and listed as converted in the report.
+func (c *Client) ImportProject(ctx context.Context, archive *dal.ProjectArchive) (*dal.ImportReport, error) {
+	var report dal.ImportReport
+	if err := c.sendJSON(ctx, http.MethodPost, path("/projects/import"), archive, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ImportTrelloBoard creates a new project from a Trello board export.
+func (c *Client) ImportTrelloBoard(ctx context.Context, board *trello.Board) (*dal.ImportReport, error) {
+	var report dal.ImportReport
+	if err := c.sendJSON(ctx, http.MethodPost, path("/projects/import/trello"), board, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+//---------------------------------------------------------------------------//
+
+// CreateCalendarFeed issues a new calendar feed token for a project, which
+// revokes the previous one.
+func (c *Client) CreateCalendarFeed(ctx context.Context, projectID int) (*dal.CalendarFeed, error) {
+	var feed dal.CalendarFeed
+	req := newRequest(http.MethodPost, path("/projects/%d/calendar", projectID)).deduplicated()
+	if err := c.call(ctx, req, &feed); err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// CalendarFeed downloads the iCalendar feed of token with the tasks as
+// component, calendar.ComponentEvent or calendar.ComponentTodo; empty means
+// events.
+func (c *Client) CalendarFeed(ctx context.Context, token, component string) ([]byte, error) {
+	query := url.Values{}
+	if component != "" {
+		query.Set("component", component)
+	}
+	return c.raw(ctx, path("/calendar/%s.ics", token), query)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// ListTasks returns the tasks of every project.
+func (c *Client) ListTasks(ctx context.Context) ([]dal.Task, error) {
+	var tasks []dal.Task
+	return tasks, c.get(ctx, path("/tasks/"), &tasks)
+}
+
+// ListColumnTasks returns the tasks of a column with their comments.
+func (c *Client) ListColumnTasks(ctx context.Context, projectID, columnID int) ([]dal.ExtendedTask, error) {
+	var tasks []dal.ExtendedTask
+	return tasks, c.get(ctx, path("/projects/%d/columns/%d/tasks/", projectID, columnID), &tasks)
+}
+
+// GetTask returns a task with its comments.
+func (c *Client) GetTask(ctx context.Context, projectID, columnID, taskID int) (*dal.ExtendedTask, error) {
+	var task dal.ExtendedTask
+	if err := c.get(ctx, path("/projects/%d/columns/%d/tasks/%d", projectID, columnID, taskID), &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// CreateTask creates a task in the column of task.ColumnID, which belongs to
+// projectID.
+func (c *Client) CreateTask(ctx context.Context, projectID int, task *dal.Task) error {
+	return c.sendJSON(ctx, http.MethodPost, path("/projects/%d/columns/%d/tasks/", projectID, task.ColumnID), task, nil)
+}
+
+func (c *Client) UpdateTask(ctx context.Context, projectID int, task *dal.Task) error {
+	return c.sendJSON(ctx, http.MethodPut, path("/projects/%d/columns/%d/tasks/%d", projectID, task.ColumnID, task.ID), task, nil)
+}
+
+// DeleteTask deletes a task. Its comments are kept.
+func (c *Client) DeleteTask(ctx context.Context, projectID, columnID, taskID int) error {
+	return c.call(ctx, newRequest(http.MethodDelete, path("/projects/%d/columns/%d/tasks/%d", projectID, columnID, taskID)), nil)
+}
+
+//---------------------------------------------------------------------------//
+
+// BatchTasks creates, updates, moves and deletes tasks of a project in one
+// request and returns the result of every operation. When an atomic batch
+// was rolled back, the results come with an *Error.
+func (c *Client) BatchTasks(ctx context.Context, projectID int, batch *dal.TaskBatch) ([]dal.TaskOperationResult, error) {
+	req, err := newRequest(http.MethodPost, path("/projects/%d/tasks:batch", projectID)).withJSON(batch)
+	if err != nil {
+		return nil, err
+	}
+	var results []dal.TaskOperationResult
+	err = c.callWithReport(ctx, req.deduplicated(), &results)
+	return results, err
+}
+
+// ExportTasksCSV returns the tasks of a project as CSV.
+func (c *Client) ExportTasksCSV(ctx context.Context, projectID int) ([]byte, error) {
+	return c.raw(ctx, path("/projects/%d/tasks.csv", projectID), nil)
+}
+
+// ImportTasksCSV imports tasks from CSV, creating the columns they name that
+// don't exist yet; with dryRun the rows are only checked. When rows are
+// invalid nothing is imported, and the report of their errors comes with an
+// *Error.
+func (c *Client) ImportTasksCSV(ctx context.Context, projectID int, csv []byte, dryRun bool) (*dal.TaskImportReport, error) {
+	req := newRequest(http.MethodPost, path("/projects/%d/tasks.csv", projectID)).deduplicated()
+	req.contentType = "text/csv"
+	req.body = csv
+	if dryRun {
+		req.query = url.Values{"dry_run": {"true"}}
+	}
+	var report dal.TaskImportReport
+	if err := c.callWithReport(ctx, req, &report); err != nil {
+		if report.Errors == nil {
+			return nil, err
+		}
+		return &report, err
+	}
+	return &report, nil
+}
+
+// callWithReport is call for requests that report what went wrong as JSON,
+// which is decoded into out along with the *Error.
+func (c *Client) callWithReport(ctx context.Context, req *request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if strings.HasPrefix(resp.header.Get("Content-Type"), jsonContentType) {
+			_ = decode(req, resp, out)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	return decode(req, resp, out)
+}
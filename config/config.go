@@ -0,0 +1,722 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// EnvPrefix is prepended to the name of every environment variable the config
+// is read from, e.g. PROJECTS_ADDR.
+const EnvPrefix = "PROJECTS_"
+
+// Exporters spans can be sent to.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// Stores the rate limiter can keep its buckets in.
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStoreDatabase = "database"
+)
+
+// Config holds everything main needs to start the server. It is filled from
+// the defaults, a YAML or TOML file, environment variables and command-line
+// flags, each source overriding the ones before it.
+type Config struct {
+	Server    Server    `yaml:"server" toml:"server"`
+	Database  Database  `yaml:"database" toml:"database"`
+	Log       Log       `yaml:"log" toml:"log"`
+	CORS      CORS      `yaml:"cors" toml:"cors"`
+	Security  Security  `yaml:"security" toml:"security"`
+	Tracing   Tracing   `yaml:"tracing" toml:"tracing"`
+	RateLimit RateLimit `yaml:"rate_limit" toml:"rate_limit"`
+	Body      Body      `yaml:"body" toml:"body"`
+	API       API       `yaml:"api" toml:"api"`
+}
+
+type Server struct {
+	// Addr is the host:port the HTTP server listens on.
+	Addr string `yaml:"addr" toml:"addr"`
+	// GRPCAddr is the host:port the gRPC API is served on, empty to serve
+	// the REST API only.
+	GRPCAddr string `yaml:"grpc_addr" toml:"grpc_addr"`
+
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout" toml:"read_header_timeout"`
+	ReadTimeout       Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout      Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout       Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish
+	// once the server has been asked to stop.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// DrainDelay is how long the server keeps serving with a failing readiness
+	// probe after it has been asked to stop, so that load balancers notice
+	// before the listener is closed.
+	DrainDelay Duration `yaml:"drain_delay" toml:"drain_delay"`
+	// RequestTimeout bounds how long a handler may work on a request, its
+	// database queries are cancelled when it runs out.
+	RequestTimeout Duration `yaml:"request_timeout" toml:"request_timeout"`
+	// TLSCertFile and TLSKeyFile are PEM files; with both set the server
+	// accepts HTTPS instead of plain HTTP connections.
+	TLSCertFile string `yaml:"tls_cert_file" toml:"tls_cert_file"`
+	TLSKeyFile  string `yaml:"tls_key_file" toml:"tls_key_file"`
+}
+
+type Database struct {
+	// Path of the sqlite database file, created on first start.
+	Path string `yaml:"path" toml:"path"`
+}
+
+type Log struct {
+	// Level is the lowest level written: debug, info, warn or error.
+	Level string `yaml:"level" toml:"level"`
+	// Format is json, one object per line, or text for reading in a terminal.
+	Format string `yaml:"format" toml:"format"`
+	// File the log is appended to. Empty means standard error.
+	File string `yaml:"file" toml:"file"`
+	// FileMode is the octal permission the log file is created with, e.g. "0640".
+	FileMode string `yaml:"file_mode" toml:"file_mode"`
+}
+
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins" toml:"allowed_origins"`
+	AllowedHeaders []string `yaml:"allowed_headers" toml:"allowed_headers"`
+	AllowedMethods []string `yaml:"allowed_methods" toml:"allowed_methods"`
+	// ExposedHeaders are the response headers scripts of other origins may read.
+	ExposedHeaders []string `yaml:"exposed_headers" toml:"exposed_headers"`
+	// AllowCredentials lets browsers send cookies and Authorization headers
+	// along; it can't be combined with the "*" origin.
+	AllowCredentials bool `yaml:"allow_credentials" toml:"allow_credentials"`
+	// MaxAge is how long browsers may cache a preflight response, at most 10m.
+	MaxAge Duration `yaml:"max_age" toml:"max_age"`
+}
+
+type Security struct {
+	// HSTSMaxAge is how long browsers should only use HTTPS for this host.
+	// The header is only sent on TLS connections, 0 disables it.
+	HSTSMaxAge            Duration `yaml:"hsts_max_age" toml:"hsts_max_age"`
+	HSTSIncludeSubdomains bool     `yaml:"hsts_include_subdomains" toml:"hsts_include_subdomains"`
+	// FrameAncestors is the CSP source list of pages that may embed responses,
+	// "'none'" forbids framing altogether.
+	FrameAncestors []string `yaml:"frame_ancestors" toml:"frame_ancestors"`
+}
+
+type Tracing struct {
+	// Exporter is where spans are sent: none, otlp (OTLP over HTTP) or stdout.
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	// Endpoint is the host:port of the OTLP collector.
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	// Insecure sends spans to the collector over plain HTTP.
+	Insecure bool `yaml:"insecure" toml:"insecure"`
+	// SampleRatio is the share of traces started by this server that are
+	// recorded. Requests carrying a trace context follow the caller's decision.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+	// ServiceName identifies this server in the tracing backend.
+	ServiceName string `yaml:"service_name" toml:"service_name"`
+}
+
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// Store keeps the buckets: memory, per server process, or database,
+	// shared by every server using the same database.
+	Store string `yaml:"store" toml:"store"`
+	// APIKeys are the keys clients may send in X-API-Key to be limited on
+	// their own instead of sharing the buckets of their IP address.
+	APIKeys []string `yaml:"api_keys" toml:"api_keys"`
+	// TrustForwardedFor takes the client address from the last entry of
+	// X-Forwarded-For. Only enable it behind a proxy that sets the header.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" toml:"trust_forwarded_for"`
+	// Read applies to GET and HEAD requests, Write to all others.
+	Read  Limit `yaml:"read" toml:"read"`
+	Write Limit `yaml:"write" toml:"write"`
+	// Routes replace the read or write limit for single routes.
+	Routes []RouteLimit `yaml:"routes" toml:"routes"`
+}
+
+// Limit is a token bucket: a client may send Burst requests at once and then
+// Rate requests per second.
+type Limit struct {
+	Rate  float64 `yaml:"rate" toml:"rate"`
+	Burst int     `yaml:"burst" toml:"burst"`
+}
+
+type RouteLimit struct {
+	Method string `yaml:"method" toml:"method"`
+	// Route is the route template without the version prefix, e.g.
+	// /projects/{id}/export; it applies to the route of every API version.
+	Route string  `yaml:"route" toml:"route"`
+	Rate  float64 `yaml:"rate" toml:"rate"`
+	Burst int     `yaml:"burst" toml:"burst"`
+}
+
+type Body struct {
+	// MaxBytes caps the size of request bodies, larger ones are answered
+	// with 413 before they are read.
+	MaxBytes int64 `yaml:"max_bytes" toml:"max_bytes"`
+	// Routes replace MaxBytes for single routes, e.g. imports.
+	Routes []RouteBodyLimit `yaml:"routes" toml:"routes"`
+}
+
+type RouteBodyLimit struct {
+	Method string `yaml:"method" toml:"method"`
+	// Route is written without the version prefix, like RouteLimit.Route.
+	Route    string `yaml:"route" toml:"route"`
+	MaxBytes int64  `yaml:"max_bytes" toml:"max_bytes"`
+}
+
+type API struct {
+	// LegacyRoutes keeps serving the routes from before versioning, without
+	// the /v1 prefix, as deprecated aliases of the /v1 routes.
+	LegacyRoutes bool `yaml:"legacy_routes" toml:"legacy_routes"`
+	// LegacyDeprecated is the date sent in the Deprecation header of the
+	// legacy routes, LegacySunset the one they stop working on, if known.
+	LegacyDeprecated Date `yaml:"legacy_deprecated" toml:"legacy_deprecated"`
+	LegacySunset     Date `yaml:"legacy_sunset" toml:"legacy_sunset"`
+}
+
+// Duration is a time.Duration written as "30s" or "1m30s" in files and variables.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// UnmarshalString has the signature the environment and flag overrides need.
+func (d *Duration) UnmarshalString(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.UnmarshalText([]byte(value.Value))
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Date is a day written as "2027-04-30" in files and variables, midnight UTC.
+// The zero Date means unset.
+type Date time.Time
+
+const dateLayout = "2006-01-02"
+
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := time.Parse(dateLayout, string(text))
+	if err != nil {
+		return err
+	}
+	*d = Date(parsed)
+	return nil
+}
+
+// UnmarshalString has the signature the environment and flag overrides need.
+func (d *Date) UnmarshalString(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d *Date) UnmarshalYAML(value *yaml.Node) error {
+	return d.UnmarshalText([]byte(value.Value))
+}
+
+func (d Date) IsZero() bool {
+	return time.Time(d).IsZero()
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return time.Time(d).Format(dateLayout)
+}
+
+// Default returns the settings the server used before it was configurable,
+// except that the log file is no longer world-writable and the server has
+// timeouts.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:              "127.0.0.1:4040",
+			GRPCAddr:          "127.0.0.1:4041",
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			ReadTimeout:       Duration(15 * time.Second),
+			WriteTimeout:      Duration(30 * time.Second),
+			IdleTimeout:       Duration(time.Minute),
+			ShutdownTimeout:   Duration(15 * time.Second),
+			RequestTimeout:    Duration(20 * time.Second),
+		},
+		Database: Database{Path: "projects.db"},
+		Log:      Log{Level: "info", Format: "json", File: "testlogfile", FileMode: "0640"},
+		CORS: CORS{
+			AllowedOrigins: []string{"*"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "X-Requested-With", "X-Request-ID", "Idempotency-Key", "X-API-Key"},
+			AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"},
+			ExposedHeaders: []string{"X-Request-ID", "Idempotent-Replayed", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+				"Deprecation", "Sunset", "Link"},
+			MaxAge: Duration(10 * time.Minute),
+		},
+		Security: Security{
+			HSTSMaxAge:     Duration(180 * 24 * time.Hour),
+			FrameAncestors: []string{"'none'"},
+		},
+		Tracing: Tracing{
+			Exporter:    TracingExporterNone,
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "golang-school-project",
+		},
+		RateLimit: RateLimit{
+			Enabled: true,
+			Store:   RateLimitStoreMemory,
+			Read:    Limit{Rate: 20, Burst: 40},
+			Write:   Limit{Rate: 5, Burst: 10},
+			Routes: []RouteLimit{
+				{Method: "POST", Route: "/projects/import", Rate: 0.2, Burst: 3},
+				{Method: "POST", Route: "/projects/import/trello", Rate: 0.2, Burst: 3},
+				{Method: "POST", Route: "/projects/{projectID}/tasks.csv", Rate: 0.2, Burst: 3},
+				{Method: "POST", Route: "/projects/{projectID}/tasks:batch", Rate: 1, Burst: 5},
+			},
+		},
+		Body: Body{
+			MaxBytes: 1 << 20,
+			Routes: []RouteBodyLimit{
+				{Method: "POST", Route: "/projects/import", MaxBytes: 32 << 20},
+				{Method: "POST", Route: "/projects/import/trello", MaxBytes: 32 << 20},
+				{Method: "POST", Route: "/projects/{projectID}/tasks.csv", MaxBytes: 16 << 20},
+				{Method: "POST", Route: "/projects/{projectID}/tasks:batch", MaxBytes: 4 << 20},
+			},
+		},
+		API: API{
+			LegacyRoutes:     true,
+			LegacyDeprecated: Date(time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)),
+			LegacySunset:     Date(time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+}
+
+//=======================================================================================//
+
+// Load builds the config from a file, the environment and the command-line
+// arguments (without the program name), in increasing order of precedence.
+// The file is named by the -config flag or the PROJECTS_CONFIG variable; without
+// one, only the defaults are overridden. The result is validated.
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	fs := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var (
+		path      = fs.String("config", "", "path of a YAML or TOML config file")
+		addr      = fs.String("addr", "", "address to listen on, host:port")
+		grpcAddr  = fs.String("grpc-addr", "", "address to serve gRPC on, host:port, empty to not serve it")
+		dbPath    = fs.String("db", "", "path of the sqlite database")
+		logLevel  = fs.String("log-level", "", "lowest log level written: debug, info, warn or error")
+		logFormat = fs.String("log-format", "", "log line format: json or text")
+		logFile   = fs.String("log-file", "", "file to append the log to, \"-\" for standard error")
+		logMode   = fs.String("log-file-mode", "", "octal permission of a new log file")
+		origins   = fs.String("cors-origins", "", "comma separated origins allowed to call the API")
+		headers   = fs.String("cors-headers", "", "comma separated request headers allowed in CORS requests")
+		methods   = fs.String("cors-methods", "", "comma separated methods allowed in CORS requests")
+		exposed   = fs.String("cors-exposed-headers", "", "comma separated response headers readable in CORS requests")
+		creds     = fs.String("cors-credentials", "", "allow credentials in CORS requests: true or false")
+		maxAge    = fs.String("cors-max-age", "", "time browsers may cache a preflight response, at most 10m")
+		hstsAge   = fs.String("hsts-max-age", "", "Strict-Transport-Security max-age sent over TLS, 0 to disable")
+		ancestors = fs.String("frame-ancestors", "", "comma separated CSP frame-ancestors sources")
+		tlsCert   = fs.String("tls-cert", "", "PEM certificate file, serves HTTPS together with -tls-key")
+		tlsKey    = fs.String("tls-key", "", "PEM private key file of -tls-cert")
+
+		tracingExporter = fs.String("tracing-exporter", "", "where spans are sent: none, otlp or stdout")
+		tracingEndpoint = fs.String("tracing-endpoint", "", "host:port of the OTLP collector")
+		tracingInsecure = fs.String("tracing-insecure", "", "send spans to the collector over plain HTTP: true or false")
+		tracingRatio    = fs.String("tracing-sample-ratio", "", "share of new traces recorded, between 0 and 1")
+
+		rateLimit      = fs.String("rate-limit", "", "limit the request rate of every client: true or false")
+		rateLimitStore = fs.String("rate-limit-store", "", "where rate limit buckets are kept: memory or database")
+		rateLimitKeys  = fs.String("rate-limit-api-keys", "", "comma separated API keys that get buckets of their own")
+		rateLimitProxy = fs.String("rate-limit-trust-forwarded-for", "", "take the client address from X-Forwarded-For: true or false")
+		readRate       = fs.String("rate-limit-read-rate", "", "GET and HEAD requests per second and client")
+		readBurst      = fs.String("rate-limit-read-burst", "", "GET and HEAD requests a client may send at once")
+		writeRate      = fs.String("rate-limit-write-rate", "", "other requests per second and client")
+		writeBurst     = fs.String("rate-limit-write-burst", "", "other requests a client may send at once")
+
+		maxBodyBytes = fs.String("max-body-bytes", "", "largest request body accepted, in bytes")
+
+		legacyRoutes     = fs.String("legacy-routes", "", "serve the unversioned routes as deprecated aliases of /v1: true or false")
+		legacyDeprecated = fs.String("legacy-deprecated", "", "date the unversioned routes were deprecated on, e.g. 2026-10-19")
+		legacySunset     = fs.String("legacy-sunset", "", "date the unversioned routes stop working on, empty if not known")
+
+		readHeaderTimeout = fs.String("read-header-timeout", "", "time allowed to read request headers, e.g. 5s")
+		readTimeout       = fs.String("read-timeout", "", "time allowed to read a whole request")
+		writeTimeout      = fs.String("write-timeout", "", "time allowed to write a response")
+		idleTimeout       = fs.String("idle-timeout", "", "time a keep-alive connection may stay idle")
+		shutdownTimeout   = fs.String("shutdown-timeout", "", "time in-flight requests get to finish on shutdown")
+		requestTimeout    = fs.String("request-timeout", "", "time a handler may take to answer a request")
+		drainDelay        = fs.String("drain-delay", "", "time readiness fails before the listener is closed on shutdown")
+	)
+	if err := fs.Parse(args); err != nil {
+		// the usage text is part of the error, flag.ErrHelp can be told apart with errors.Is
+		var usage bytes.Buffer
+		fs.SetOutput(&usage)
+		fs.PrintDefaults()
+		return nil, fmt.Errorf("%w\n\nUsage of %s:\n%s", err, fs.Name(), usage.String())
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+
+	cfg := Default()
+	file := *path
+	if file == "" {
+		file = getenv(EnvPrefix + "CONFIG")
+	}
+	if file != "" {
+		if err := cfg.loadFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	text := func(dst *string) func(string) error {
+		return func(v string) error { *dst = v; return nil }
+	}
+	list := func(dst *[]string) func(string) error {
+		return func(v string) error { *dst = splitList(v); return nil }
+	}
+	boolean := func(dst *bool) func(string) error {
+		return func(v string) (err error) { *dst, err = strconv.ParseBool(v); return err }
+	}
+	float := func(dst *float64) func(string) error {
+		return func(v string) (err error) { *dst, err = strconv.ParseFloat(v, 64); return err }
+	}
+	integer := func(dst *int) func(string) error {
+		return func(v string) (err error) { *dst, err = strconv.Atoi(v); return err }
+	}
+	size := func(dst *int64) func(string) error {
+		return func(v string) (err error) { *dst, err = strconv.ParseInt(v, 10, 64); return err }
+	}
+	overrides := []struct {
+		env, flag string
+		value     *string
+		apply     func(string) error
+	}{
+		{env: "ADDR", flag: "addr", value: addr, apply: text(&cfg.Server.Addr)},
+		{env: "GRPC_ADDR", flag: "grpc-addr", value: grpcAddr, apply: text(&cfg.Server.GRPCAddr)},
+		{env: "READ_HEADER_TIMEOUT", flag: "read-header-timeout", value: readHeaderTimeout, apply: cfg.Server.ReadHeaderTimeout.UnmarshalString},
+		{env: "READ_TIMEOUT", flag: "read-timeout", value: readTimeout, apply: cfg.Server.ReadTimeout.UnmarshalString},
+		{env: "WRITE_TIMEOUT", flag: "write-timeout", value: writeTimeout, apply: cfg.Server.WriteTimeout.UnmarshalString},
+		{env: "IDLE_TIMEOUT", flag: "idle-timeout", value: idleTimeout, apply: cfg.Server.IdleTimeout.UnmarshalString},
+		{env: "SHUTDOWN_TIMEOUT", flag: "shutdown-timeout", value: shutdownTimeout, apply: cfg.Server.ShutdownTimeout.UnmarshalString},
+		{env: "REQUEST_TIMEOUT", flag: "request-timeout", value: requestTimeout, apply: cfg.Server.RequestTimeout.UnmarshalString},
+		{env: "DRAIN_DELAY", flag: "drain-delay", value: drainDelay, apply: cfg.Server.DrainDelay.UnmarshalString},
+		{env: "TLS_CERT_FILE", flag: "tls-cert", value: tlsCert, apply: text(&cfg.Server.TLSCertFile)},
+		{env: "TLS_KEY_FILE", flag: "tls-key", value: tlsKey, apply: text(&cfg.Server.TLSKeyFile)},
+		{env: "MAX_BODY_BYTES", flag: "max-body-bytes", value: maxBodyBytes, apply: size(&cfg.Body.MaxBytes)},
+		{env: "LEGACY_ROUTES", flag: "legacy-routes", value: legacyRoutes, apply: boolean(&cfg.API.LegacyRoutes)},
+		{env: "LEGACY_DEPRECATED", flag: "legacy-deprecated", value: legacyDeprecated, apply: cfg.API.LegacyDeprecated.UnmarshalString},
+		{env: "LEGACY_SUNSET", flag: "legacy-sunset", value: legacySunset, apply: cfg.API.LegacySunset.UnmarshalString},
+		{env: "DB_PATH", flag: "db", value: dbPath, apply: text(&cfg.Database.Path)},
+		{env: "LOG_LEVEL", flag: "log-level", value: logLevel, apply: text(&cfg.Log.Level)},
+		{env: "LOG_FORMAT", flag: "log-format", value: logFormat, apply: text(&cfg.Log.Format)},
+		{env: "LOG_FILE", flag: "log-file", value: logFile, apply: func(v string) error { cfg.Log.File = stdErrName(v); return nil }},
+		{env: "LOG_FILE_MODE", flag: "log-file-mode", value: logMode, apply: text(&cfg.Log.FileMode)},
+		{env: "CORS_ORIGINS", flag: "cors-origins", value: origins, apply: list(&cfg.CORS.AllowedOrigins)},
+		{env: "CORS_HEADERS", flag: "cors-headers", value: headers, apply: list(&cfg.CORS.AllowedHeaders)},
+		{env: "CORS_METHODS", flag: "cors-methods", value: methods, apply: list(&cfg.CORS.AllowedMethods)},
+		{env: "CORS_EXPOSED_HEADERS", flag: "cors-exposed-headers", value: exposed, apply: list(&cfg.CORS.ExposedHeaders)},
+		{env: "CORS_CREDENTIALS", flag: "cors-credentials", value: creds, apply: boolean(&cfg.CORS.AllowCredentials)},
+		{env: "CORS_MAX_AGE", flag: "cors-max-age", value: maxAge, apply: cfg.CORS.MaxAge.UnmarshalString},
+		{env: "HSTS_MAX_AGE", flag: "hsts-max-age", value: hstsAge, apply: cfg.Security.HSTSMaxAge.UnmarshalString},
+		{env: "FRAME_ANCESTORS", flag: "frame-ancestors", value: ancestors, apply: list(&cfg.Security.FrameAncestors)},
+		{env: "TRACING_EXPORTER", flag: "tracing-exporter", value: tracingExporter, apply: text(&cfg.Tracing.Exporter)},
+		{env: "TRACING_ENDPOINT", flag: "tracing-endpoint", value: tracingEndpoint, apply: text(&cfg.Tracing.Endpoint)},
+		{env: "TRACING_INSECURE", flag: "tracing-insecure", value: tracingInsecure, apply: boolean(&cfg.Tracing.Insecure)},
+		{env: "TRACING_SAMPLE_RATIO", flag: "tracing-sample-ratio", value: tracingRatio, apply: float(&cfg.Tracing.SampleRatio)},
+		{env: "RATE_LIMIT", flag: "rate-limit", value: rateLimit, apply: boolean(&cfg.RateLimit.Enabled)},
+		{env: "RATE_LIMIT_STORE", flag: "rate-limit-store", value: rateLimitStore, apply: text(&cfg.RateLimit.Store)},
+		{env: "RATE_LIMIT_API_KEYS", flag: "rate-limit-api-keys", value: rateLimitKeys, apply: list(&cfg.RateLimit.APIKeys)},
+		{env: "RATE_LIMIT_TRUST_FORWARDED_FOR", flag: "rate-limit-trust-forwarded-for", value: rateLimitProxy, apply: boolean(&cfg.RateLimit.TrustForwardedFor)},
+		{env: "RATE_LIMIT_READ_RATE", flag: "rate-limit-read-rate", value: readRate, apply: float(&cfg.RateLimit.Read.Rate)},
+		{env: "RATE_LIMIT_READ_BURST", flag: "rate-limit-read-burst", value: readBurst, apply: integer(&cfg.RateLimit.Read.Burst)},
+		{env: "RATE_LIMIT_WRITE_RATE", flag: "rate-limit-write-rate", value: writeRate, apply: float(&cfg.RateLimit.Write.Rate)},
+		{env: "RATE_LIMIT_WRITE_BURST", flag: "rate-limit-write-burst", value: writeBurst, apply: integer(&cfg.RateLimit.Write.Burst)},
+	}
+	for _, o := range overrides {
+		if v, ok := lookupEnv(getenv, EnvPrefix+o.env); ok {
+			if err := o.apply(v); err != nil {
+				return nil, fmt.Errorf("invalid %s%s: %w", EnvPrefix, o.env, err)
+			}
+		}
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, o := range overrides {
+		if set[o.flag] {
+			if err := o.apply(*o.value); err != nil {
+				return nil, fmt.Errorf("invalid -%s: %w", o.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+//---------------------------------------------------------------------------//
+
+// Validate reports every invalid setting at once, so that a broken deployment
+// can be fixed in one go.
+func (c *Config) Validate() error {
+	var problems []string
+	checkAddr := func(name, addr string) {
+		if _, port, err := net.SplitHostPort(addr); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q isn't host:port", name, addr))
+		} else if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+			problems = append(problems, fmt.Sprintf("%s %q has an invalid port", name, addr))
+		}
+	}
+	checkAddr("server.addr", c.Server.Addr)
+	if c.Server.GRPCAddr != "" {
+		checkAddr("server.grpc_addr", c.Server.GRPCAddr)
+	}
+	if c.Server.GRPCAddr != "" && c.Server.GRPCAddr == c.Server.Addr {
+		problems = append(problems, fmt.Sprintf("server.grpc_addr %q is the address of the REST API", c.Server.GRPCAddr))
+	}
+	timeouts := []struct {
+		name  string
+		value Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"server.request_timeout", c.Server.RequestTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			problems = append(problems, fmt.Sprintf("%s must be positive, got %s", timeout.name, timeout.value))
+		}
+	}
+	if c.Server.DrainDelay < 0 {
+		problems = append(problems, fmt.Sprintf("server.drain_delay can't be negative, got %s", c.Server.DrainDelay))
+	}
+	// the 503 for a request that ran out of time has to be written before
+	// the connection's write deadline
+	if c.Server.RequestTimeout >= c.Server.WriteTimeout {
+		problems = append(problems, fmt.Sprintf("server.request_timeout %s must be shorter than server.write_timeout %s",
+			c.Server.RequestTimeout, c.Server.WriteTimeout))
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		problems = append(problems, "server.tls_cert_file and server.tls_key_file must be set together")
+	}
+	if strings.TrimSpace(c.Database.Path) == "" {
+		problems = append(problems, "database.path is empty")
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		problems = append(problems, "log.level: "+err.Error())
+	}
+	if c.Log.Format != logging.FormatJSON && c.Log.Format != logging.FormatText {
+		problems = append(problems, fmt.Sprintf("log.format %q isn't json or text", c.Log.Format))
+	}
+	if c.Log.File != "" {
+		if _, err := c.Log.Mode(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problems = append(problems, "cors.allowed_origins is empty")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if len(c.CORS.AllowedOrigins) > 1 {
+				problems = append(problems, `cors.allowed_origins can't mix "*" with other origins`)
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			problems = append(problems, fmt.Sprintf("cors.allowed_origins entry %q isn't an http(s) origin", origin))
+		}
+	}
+	if len(c.CORS.AllowedMethods) == 0 {
+		problems = append(problems, "cors.allowed_methods is empty")
+	}
+	// browsers refuse credentialed responses that allow every origin
+	if c.CORS.AllowCredentials && len(c.CORS.AllowedOrigins) == 1 && c.CORS.AllowedOrigins[0] == "*" {
+		problems = append(problems, `cors.allow_credentials needs explicit origins instead of "*"`)
+	}
+	if c.CORS.MaxAge < 0 || c.CORS.MaxAge > Duration(10*time.Minute) {
+		problems = append(problems, fmt.Sprintf("cors.max_age must be between 0s and 10m, got %s", c.CORS.MaxAge))
+	}
+	if c.Security.HSTSMaxAge < 0 {
+		problems = append(problems, fmt.Sprintf("security.hsts_max_age can't be negative, got %s", c.Security.HSTSMaxAge))
+	}
+	if len(c.Security.FrameAncestors) == 0 {
+		problems = append(problems, `security.frame_ancestors is empty, use "'none'" to forbid framing`)
+	}
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		if _, _, err := net.SplitHostPort(c.Tracing.Endpoint); err != nil {
+			problems = append(problems, fmt.Sprintf("tracing.endpoint %q isn't host:port", c.Tracing.Endpoint))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter %q isn't none, otlp or stdout", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("tracing.sample_ratio %v isn't between 0 and 1", c.Tracing.SampleRatio))
+	}
+	if strings.TrimSpace(c.Tracing.ServiceName) == "" {
+		problems = append(problems, "tracing.service_name is empty")
+	}
+	if c.Body.MaxBytes < 1 {
+		problems = append(problems, fmt.Sprintf("body.max_bytes must be positive, got %d", c.Body.MaxBytes))
+	}
+	bodyRoutes := map[string]bool{}
+	for _, route := range c.Body.Routes {
+		name := fmt.Sprintf("body.routes %s %s", route.Method, route.Route)
+		if route.Method == "" || route.Method != strings.ToUpper(route.Method) || !strings.HasPrefix(route.Route, "/") {
+			problems = append(problems, name+" needs an upper case method and a route template starting with /")
+		}
+		if bodyRoutes[route.Method+" "+route.Route] {
+			problems = append(problems, name+" is listed twice")
+		}
+		bodyRoutes[route.Method+" "+route.Route] = true
+		if route.MaxBytes < 1 {
+			problems = append(problems, fmt.Sprintf("%s must allow a positive max_bytes, got %d", name, route.MaxBytes))
+		}
+	}
+	if c.RateLimit.Store != RateLimitStoreMemory && c.RateLimit.Store != RateLimitStoreDatabase {
+		problems = append(problems, fmt.Sprintf("rate_limit.store %q isn't memory or database", c.RateLimit.Store))
+	}
+	limits := []struct {
+		name  string
+		limit Limit
+	}{
+		{"rate_limit.read", c.RateLimit.Read},
+		{"rate_limit.write", c.RateLimit.Write},
+	}
+	seen := map[string]bool{}
+	for _, route := range c.RateLimit.Routes {
+		name := fmt.Sprintf("rate_limit.routes %s %s", route.Method, route.Route)
+		if route.Method == "" || route.Method != strings.ToUpper(route.Method) || !strings.HasPrefix(route.Route, "/") {
+			problems = append(problems, name+" needs an upper case method and a route template starting with /")
+		}
+		if seen[route.Method+" "+route.Route] {
+			problems = append(problems, name+" is listed twice")
+		}
+		seen[route.Method+" "+route.Route] = true
+		limits = append(limits, struct {
+			name  string
+			limit Limit
+		}{name, Limit{Rate: route.Rate, Burst: route.Burst}})
+	}
+	for _, l := range limits {
+		if l.limit.Rate <= 0 || l.limit.Burst < 1 {
+			problems = append(problems, fmt.Sprintf("%s needs a positive rate and a burst of at least 1, got %v/s and %d",
+				l.name, l.limit.Rate, l.limit.Burst))
+		}
+	}
+	if c.API.LegacyRoutes && c.API.LegacyDeprecated.IsZero() {
+		problems = append(problems, "api.legacy_deprecated is needed to serve the legacy routes")
+	}
+	if !c.API.LegacySunset.IsZero() && !time.Time(c.API.LegacySunset).After(time.Time(c.API.LegacyDeprecated)) {
+		problems = append(problems, fmt.Sprintf("api.legacy_sunset %s must come after api.legacy_deprecated %s",
+			c.API.LegacySunset, c.API.LegacyDeprecated))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Mode parses FileMode, which must be an octal permission without special bits.
+func (l Log) Mode() (os.FileMode, error) {
+	mode, err := strconv.ParseUint(l.FileMode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("log.file_mode %q isn't an octal permission like 0640", l.FileMode)
+	}
+	return os.FileMode(mode), nil
+}
+
+//---------------------------------------------------------------------------//
+
+// loadFile overrides the config with the settings present in a YAML or TOML
+// file, picked by extension. Unknown keys are rejected so that typos don't go
+// unnoticed.
+func (c *Config) loadFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can't read config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(raw))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("can't parse %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(raw), c)
+		if err != nil {
+			return fmt.Errorf("can't parse %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("can't parse %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+	return nil
+}
+
+// lookupEnv treats empty variables as unset, as most deployment tools can't
+// unset a variable they once defined.
+func lookupEnv(getenv func(string) string, name string) (string, bool) {
+	v := getenv(name)
+	return v, v != ""
+}
+
+func stdErrName(v string) string {
+	if v == "-" {
+		return ""
+	}
+	return v
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+//=======================================================================================//
@@ -0,0 +1,348 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("can't write config file: %v", err)
+	}
+	return path
+}
+
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestLoad(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", `
+server:
+  addr: 0.0.0.0:8080
+  shutdown_timeout: 5s
+database:
+  path: /var/lib/projects/projects.db
+log:
+  file: ""
+cors:
+  allowed_origins:
+    - https://board.example.com
+  allow_credentials: true
+  max_age: 5m
+security:
+  hsts_max_age: 0s
+  frame_ancestors: ["'self'"]
+`)
+	tomlFile := writeFile(t, "config.toml", `
+[server]
+addr = "0.0.0.0:9090"
+write_timeout = "1m30s"
+
+[log]
+file = "/var/log/projects.log"
+file_mode = "0600"
+
+[tracing]
+exporter = "otlp"
+endpoint = "collector:4318"
+insecure = false
+
+[rate_limit]
+store = "database"
+
+[rate_limit.write]
+rate = 2.5
+burst = 5
+
+[[rate_limit.routes]]
+method = "GET"
+route = "/tasks/"
+rate = 1
+burst = 2
+
+[api]
+legacy_sunset = "2027-01-31"
+`)
+
+	withDefaults := func(change func(c *Config)) *Config {
+		c := Default()
+		change(c)
+		return c
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want *Config
+	}{
+		{
+			name: "defaults",
+			want: Default(),
+		},
+		{
+			name: "yaml file",
+			args: []string{"-config", yamlFile},
+			want: withDefaults(func(c *Config) {
+				c.Server.Addr = "0.0.0.0:8080"
+				c.Server.ShutdownTimeout = Duration(5 * time.Second)
+				c.Database.Path = "/var/lib/projects/projects.db"
+				c.Log.File = ""
+				c.CORS.AllowedOrigins = []string{"https://board.example.com"}
+				c.CORS.AllowCredentials = true
+				c.CORS.MaxAge = Duration(5 * time.Minute)
+				c.Security.HSTSMaxAge = 0
+				c.Security.FrameAncestors = []string{"'self'"}
+			}),
+		},
+		{
+			name: "toml file named by the environment",
+			env:  map[string]string{"PROJECTS_CONFIG": tomlFile},
+			want: withDefaults(func(c *Config) {
+				c.Server.Addr = "0.0.0.0:9090"
+				c.Server.WriteTimeout = Duration(90 * time.Second)
+				c.Log.File = "/var/log/projects.log"
+				c.Log.FileMode = "0600"
+				c.Tracing.Exporter = TracingExporterOTLP
+				c.Tracing.Endpoint = "collector:4318"
+				c.Tracing.Insecure = false
+				c.RateLimit.Store = RateLimitStoreDatabase
+				c.RateLimit.Write = Limit{Rate: 2.5, Burst: 5}
+				c.RateLimit.Routes = []RouteLimit{{Method: "GET", Route: "/tasks/", Rate: 1, Burst: 2}}
+				c.API.LegacySunset = Date(time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC))
+			}),
+		},
+		{
+			name: "environment overrides file",
+			args: []string{"-config", yamlFile},
+			env: map[string]string{
+				"PROJECTS_ADDR":                 "127.0.0.1:5050",
+				"PROJECTS_SHUTDOWN_TIMEOUT":     "1m",
+				"PROJECTS_CORS_ORIGINS":         "https://a.example.com, https://b.example.com",
+				"PROJECTS_TRACING_EXPORTER":     "stdout",
+				"PROJECTS_TRACING_SAMPLE_RATIO": "0.25",
+				"PROJECTS_RATE_LIMIT_API_KEYS":  "k1, k2",
+				"PROJECTS_RATE_LIMIT_READ_RATE": "7.5",
+				"PROJECTS_CORS_MAX_AGE":         "90s",
+				"PROJECTS_HSTS_MAX_AGE":         "24h",
+				"PROJECTS_MAX_BODY_BYTES":       "2048",
+				"PROJECTS_GRPC_ADDR":            "127.0.0.1:5051",
+			},
+			want: withDefaults(func(c *Config) {
+				c.Server.Addr = "127.0.0.1:5050"
+				c.Server.GRPCAddr = "127.0.0.1:5051"
+				c.Server.ShutdownTimeout = Duration(time.Minute)
+				c.Database.Path = "/var/lib/projects/projects.db"
+				c.Log.File = ""
+				c.CORS.AllowedOrigins = []string{"https://a.example.com", "https://b.example.com"}
+				c.Tracing.Exporter = TracingExporterStdout
+				c.Tracing.SampleRatio = 0.25
+				c.RateLimit.APIKeys = []string{"k1", "k2"}
+				c.RateLimit.Read.Rate = 7.5
+				c.Body.MaxBytes = 2048
+				c.CORS.AllowCredentials = true
+				c.CORS.MaxAge = Duration(90 * time.Second)
+				c.Security.HSTSMaxAge = Duration(24 * time.Hour)
+				c.Security.FrameAncestors = []string{"'self'"}
+			}),
+		},
+		{
+			name: "flags override environment",
+			args: []string{"-config", yamlFile, "-addr", ":6060", "-log-file", "-", "-db", "test.db", "-shutdown-timeout", "2s", "-request-timeout", "5s",
+				"-rate-limit", "false", "-rate-limit-write-burst", "3", "-cors-credentials", "false",
+				"-tls-cert", "cert.pem", "-tls-key", "key.pem", "-frame-ancestors", "'self',https://board.example.com",
+				"-legacy-routes", "false", "-legacy-sunset", "", "-grpc-addr", ""},
+			env: map[string]string{
+				"PROJECTS_ADDR":     "127.0.0.1:5050",
+				"PROJECTS_LOG_FILE": "env.log",
+			},
+			want: withDefaults(func(c *Config) {
+				c.Server.Addr = ":6060"
+				c.Server.ShutdownTimeout = Duration(2 * time.Second)
+				c.Server.RequestTimeout = Duration(5 * time.Second)
+				c.RateLimit.Enabled = false
+				c.RateLimit.Write.Burst = 3
+				c.Database.Path = "test.db"
+				c.Log.File = ""
+				c.CORS.AllowedOrigins = []string{"https://board.example.com"}
+				c.CORS.MaxAge = Duration(5 * time.Minute)
+				c.Server.TLSCertFile = "cert.pem"
+				c.Server.TLSKeyFile = "key.pem"
+				c.Security.HSTSMaxAge = 0
+				c.Security.FrameAncestors = []string{"'self'", "https://board.example.com"}
+				c.API.LegacyRoutes = false
+				c.API.LegacySunset = Date{}
+				c.Server.GRPCAddr = ""
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.args, env(tt.env))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown flag",
+			args:    []string{"-port", "80"},
+			wantErr: "flag provided but not defined: -port",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: "can't read config file",
+		},
+		{
+			name:    "unknown yaml key",
+			args:    []string{"-config", writeFile(t, "typo.yaml", "server:\n  adress: :80\n")},
+			wantErr: "field adress not found",
+		},
+		{
+			name:    "unknown toml key",
+			args:    []string{"-config", writeFile(t, "typo.toml", "[server]\nadress = \":80\"\n")},
+			wantErr: "unknown keys [server.adress]",
+		},
+		{
+			name:    "unsupported extension",
+			args:    []string{"-config", writeFile(t, "config.json", "{}")},
+			wantErr: "must have a .yaml, .yml or .toml extension",
+		},
+		{
+			name: "invalid values",
+			args: []string{"-addr", "localhost", "-db", " ", "-log-file-mode", "0777x", "-cors-origins", "*,board.example.com"},
+			wantErr: `invalid config: server.addr "localhost" isn't host:port; database.path is empty; ` +
+				`log.file_mode "0777x" isn't an octal permission like 0640; ` +
+				`cors.allowed_origins can't mix "*" with other origins; ` +
+				`cors.allowed_origins entry "board.example.com" isn't an http(s) origin`,
+		},
+		{
+			name:    "invalid duration flag",
+			args:    []string{"-write-timeout", "soon"},
+			wantErr: `invalid -write-timeout: time: invalid duration`,
+		},
+		{
+			name:    "invalid duration in a file",
+			args:    []string{"-config", writeFile(t, "timeout.yaml", "server:\n  idle_timeout: 10\n")},
+			wantErr: `time: missing unit in duration`,
+		},
+		{
+			name:    "timeout that isn't positive",
+			env:     map[string]string{"PROJECTS_READ_TIMEOUT": "0s"},
+			wantErr: `server.read_timeout must be positive, got 0s`,
+		},
+		{
+			name:    "request timeout outliving the write timeout",
+			args:    []string{"-request-timeout", "45s"},
+			wantErr: `server.request_timeout 45s must be shorter than server.write_timeout 30s`,
+		},
+		{
+			name:    "negative drain delay",
+			args:    []string{"-drain-delay", "-1s"},
+			wantErr: `server.drain_delay can't be negative, got -1s`,
+		},
+		{
+			name:    "invalid tracing settings",
+			args:    []string{"-tracing-exporter", "otlp", "-tracing-endpoint", "collector", "-tracing-sample-ratio", "2"},
+			wantErr: `tracing.endpoint "collector" isn't host:port; tracing.sample_ratio 2 isn't between 0 and 1`,
+		},
+		{
+			name: "invalid CORS and security settings",
+			args: []string{"-cors-credentials", "true", "-cors-max-age", "1h", "-hsts-max-age", "-1s", "-tls-cert", "cert.pem"},
+			wantErr: `server.tls_cert_file and server.tls_key_file must be set together; ` +
+				`cors.allow_credentials needs explicit origins instead of "*"; ` +
+				`cors.max_age must be between 0s and 10m, got 1h0m0s; ` +
+				`security.hsts_max_age can't be negative, got -1s`,
+		},
+		{
+			name:    "invalid body limits",
+			args:    []string{"-max-body-bytes", "0", "-config", writeFile(t, "body.yaml", "body:\n  routes:\n    - {method: POST, route: /projects/import}\n")},
+			wantErr: `body.max_bytes must be positive, got 0; body.routes POST /projects/import must allow a positive max_bytes, got 0`,
+		},
+		{
+			name: "invalid rate limit settings",
+			args: []string{"-rate-limit-store", "redis", "-rate-limit-read-rate", "0", "-rate-limit-write-burst", "0"},
+			wantErr: `rate_limit.store "redis" isn't memory or database; ` +
+				`rate_limit.read needs a positive rate and a burst of at least 1, got 0/s and 40; ` +
+				`rate_limit.write needs a positive rate and a burst of at least 1, got 5/s and 0`,
+		},
+		{
+			name:    "invalid rate limit route",
+			args:    []string{"-config", writeFile(t, "routes.yaml", "rate_limit:\n  routes:\n    - {method: get, route: tasks, rate: 1, burst: 1}\n")},
+			wantErr: `rate_limit.routes get tasks needs an upper case method and a route template starting with /`,
+		},
+		{
+			name:    "legacy routes without a deprecation date",
+			args:    []string{"-config", writeFile(t, "api.yaml", "api:\n  legacy_deprecated: \"\"\n  legacy_sunset: \"\"\n")},
+			wantErr: `api.legacy_deprecated is needed to serve the legacy routes`,
+		},
+		{
+			name:    "sunset before the deprecation",
+			args:    []string{"-legacy-deprecated", "2027-05-01"},
+			wantErr: `api.legacy_sunset 2027-04-30 must come after api.legacy_deprecated 2027-05-01`,
+		},
+		{
+			name:    "invalid date flag",
+			args:    []string{"-legacy-sunset", "next spring"},
+			wantErr: `invalid -legacy-sunset: parsing time "next spring"`,
+		},
+		{
+			name:    "invalid integer flag",
+			args:    []string{"-rate-limit-read-burst", "many"},
+			wantErr: `invalid -rate-limit-read-burst: strconv.Atoi: parsing "many": invalid syntax`,
+		},
+		{
+			name:    "invalid boolean flag",
+			args:    []string{"-tracing-insecure", "maybe"},
+			wantErr: `invalid -tracing-insecure: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+		{
+			name:    "invalid log settings",
+			args:    []string{"-log-level", "verbose", "-log-format", "xml"},
+			wantErr: `log.level: unknown log level "verbose", expected debug, info, warn or error; log.format "xml" isn't json or text`,
+		},
+		{
+			name:    "gRPC on the address of the REST API",
+			args:    []string{"-addr", "127.0.0.1:4040", "-grpc-addr", "127.0.0.1:4040"},
+			wantErr: `server.grpc_addr "127.0.0.1:4040" is the address of the REST API`,
+		},
+		{
+			name:    "invalid gRPC address",
+			env:     map[string]string{"PROJECTS_GRPC_ADDR": "4041"},
+			wantErr: `server.grpc_addr "4041" isn't host:port`,
+		},
+		{
+			name:    "invalid port from the environment",
+			env:     map[string]string{"PROJECTS_ADDR": "127.0.0.1:99999"},
+			wantErr: `server.addr "127.0.0.1:99999" has an invalid port`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.args, env(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package dal
+
+import "gorm.io/gorm"
+
+// registerer is implemented by the callbacks returned from the Before and
+// After methods of GORM's callback processors.
+type registerer interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+// registerAround installs the callbacks returned by before and after around
+// every kind of statement GORM runs: create, query, update, delete, row and
+// raw. The callbacks are named after plugin.
+func registerAround(db *gorm.DB, plugin string, before, after func(operation string) func(*gorm.DB)) error {
+	callbacks := db.Callback()
+	hooks := []struct {
+		operation     string
+		before, after registerer
+	}{
+		{"create", callbacks.Create().Before("gorm:create"), callbacks.Create().After("gorm:create")},
+		{"query", callbacks.Query().Before("gorm:query"), callbacks.Query().After("gorm:query")},
+		{"update", callbacks.Update().Before("gorm:update"), callbacks.Update().After("gorm:update")},
+		{"delete", callbacks.Delete().Before("gorm:delete"), callbacks.Delete().After("gorm:delete")},
+		{"row", callbacks.Row().Before("gorm:row"), callbacks.Row().After("gorm:row")},
+		{"raw", callbacks.Raw().Before("gorm:raw"), callbacks.Raw().After("gorm:raw")},
+	}
+	for _, hook := range hooks {
+		if err := hook.before.Register(plugin+":before_"+hook.operation, before(hook.operation)); err != nil {
+			return err
+		}
+		if err := hook.after.Register(plugin+":after_"+hook.operation, after(hook.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
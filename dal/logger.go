@@ -0,0 +1,55 @@
+package dal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// slowQueryThreshold is the duration after which a query is logged as a warning.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger sends GORM's own messages through the application logger instead
+// of standard output. Queries are logged at debug level, slow ones as warnings
+// and failed ones as errors; "record not found" is left to the callers, which
+// decide whether it's an error.
+type gormLogger struct {
+	logger logging.Logger
+}
+
+func (l gormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	// the level is chosen by the application logger
+	return l
+}
+
+func (l gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.Ctx(ctx).Info(fmt.Sprintf(msg, args...))
+}
+
+func (l gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.Ctx(ctx).Warn(fmt.Sprintf(msg, args...))
+}
+
+func (l gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.Ctx(ctx).Error(fmt.Sprintf(msg, args...))
+}
+
+func (l gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	logger := l.logger.Ctx(ctx)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		logger.Error("query failed", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case elapsed > slowQueryThreshold:
+		logger.Warn("slow query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	default:
+		logger.Debug("query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
@@ -0,0 +1,50 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// queryStartKey is the statement setting the start of a query is kept under.
+const queryStartKey = "metrics:query_start"
+
+// QueryMetrics returns a GORM plugin that observes the duration of every
+// statement, in seconds, labelled by operation (create, query, update, delete,
+// row or raw) and table.
+func QueryMetrics(durations prometheus.ObserverVec) gorm.Plugin {
+	return queryMetrics{durations: durations}
+}
+
+type queryMetrics struct {
+	durations prometheus.ObserverVec
+}
+
+func (queryMetrics) Name() string {
+	return "metrics"
+}
+
+func (p queryMetrics) Initialize(db *gorm.DB) error {
+	return registerAround(db, p.Name(), startQuery, p.observe)
+}
+
+func startQuery(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		db.InstanceSet(queryStartKey, time.Now())
+	}
+}
+
+func (p queryMetrics) observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		p.durations.WithLabelValues(operation, db.Statement.Table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,48 @@
+package dal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SchemaVersion is bumped whenever a model changes, so that a database left
+// behind by a failed migration can be told apart from one that is up to date.
+const SchemaVersion = 2
+
+// SchemaMigration records that the tables were migrated to Version.
+type SchemaMigration struct {
+	Version   int       `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time `json:"applied_at" gorm:"not null"`
+}
+
+// migrate creates or alters the tables of every model and records
+// SchemaVersion once all of them succeeded.
+func migrate(db *gorm.DB) error {
+	models := []interface{}{
+		&Project{}, &Column{}, &Task{}, &Comment{}, &IdempotencyKey{}, &CalendarFeed{}, &RateLimitBucket{}, &SchemaMigration{},
+	}
+	for _, model := range models {
+		if err := db.AutoMigrate(model); err != nil {
+			return fmt.Errorf("can't migrate %T: %w", model, err)
+		}
+	}
+	migration := &SchemaMigration{Version: SchemaVersion, AppliedAt: time.Now().UTC()}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(migration).Error
+}
+
+// CheckSchema reports an error unless the database was migrated to
+// SchemaVersion.
+func CheckSchema(ctx context.Context, repo Repository) error {
+	version, err := repo.GetSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version != SchemaVersion {
+		return fmt.Errorf("database schema is at version %d, expected %d", version, SchemaVersion)
+	}
+	return nil
+}
@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	dal "github.com/Boobuh/golang-school-project/dal"
 	gomock "github.com/golang/mock/gomock"
@@ -34,291 +36,553 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockRepository) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+}
+
 // CreateColumn mocks base method.
-func (m *MockRepository) CreateColumn(arg0 *dal.Column) error {
+func (m *MockRepository) CreateColumn(arg0 context.Context, arg1 *dal.Column) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateColumn", arg0)
+	ret := m.ctrl.Call(m, "CreateColumn", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateColumn indicates an expected call of CreateColumn.
-func (mr *MockRepositoryMockRecorder) CreateColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateColumn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockRepository)(nil).CreateColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockRepository)(nil).CreateColumn), arg0, arg1)
 }
 
 // CreateComment mocks base method.
-func (m *MockRepository) CreateComment(arg0 *dal.Comment) error {
+func (m *MockRepository) CreateComment(arg0 context.Context, arg1 *dal.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComment", arg0)
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateComment indicates an expected call of CreateComment.
-func (mr *MockRepositoryMockRecorder) CreateComment(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRepository)(nil).CreateComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRepository)(nil).CreateComment), arg0, arg1)
 }
 
 // CreateProject mocks base method.
-func (m *MockRepository) CreateProject(arg0 *dal.Project) (*dal.Project, error) {
+func (m *MockRepository) CreateProject(arg0 context.Context, arg1 *dal.Project) (*dal.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", arg0)
+	ret := m.ctrl.Call(m, "CreateProject", arg0, arg1)
 	ret0, _ := ret[0].(*dal.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProject indicates an expected call of CreateProject.
-func (mr *MockRepositoryMockRecorder) CreateProject(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockRepository)(nil).CreateProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockRepository)(nil).CreateProject), arg0, arg1)
 }
 
 // CreateTask mocks base method.
-func (m *MockRepository) CreateTask(arg0 *dal.Task) error {
+func (m *MockRepository) CreateTask(arg0 context.Context, arg1 *dal.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", arg0)
+	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockRepositoryMockRecorder) CreateTask(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockRepository)(nil).CreateTask), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockRepository)(nil).CreateTask), arg0, arg1)
 }
 
 // DeleteColumn mocks base method.
-func (m *MockRepository) DeleteColumn(arg0, arg1 int) error {
+func (m *MockRepository) DeleteColumn(arg0 context.Context, arg1, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteColumn", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteColumn", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteColumn indicates an expected call of DeleteColumn.
-func (mr *MockRepositoryMockRecorder) DeleteColumn(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteColumn(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockRepository)(nil).DeleteColumn), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockRepository)(nil).DeleteColumn), arg0, arg1, arg2)
 }
 
 // DeleteComment mocks base method.
-func (m *MockRepository) DeleteComment(arg0, arg1, arg2, arg3 int) error {
+func (m *MockRepository) DeleteComment(arg0 context.Context, arg1, arg2, arg3, arg4 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteComment indicates an expected call of DeleteComment.
-func (mr *MockRepositoryMockRecorder) DeleteComment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockRepository) DeleteExpiredIdempotencyKeys(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockRepositoryMockRecorder) DeleteExpiredIdempotencyKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockRepository)(nil).DeleteExpiredIdempotencyKeys), arg0, arg1)
+}
+
+// DeleteFullRateLimitBuckets mocks base method.
+func (m *MockRepository) DeleteFullRateLimitBuckets(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFullRateLimitBuckets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFullRateLimitBuckets indicates an expected call of DeleteFullRateLimitBuckets.
+func (mr *MockRepositoryMockRecorder) DeleteFullRateLimitBuckets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFullRateLimitBuckets", reflect.TypeOf((*MockRepository)(nil).DeleteFullRateLimitBuckets), arg0, arg1)
 }
 
 // DeleteProject mocks base method.
-func (m *MockRepository) DeleteProject(arg0 int) error {
+func (m *MockRepository) DeleteProject(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProject", arg0)
+	ret := m.ctrl.Call(m, "DeleteProject", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProject indicates an expected call of DeleteProject.
-func (mr *MockRepositoryMockRecorder) DeleteProject(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockRepository)(nil).DeleteProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockRepository)(nil).DeleteProject), arg0, arg1)
 }
 
 // DeleteTask mocks base method.
-func (m *MockRepository) DeleteTask(arg0, arg1, arg2 int) error {
+func (m *MockRepository) DeleteTask(arg0 context.Context, arg1, arg2, arg3 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockRepositoryMockRecorder) DeleteTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockRepository)(nil).DeleteTask), arg0, arg1, arg2, arg3)
+}
+
+// GetCalendarFeed mocks base method.
+func (m *MockRepository) GetCalendarFeed(arg0 context.Context, arg1 string) (*dal.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(*dal.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeed indicates an expected call of GetCalendarFeed.
+func (mr *MockRepositoryMockRecorder) GetCalendarFeed(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockRepository)(nil).DeleteTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeed", reflect.TypeOf((*MockRepository)(nil).GetCalendarFeed), arg0, arg1)
 }
 
 // GetColumn mocks base method.
-func (m *MockRepository) GetColumn(arg0 int) (*dal.ExtendedColumn, error) {
+func (m *MockRepository) GetColumn(arg0 context.Context, arg1 int) (*dal.ExtendedColumn, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetColumn", arg0)
+	ret := m.ctrl.Call(m, "GetColumn", arg0, arg1)
 	ret0, _ := ret[0].(*dal.ExtendedColumn)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetColumn indicates an expected call of GetColumn.
-func (mr *MockRepositoryMockRecorder) GetColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetColumn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockRepository)(nil).GetColumn), arg0, arg1)
+}
+
+// GetColumnRow mocks base method.
+func (m *MockRepository) GetColumnRow(arg0 context.Context, arg1 int) (*dal.Column, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnRow", arg0, arg1)
+	ret0, _ := ret[0].(*dal.Column)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnRow indicates an expected call of GetColumnRow.
+func (mr *MockRepositoryMockRecorder) GetColumnRow(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockRepository)(nil).GetColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnRow", reflect.TypeOf((*MockRepository)(nil).GetColumnRow), arg0, arg1)
 }
 
 // GetColumns mocks base method.
-func (m *MockRepository) GetColumns() ([]dal.Column, error) {
+func (m *MockRepository) GetColumns(arg0 context.Context) ([]dal.Column, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetColumns")
+	ret := m.ctrl.Call(m, "GetColumns", arg0)
 	ret0, _ := ret[0].([]dal.Column)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetColumns indicates an expected call of GetColumns.
-func (mr *MockRepositoryMockRecorder) GetColumns() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetColumns(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockRepository)(nil).GetColumns))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockRepository)(nil).GetColumns), arg0)
+}
+
+// GetColumnsByProjectIDs mocks base method.
+func (m *MockRepository) GetColumnsByProjectIDs(arg0 context.Context, arg1 []int) ([]dal.Column, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnsByProjectIDs", arg0, arg1)
+	ret0, _ := ret[0].([]dal.Column)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnsByProjectIDs indicates an expected call of GetColumnsByProjectIDs.
+func (mr *MockRepositoryMockRecorder) GetColumnsByProjectIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnsByProjectIDs", reflect.TypeOf((*MockRepository)(nil).GetColumnsByProjectIDs), arg0, arg1)
 }
 
 // GetComment mocks base method.
-func (m *MockRepository) GetComment(arg0 int) (*dal.Comment, error) {
+func (m *MockRepository) GetComment(arg0 context.Context, arg1 int) (*dal.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComment", arg0)
+	ret := m.ctrl.Call(m, "GetComment", arg0, arg1)
 	ret0, _ := ret[0].(*dal.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetComment indicates an expected call of GetComment.
-func (mr *MockRepositoryMockRecorder) GetComment(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockRepository)(nil).GetComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockRepository)(nil).GetComment), arg0, arg1)
 }
 
 // GetComments mocks base method.
-func (m *MockRepository) GetComments() ([]dal.Comment, error) {
+func (m *MockRepository) GetComments(arg0 context.Context) ([]dal.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComments")
+	ret := m.ctrl.Call(m, "GetComments", arg0)
 	ret0, _ := ret[0].([]dal.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetComments indicates an expected call of GetComments.
-func (mr *MockRepositoryMockRecorder) GetComments() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetComments(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockRepository)(nil).GetComments))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockRepository)(nil).GetComments), arg0)
+}
+
+// GetCommentsByTaskIDs mocks base method.
+func (m *MockRepository) GetCommentsByTaskIDs(arg0 context.Context, arg1 []int) ([]dal.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentsByTaskIDs", arg0, arg1)
+	ret0, _ := ret[0].([]dal.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentsByTaskIDs indicates an expected call of GetCommentsByTaskIDs.
+func (mr *MockRepositoryMockRecorder) GetCommentsByTaskIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByTaskIDs", reflect.TypeOf((*MockRepository)(nil).GetCommentsByTaskIDs), arg0, arg1)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockRepository) GetIdempotencyKey(arg0 context.Context, arg1 string) (*dal.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", arg0, arg1)
+	ret0, _ := ret[0].(*dal.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) GetIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).GetIdempotencyKey), arg0, arg1)
 }
 
 // GetProject mocks base method.
-func (m *MockRepository) GetProject(arg0 int) (*dal.ExtendedProjectEntities, error) {
+func (m *MockRepository) GetProject(arg0 context.Context, arg1 int) (*dal.ExtendedProjectEntities, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProject", arg0)
+	ret := m.ctrl.Call(m, "GetProject", arg0, arg1)
 	ret0, _ := ret[0].(*dal.ExtendedProjectEntities)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProject indicates an expected call of GetProject.
-func (mr *MockRepositoryMockRecorder) GetProject(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockRepository)(nil).GetProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockRepository)(nil).GetProject), arg0, arg1)
 }
 
 // GetProjects mocks base method.
-func (m *MockRepository) GetProjects() ([]dal.Project, error) {
+func (m *MockRepository) GetProjects(arg0 context.Context) ([]dal.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjects")
+	ret := m.ctrl.Call(m, "GetProjects", arg0)
 	ret0, _ := ret[0].([]dal.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjects indicates an expected call of GetProjects.
-func (mr *MockRepositoryMockRecorder) GetProjects() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockRepository)(nil).GetProjects), arg0)
+}
+
+// GetProjectsByIDs mocks base method.
+func (m *MockRepository) GetProjectsByIDs(arg0 context.Context, arg1 []int) ([]dal.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectsByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]dal.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectsByIDs indicates an expected call of GetProjectsByIDs.
+func (mr *MockRepositoryMockRecorder) GetProjectsByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsByIDs", reflect.TypeOf((*MockRepository)(nil).GetProjectsByIDs), arg0, arg1)
+}
+
+// GetRateLimitBucket mocks base method.
+func (m *MockRepository) GetRateLimitBucket(arg0 context.Context, arg1 string) (*dal.RateLimitBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateLimitBucket", arg0, arg1)
+	ret0, _ := ret[0].(*dal.RateLimitBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateLimitBucket indicates an expected call of GetRateLimitBucket.
+func (mr *MockRepositoryMockRecorder) GetRateLimitBucket(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockRepository)(nil).GetProjects))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateLimitBucket", reflect.TypeOf((*MockRepository)(nil).GetRateLimitBucket), arg0, arg1)
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockRepository) GetSchemaVersion(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockRepositoryMockRecorder) GetSchemaVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockRepository)(nil).GetSchemaVersion), arg0)
+}
+
+// GetStats mocks base method.
+func (m *MockRepository) GetStats(arg0 context.Context) (*dal.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0)
+	ret0, _ := ret[0].(*dal.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockRepositoryMockRecorder) GetStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockRepository)(nil).GetStats), arg0)
 }
 
 // GetTask mocks base method.
-func (m *MockRepository) GetTask(arg0 int) (*dal.ExtendedTask, error) {
+func (m *MockRepository) GetTask(arg0 context.Context, arg1 int) (*dal.ExtendedTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", arg0)
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1)
 	ret0, _ := ret[0].(*dal.ExtendedTask)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockRepositoryMockRecorder) GetTask(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockRepository)(nil).GetTask), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockRepository)(nil).GetTask), arg0, arg1)
 }
 
 // GetTasks mocks base method.
-func (m *MockRepository) GetTasks() ([]dal.Task, error) {
+func (m *MockRepository) GetTasks(arg0 context.Context) ([]dal.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks")
+	ret := m.ctrl.Call(m, "GetTasks", arg0)
 	ret0, _ := ret[0].([]dal.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockRepositoryMockRecorder) GetTasks() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockRepository)(nil).GetTasks), arg0)
+}
+
+// GetTasksByColumnIDs mocks base method.
+func (m *MockRepository) GetTasksByColumnIDs(arg0 context.Context, arg1 []int) ([]dal.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByColumnIDs", arg0, arg1)
+	ret0, _ := ret[0].([]dal.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByColumnIDs indicates an expected call of GetTasksByColumnIDs.
+func (mr *MockRepositoryMockRecorder) GetTasksByColumnIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByColumnIDs", reflect.TypeOf((*MockRepository)(nil).GetTasksByColumnIDs), arg0, arg1)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), arg0)
+}
+
+// SaveCalendarFeed mocks base method.
+func (m *MockRepository) SaveCalendarFeed(arg0 context.Context, arg1 *dal.CalendarFeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCalendarFeed indicates an expected call of SaveCalendarFeed.
+func (mr *MockRepositoryMockRecorder) SaveCalendarFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCalendarFeed", reflect.TypeOf((*MockRepository)(nil).SaveCalendarFeed), arg0, arg1)
+}
+
+// SaveIdempotencyKey mocks base method.
+func (m *MockRepository) SaveIdempotencyKey(arg0 context.Context, arg1 *dal.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyKey indicates an expected call of SaveIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) SaveIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).SaveIdempotencyKey), arg0, arg1)
+}
+
+// SaveRateLimitBucket mocks base method.
+func (m *MockRepository) SaveRateLimitBucket(arg0 context.Context, arg1 *dal.RateLimitBucket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRateLimitBucket", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRateLimitBucket indicates an expected call of SaveRateLimitBucket.
+func (mr *MockRepositoryMockRecorder) SaveRateLimitBucket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRateLimitBucket", reflect.TypeOf((*MockRepository)(nil).SaveRateLimitBucket), arg0, arg1)
+}
+
+// Transaction mocks base method.
+func (m *MockRepository) Transaction(arg0 context.Context, arg1 func(dal.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockRepositoryMockRecorder) Transaction(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockRepository)(nil).GetTasks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockRepository)(nil).Transaction), arg0, arg1)
 }
 
 // UpdateColumn mocks base method.
-func (m *MockRepository) UpdateColumn(arg0 *dal.Column) error {
+func (m *MockRepository) UpdateColumn(arg0 context.Context, arg1 *dal.Column) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateColumn", arg0)
+	ret := m.ctrl.Call(m, "UpdateColumn", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateColumn indicates an expected call of UpdateColumn.
-func (mr *MockRepositoryMockRecorder) UpdateColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateColumn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockRepository)(nil).UpdateColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockRepository)(nil).UpdateColumn), arg0, arg1)
 }
 
 // UpdateComment mocks base method.
-func (m *MockRepository) UpdateComment(arg0 *dal.Comment) error {
+func (m *MockRepository) UpdateComment(arg0 context.Context, arg1 *dal.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateComment", arg0)
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateComment indicates an expected call of UpdateComment.
-func (mr *MockRepositoryMockRecorder) UpdateComment(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepository)(nil).UpdateComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepository)(nil).UpdateComment), arg0, arg1)
 }
 
 // UpdateProject mocks base method.
-func (m *MockRepository) UpdateProject(arg0 *dal.Project) error {
+func (m *MockRepository) UpdateProject(arg0 context.Context, arg1 *dal.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", arg0)
+	ret := m.ctrl.Call(m, "UpdateProject", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockRepositoryMockRecorder) UpdateProject(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockRepository)(nil).UpdateProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockRepository)(nil).UpdateProject), arg0, arg1)
 }
 
 // UpdateTask mocks base method.
-func (m *MockRepository) UpdateTask(arg0 *dal.Task) error {
+func (m *MockRepository) UpdateTask(arg0 context.Context, arg1 *dal.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", arg0)
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockRepositoryMockRecorder) UpdateTask(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockRepository)(nil).UpdateTask), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockRepository)(nil).UpdateTask), arg0, arg1)
 }
@@ -1,26 +1,132 @@
 package dal
 
+import "time"
+
 type Project struct {
 	ID          int    `json:"id" gorm:"primaryKey; autoIncrement"`
-	Name        string `json:"name"gorm:"name;type:varchar(500);not null"`
-	Description string `json:"description"gorm:"type:varchar(1000);description"`
+	Name        string `json:"name" gorm:"name;type:varchar(500);not null"`
+	Description string `json:"description" gorm:"type:varchar(1000);description"`
 }
 type Column struct {
-	ID        int    `json:"id"gorm:"primaryKey; AUTO_INCREMENT"`
-	Name      string `json:"name"gorm:"name;type:varchar(255);not null;unique"`
-	ProjectID int    `json:"project_id"gorm:"project_id; not null"`
-	OrderNum  int    `json:"order_number"gorm:"order_number"`
-	Status    string `json:"status"gorm:"status"`
+	ID        int    `json:"id" gorm:"primaryKey; AUTO_INCREMENT"`
+	Name      string `json:"name" gorm:"name;type:varchar(255);not null;unique"`
+	ProjectID int    `json:"project_id" gorm:"project_id; not null"`
+	OrderNum  int    `json:"order_number" gorm:"order_number"`
+	Status    string `json:"status" gorm:"status"`
 }
 type Task struct {
-	ID          int    `json:"id"gorm:"primaryKey; autoIncrement; not null"`
-	Name        string `json:"name"gorm:"name;type:varchar(500); not null"`
-	Status      bool   `json:"status"gorm:"status"`
-	Description string `json:"description"gorm:"type:varchar(5000);description"`
-	ColumnID    int    `json:"column_id"gorm:"column_id; not null"`
+	ID          int        `json:"id" gorm:"primaryKey; autoIncrement; not null"`
+	Name        string     `json:"name" gorm:"name;type:varchar(500); not null"`
+	Status      bool       `json:"status" gorm:"status"`
+	Description string     `json:"description" gorm:"type:varchar(5000);description"`
+	ColumnID    int        `json:"column_id" gorm:"column_id; not null"`
+	DueDate     *time.Time `json:"due_date" gorm:"index"`
 }
 type Comment struct {
-	Description string `json:"description"gorm:"description;type:varchar(5000)"`
-	TaskID      int    `json:"task_id"gorm:"task_id; not null"`
-	ID          int    `json:"id"gorm:"primaryKey; autoIncrement"`
+	Description string `json:"description" gorm:"description;type:varchar(5000)"`
+	TaskID      int    `json:"task_id" gorm:"task_id; not null"`
+	ID          int    `json:"id" gorm:"primaryKey; autoIncrement"`
+}
+type IdempotencyKey struct {
+	Key         string    `json:"key" gorm:"primaryKey;type:varchar(255)"`
+	Fingerprint string    `json:"fingerprint" gorm:"type:varchar(64);not null"`
+	StatusCode  int       `json:"status_code" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"type:varchar(255)"`
+	Body        []byte    `json:"body"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"index;not null"`
+}
+
+// RateLimitBucket is the token bucket of one client, shared by every server
+// using the database. FullAt is when the bucket will have refilled completely,
+// after which it can be deleted without changing any decision.
+type RateLimitBucket struct {
+	Key        string    `json:"key" gorm:"primaryKey;type:varchar(255)"`
+	Tokens     float64   `json:"tokens" gorm:"not null"`
+	RefilledAt time.Time `json:"refilled_at" gorm:"not null"`
+	FullAt     time.Time `json:"full_at" gorm:"index;not null"`
+}
+
+const (
+	BatchModeAtomic  = "atomic"
+	BatchModePerItem = "per_item"
+
+	TaskOpCreate = "create"
+	TaskOpUpdate = "update"
+	TaskOpMove   = "move"
+	TaskOpDelete = "delete"
+
+	TaskOpStatusOK         = "ok"
+	TaskOpStatusFailed     = "failed"
+	TaskOpStatusRolledBack = "rolled_back"
+	TaskOpStatusSkipped    = "skipped"
+)
+
+type TaskBatch struct {
+	Mode       string          `json:"mode"`
+	Operations []TaskOperation `json:"operations"`
+}
+type TaskOperation struct {
+	Op   string `json:"op"`
+	Task Task   `json:"task"`
+}
+type TaskOperationResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	Task   *Task  `json:"task,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ArchiveSchemaVersion is bumped whenever the layout of ProjectArchive changes
+// in a way older importers can't read.
+const ArchiveSchemaVersion = 1
+
+type ProjectArchive struct {
+	SchemaVersion int                     `json:"schema_version"`
+	ExportedAt    time.Time               `json:"exported_at"`
+	Project       ExtendedProjectEntities `json:"project"`
+}
+
+type ImportReport struct {
+	Project   *Project           `json:"project"`
+	Columns   int                `json:"columns"`
+	Tasks     int                `json:"tasks"`
+	Comments  int                `json:"comments"`
+	Converted []ImportReportItem `json:"converted"`
+	Skipped   []ImportReportItem `json:"skipped"`
+}
+type ImportReportItem struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type TaskImportReport struct {
+	DryRun         bool                 `json:"dry_run"`
+	Created        int                  `json:"created"`
+	CreatedColumns []string             `json:"created_columns"`
+	Errors         []TaskImportRowError `json:"errors"`
+}
+type TaskImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// CalendarFeed grants read access to the iCalendar feed of a project to anyone
+// who knows its token. A project has at most one feed.
+type CalendarFeed struct {
+	Token     string    `json:"token" gorm:"primaryKey;type:varchar(64)"`
+	ProjectID int       `json:"project_id" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	// URL is the path the feed is served at. It is filled in by the handler.
+	URL string `json:"url" gorm:"-"`
+}
+
+// Stats are the totals the business metrics are computed from. Open tasks are
+// those whose status isn't done yet.
+type Stats struct {
+	Projects  int64
+	Tasks     int64
+	OpenTasks int64
 }
@@ -3,54 +3,91 @@ package dal
 //go:generate   $GOPATH/bin/mockgen -package mocks -destination=mocks/mock_repository.go -package=mocks github.com/Boobuh/golang-school-project/dal Repository
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
 type Repository interface {
 	//-----------------------------------------//
-	GetProjects() ([]Project, error)
-	GetProject(id int) (*ExtendedProjectEntities, error)
-	UpdateProject(project *Project) error
-	CreateProject(project *Project) (*Project, error)
-	DeleteProject(id int) error
+	GetProjects(ctx context.Context) ([]Project, error)
+	GetProjectsByIDs(ctx context.Context, ids []int) ([]Project, error)
+	GetProject(ctx context.Context, id int) (*ExtendedProjectEntities, error)
+	UpdateProject(ctx context.Context, project *Project) error
+	CreateProject(ctx context.Context, project *Project) (*Project, error)
+	DeleteProject(ctx context.Context, id int) error
+	//-----------------------------------------//
+	GetColumns(ctx context.Context) ([]Column, error)
+	GetColumnsByProjectIDs(ctx context.Context, projectIDs []int) ([]Column, error)
+	GetColumn(ctx context.Context, id int) (*ExtendedColumn, error)
+	GetColumnRow(ctx context.Context, id int) (*Column, error)
+	UpdateColumn(ctx context.Context, updatedColumn *Column) error
+	CreateColumn(ctx context.Context, column *Column) error
+	DeleteColumn(ctx context.Context, projectID, columnID int) error
+	//-----------------------------------------//
+	GetTasks(ctx context.Context) ([]Task, error)
+	GetTasksByColumnIDs(ctx context.Context, columnIDs []int) ([]Task, error)
+	GetTask(ctx context.Context, id int) (*ExtendedTask, error)
+	UpdateTask(ctx context.Context, updatedTask *Task) error
+	CreateTask(ctx context.Context, task *Task) error
+	DeleteTask(ctx context.Context, projectID, columnID, taskID int) error
+	//-----------------------------------------//
+	GetComments(ctx context.Context) ([]Comment, error)
+	GetCommentsByTaskIDs(ctx context.Context, taskIDs []int) ([]Comment, error)
+	GetComment(ctx context.Context, id int) (*Comment, error)
+	UpdateComment(ctx context.Context, updatedComment *Comment) error
+	CreateComment(ctx context.Context, comment *Comment) error
+	DeleteComment(ctx context.Context, projectID, columnID, taskID, commentID int) error
+	//-----------------------------------------//
+	GetIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error)
+	SaveIdempotencyKey(ctx context.Context, record *IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error
+	//-----------------------------------------//
+	GetRateLimitBucket(ctx context.Context, key string) (*RateLimitBucket, error)
+	SaveRateLimitBucket(ctx context.Context, bucket *RateLimitBucket) error
+	DeleteFullRateLimitBuckets(ctx context.Context, now time.Time) error
 	//-----------------------------------------//
-	GetColumns() ([]Column, error)
-	GetColumn(id int) (*ExtendedColumn, error)
-	UpdateColumn(updatedColumn *Column) error
-	CreateColumn(column *Column) error
-	DeleteColumn(projectID, columnID int) error
+	GetCalendarFeed(ctx context.Context, token string) (*CalendarFeed, error)
+	SaveCalendarFeed(ctx context.Context, feed *CalendarFeed) error
 	//-----------------------------------------//
-	GetTasks() ([]Task, error)
-	GetTask(id int) (*ExtendedTask, error)
-	UpdateTask(updatedTask *Task) error
-	CreateTask(task *Task) error
-	DeleteTask(projectID, columnID, taskID int) error
+	GetStats(ctx context.Context) (*Stats, error)
 	//-----------------------------------------//
-	GetComments() ([]Comment, error)
-	GetComment(id int) (*Comment, error)
-	UpdateComment(updatedComment *Comment) error
-	CreateComment(comment *Comment) error
-	DeleteComment(projectID, columnID, taskID, commentID int) error
+	GetSchemaVersion(ctx context.Context) (int, error)
+	Ping(ctx context.Context) error
+	//-----------------------------------------//
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
+	Close() error
 	//-----------------------------------------//
 }
 
 type RepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	logger logging.Logger
 }
 
-func NewRepository() Repository {
-	db, err := gorm.Open(sqlite.Open("projects.db"), &gorm.Config{})
+// NewRepository opens the sqlite database at path, creating it and its tables
+// when they don't exist yet. plugins, such as QueryMetrics, are installed
+// before the tables are migrated.
+func NewRepository(path string, logger logging.Logger, plugins ...gorm.Plugin) Repository {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: gormLogger{logger: logger}})
 	if err != nil {
-		panic("failed to connect database")
+		panic(fmt.Sprintf("failed to connect database %s: %v", path, err))
+	}
+	for _, plugin := range plugins {
+		if err := db.Use(plugin); err != nil {
+			panic(fmt.Sprintf("failed to install gorm plugin %s: %v", plugin.Name(), err))
+		}
 	}
-	db.AutoMigrate(&Project{})
-	db.AutoMigrate(&Column{})
-	db.AutoMigrate(&Task{})
-	db.AutoMigrate(&Comment{})
-	return &RepositoryImpl{db: db}
+	if err := migrate(db); err != nil {
+		// the server still starts, readiness reports the database as not migrated
+		logger.Error("error migrating database", "path", path, "error", err)
+	}
+	return &RepositoryImpl{db: db, logger: logger}
 }
 
 //----------------------------------------------------------------------------------------//
@@ -70,27 +107,37 @@ type ExtendedTask struct {
 	Comments []Comment
 }
 
-func (r *RepositoryImpl) GetProjects() ([]Project, error) {
+func (r *RepositoryImpl) GetProjects(ctx context.Context) ([]Project, error) {
 	var projects []Project
-	err := r.db.Find(&projects).Error
+	err := r.db.WithContext(ctx).Find(&projects).Error
 	return projects, err
 
 }
 
-func (r *RepositoryImpl) GetProject(id int) (*ExtendedProjectEntities, error) {
+// GetProjectsByIDs finds the projects with any of ids in one query, skipping
+// the ids that don't exist.
+func (r *RepositoryImpl) GetProjectsByIDs(ctx context.Context, ids []int) ([]Project, error) {
+	var projects []Project
+	err := r.db.WithContext(ctx).Find(&projects, "id IN ?", ids).Error
+	return projects, err
+}
+
+func (r *RepositoryImpl) GetProject(ctx context.Context, id int) (*ExtendedProjectEntities, error) {
+	db := r.db.WithContext(ctx)
+	logger := r.logger.Ctx(ctx)
 	//TODO: create projectEntities wich has no project but all columns tasks and comments that belongs to this project
 	var extendedProject ExtendedProjectEntities
 	var project *Project
-	err := r.db.First(&project, id).Error
+	err := db.First(&project, id).Error
 	if err != nil {
-		fmt.Printf("error retreiving project by id:%s\n", err.Error())
+		logger.Warn("error retrieving project by id", "project_id", id, "error", err)
 		return nil, err
 	}
 	extendedProject.Project = *project
 	var columns []Column
-	err = r.db.Find(&columns, "project_id = ?", project.ID).Error
+	err = db.Find(&columns, "project_id = ?", project.ID).Error
 	if err != nil {
-		fmt.Printf("error finding columns by project_id:%s\n", err.Error())
+		logger.Error("error finding columns by project_id", "project_id", project.ID, "error", err)
 		return nil, err
 	}
 
@@ -99,9 +146,9 @@ func (r *RepositoryImpl) GetProject(id int) (*ExtendedProjectEntities, error) {
 		var extColumn ExtendedColumn
 		extColumn.Column = column
 		var tasks []Task
-		err := r.db.Find(&tasks, "column_id = ?", column.ID).Error
+		err := db.Find(&tasks, "column_id = ?", column.ID).Error
 		if err != nil {
-			fmt.Printf("error finding tasks by column_id:%s\n", err.Error())
+			logger.Error("error finding tasks by column_id", "column_id", column.ID, "error", err)
 			return nil, err
 		}
 		var extTasks []ExtendedTask
@@ -109,9 +156,9 @@ func (r *RepositoryImpl) GetProject(id int) (*ExtendedProjectEntities, error) {
 			var extTask ExtendedTask
 			extTask.Task = task
 			var comments []Comment
-			err := r.db.Find(&comments, "task_id = ?", task.ID).Error
+			err := db.Find(&comments, "task_id = ?", task.ID).Error
 			if err != nil {
-				fmt.Printf("error finding comments by task_id:%s\n", err.Error())
+				logger.Error("error finding comments by task_id", "task_id", task.ID, "error", err)
 				return nil, err
 			}
 			extTask.Comments = comments
@@ -127,12 +174,12 @@ func (r *RepositoryImpl) GetProject(id int) (*ExtendedProjectEntities, error) {
 
 }
 
-func (r *RepositoryImpl) UpdateProject(updatedProject *Project) error {
-	return r.db.Model(&updatedProject).Updates(updatedProject).Error
+func (r *RepositoryImpl) UpdateProject(ctx context.Context, updatedProject *Project) error {
+	return r.db.WithContext(ctx).Model(&updatedProject).Updates(updatedProject).Error
 }
 
-func (r *RepositoryImpl) CreateProject(project *Project) (*Project, error) {
-	err := r.db.Create(&project).Error
+func (r *RepositoryImpl) CreateProject(ctx context.Context, project *Project) (*Project, error) {
+	err := r.db.WithContext(ctx).Create(&project).Error
 	if err != nil {
 		return nil, err
 	}
@@ -140,33 +187,55 @@ func (r *RepositoryImpl) CreateProject(project *Project) (*Project, error) {
 	return project, err
 }
 
-func (r *RepositoryImpl) DeleteProject(id int) error {
-	project := &Project{ID: id}
-	return r.db.Delete(&project).Error
+// DeleteProject deletes a project with its columns, tasks and comments in one
+// transaction, so that a failure leaves the board as it was.
+func (r *RepositoryImpl) DeleteProject(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		columns := tx.Model(&Column{}).Select("id").Where("project_id = ?", id)
+		tasks := tx.Model(&Task{}).Select("id").Where("column_id IN (?)", columns)
+		if err := tx.Where("task_id IN (?)", tasks).Delete(&Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("column_id IN (?)", columns).Delete(&Task{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&Column{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Project{ID: id}).Error
+	})
 }
 
 //----------------------------------------------------------------------------------------//
 
-func (r *RepositoryImpl) GetColumns() ([]Column, error) {
+func (r *RepositoryImpl) GetColumns(ctx context.Context) ([]Column, error) {
 	var columns []Column
-	err := r.db.Find(&columns).Error
+	err := r.db.WithContext(ctx).Find(&columns).Error
 	return columns, err
 
 }
 
-func (r *RepositoryImpl) GetColumn(id int) (*ExtendedColumn, error) {
+// GetColumnsByProjectIDs finds the columns of all the projects in one query.
+func (r *RepositoryImpl) GetColumnsByProjectIDs(ctx context.Context, projectIDs []int) ([]Column, error) {
+	var columns []Column
+	err := r.db.WithContext(ctx).Find(&columns, "project_id IN ?", projectIDs).Error
+	return columns, err
+}
 
+func (r *RepositoryImpl) GetColumn(ctx context.Context, id int) (*ExtendedColumn, error) {
+	db := r.db.WithContext(ctx)
+	logger := r.logger.Ctx(ctx)
 	var column Column
-	err := r.db.First(&column, id).Error
+	err := db.First(&column, id).Error
 	if err != nil {
 		return nil, err
 	}
 	var extColumn ExtendedColumn
 	extColumn.Column = column
 	var tasks []Task
-	err = r.db.Find(&tasks, "column_id = ?", column.ID).Error
+	err = db.Find(&tasks, "column_id = ?", column.ID).Error
 	if err != nil {
-		fmt.Printf("error finding tasks by column_id:%s\n", err.Error())
+		logger.Error("error finding tasks by column_id", "column_id", column.ID, "error", err)
 		return nil, err
 	}
 	var extTasks []ExtendedTask
@@ -174,9 +243,9 @@ func (r *RepositoryImpl) GetColumn(id int) (*ExtendedColumn, error) {
 		var extTask ExtendedTask
 		extTask.Task = task
 		var comments []Comment
-		err := r.db.Find(&comments, "task_id = ?", task.ID).Error
+		err := db.Find(&comments, "task_id = ?", task.ID).Error
 		if err != nil {
-			fmt.Printf("error finding comments by task_id:%s\n", err.Error())
+			logger.Error("error finding comments by task_id", "task_id", task.ID, "error", err)
 			return nil, err
 		}
 		extTask.Comments = comments
@@ -190,40 +259,57 @@ func (r *RepositoryImpl) GetColumn(id int) (*ExtendedColumn, error) {
 
 }
 
-func (r *RepositoryImpl) UpdateColumn(updatedColumn *Column) error {
-	return r.db.Save(updatedColumn).Error
+// GetColumnRow finds a column without loading its tasks and comments.
+func (r *RepositoryImpl) GetColumnRow(ctx context.Context, id int) (*Column, error) {
+	var column Column
+	if err := r.db.WithContext(ctx).First(&column, id).Error; err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+func (r *RepositoryImpl) UpdateColumn(ctx context.Context, updatedColumn *Column) error {
+	return r.db.WithContext(ctx).Save(updatedColumn).Error
 }
 
-func (r *RepositoryImpl) CreateColumn(column *Column) error {
-	return r.db.Create(column).Error
+func (r *RepositoryImpl) CreateColumn(ctx context.Context, column *Column) error {
+	return r.db.WithContext(ctx).Create(column).Error
 }
 
-func (r *RepositoryImpl) DeleteColumn(projectID, columnID int) error {
+func (r *RepositoryImpl) DeleteColumn(ctx context.Context, projectID, columnID int) error {
 	column := &Column{ID: columnID, ProjectID: projectID}
-	return r.db.Delete(&column).Error
+	return r.db.WithContext(ctx).Delete(&column).Error
 }
 
 //----------------------------------------------------------------------------------------//
 
-func (r *RepositoryImpl) GetTasks() ([]Task, error) {
+func (r *RepositoryImpl) GetTasks(ctx context.Context) ([]Task, error) {
 	var tasks []Task
-	err := r.db.Find(&tasks).Error
+	err := r.db.WithContext(ctx).Find(&tasks).Error
 	return tasks, err
 
 }
 
-func (r *RepositoryImpl) GetTask(id int) (*ExtendedTask, error) {
+// GetTasksByColumnIDs finds the tasks of all the columns in one query.
+func (r *RepositoryImpl) GetTasksByColumnIDs(ctx context.Context, columnIDs []int) ([]Task, error) {
+	var tasks []Task
+	err := r.db.WithContext(ctx).Find(&tasks, "column_id IN ?", columnIDs).Error
+	return tasks, err
+}
+
+func (r *RepositoryImpl) GetTask(ctx context.Context, id int) (*ExtendedTask, error) {
+	db := r.db.WithContext(ctx)
 	var task Task
-	err := r.db.First(&task, id).Error
+	err := db.First(&task, id).Error
 	if err != nil {
 		return nil, err
 	}
 	var extTask ExtendedTask
 	extTask.Task = task
 	var comments []Comment
-	err = r.db.Find(&comments, "task_id = ?", task.ID).Error
+	err = db.Find(&comments, "task_id = ?", task.ID).Error
 	if err != nil {
-		fmt.Printf("error finding comments by task_id:%s\n", err.Error())
+		r.logger.Ctx(ctx).Error("error finding comments by task_id", "task_id", task.ID, "error", err)
 		return nil, err
 	}
 
@@ -232,45 +318,171 @@ func (r *RepositoryImpl) GetTask(id int) (*ExtendedTask, error) {
 	return &extTask, nil
 }
 
-func (r *RepositoryImpl) UpdateTask(updatedTask *Task) error {
-	return r.db.Save(updatedTask).Error
+func (r *RepositoryImpl) UpdateTask(ctx context.Context, updatedTask *Task) error {
+	return r.db.WithContext(ctx).Save(updatedTask).Error
 }
 
-func (r *RepositoryImpl) CreateTask(task *Task) error {
-	return r.db.Create(task).Error
+func (r *RepositoryImpl) CreateTask(ctx context.Context, task *Task) error {
+	return r.db.WithContext(ctx).Create(task).Error
 }
 
-func (r *RepositoryImpl) DeleteTask(projectID, columnID, taskID int) error {
+func (r *RepositoryImpl) DeleteTask(ctx context.Context, projectID, columnID, taskID int) error {
 	task := &Task{ID: taskID, ColumnID: columnID}
-	return r.db.Delete(&task).Error
+	return r.db.WithContext(ctx).Delete(&task).Error
 }
 
 //----------------------------------------------------------------------------------------//
 
-func (r *RepositoryImpl) GetComments() ([]Comment, error) {
+func (r *RepositoryImpl) GetComments(ctx context.Context) ([]Comment, error) {
+	var comments []Comment
+	err := r.db.WithContext(ctx).Find(&comments).Error
+	return comments, err
+}
+
+// GetCommentsByTaskIDs finds the comments of all the tasks in one query.
+func (r *RepositoryImpl) GetCommentsByTaskIDs(ctx context.Context, taskIDs []int) ([]Comment, error) {
 	var comments []Comment
-	err := r.db.Find(&comments).Error
+	err := r.db.WithContext(ctx).Find(&comments, "task_id IN ?", taskIDs).Error
 	return comments, err
 }
 
-func (r *RepositoryImpl) GetComment(id int) (*Comment, error) {
+func (r *RepositoryImpl) GetComment(ctx context.Context, id int) (*Comment, error) {
 	var comment *Comment
-	err := r.db.Find(&comment, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Find(&comment, "id = ?", id).Error
 	return comment, err
 }
 
-func (r *RepositoryImpl) UpdateComment(updatedComment *Comment) error {
-	err := r.db.Save(updatedComment).Error
+func (r *RepositoryImpl) UpdateComment(ctx context.Context, updatedComment *Comment) error {
+	err := r.db.WithContext(ctx).Save(updatedComment).Error
 	return err
 }
 
-func (r *RepositoryImpl) CreateComment(comment *Comment) error {
-	return r.db.Create(comment).Error
+func (r *RepositoryImpl) CreateComment(ctx context.Context, comment *Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
 }
 
-func (r *RepositoryImpl) DeleteComment(projectID, columnID, taskID, commentID int) error {
+func (r *RepositoryImpl) DeleteComment(ctx context.Context, projectID, columnID, taskID, commentID int) error {
 	comment := &Comment{ID: commentID, TaskID: taskID}
-	return r.db.Delete(&comment).Error
+	return r.db.WithContext(ctx).Delete(&comment).Error
+}
+
+//----------------------------------------------------------------------------------------//
+
+// GetIdempotencyKey returns nil without an error when the key has never been stored.
+func (r *RepositoryImpl) GetIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error) {
+	var records []IdempotencyKey
+	err := r.db.WithContext(ctx).Limit(1).Find(&records, "key = ?", key).Error
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+func (r *RepositoryImpl) SaveIdempotencyKey(ctx context.Context, record *IdempotencyKey) error {
+	return r.db.WithContext(ctx).Save(record).Error
+}
+
+func (r *RepositoryImpl) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error {
+	return r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&IdempotencyKey{}).Error
+}
+
+//----------------------------------------------------------------------------------------//
+
+// GetRateLimitBucket returns nil without an error when the client has no bucket.
+func (r *RepositoryImpl) GetRateLimitBucket(ctx context.Context, key string) (*RateLimitBucket, error) {
+	var buckets []RateLimitBucket
+	err := r.db.WithContext(ctx).Limit(1).Find(&buckets, "key = ?", key).Error
+	if err != nil || len(buckets) == 0 {
+		return nil, err
+	}
+	return &buckets[0], nil
+}
+
+func (r *RepositoryImpl) SaveRateLimitBucket(ctx context.Context, bucket *RateLimitBucket) error {
+	return r.db.WithContext(ctx).Save(bucket).Error
+}
+
+// DeleteFullRateLimitBuckets deletes the buckets that have refilled by now; a
+// missing bucket is treated as a full one.
+func (r *RepositoryImpl) DeleteFullRateLimitBuckets(ctx context.Context, now time.Time) error {
+	return r.db.WithContext(ctx).Where("full_at <= ?", now).Delete(&RateLimitBucket{}).Error
+}
+
+//----------------------------------------------------------------------------------------//
+
+// GetCalendarFeed returns nil without an error when no feed has the token.
+func (r *RepositoryImpl) GetCalendarFeed(ctx context.Context, token string) (*CalendarFeed, error) {
+	var feeds []CalendarFeed
+	err := r.db.WithContext(ctx).Limit(1).Find(&feeds, "token = ?", token).Error
+	if err != nil || len(feeds) == 0 {
+		return nil, err
+	}
+	return &feeds[0], nil
+}
+
+// SaveCalendarFeed replaces the project's feed, so the previous token stops working.
+func (r *RepositoryImpl) SaveCalendarFeed(ctx context.Context, feed *CalendarFeed) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", feed.ProjectID).Delete(&CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(feed).Error
+	})
+}
+
+//----------------------------------------------------------------------------------------//
+
+func (r *RepositoryImpl) GetStats(ctx context.Context) (*Stats, error) {
+	db := r.db.WithContext(ctx)
+	var stats Stats
+	if err := db.Model(&Project{}).Count(&stats.Projects).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&Task{}).Count(&stats.Tasks).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&Task{}).Where("status = ?", false).Count(&stats.OpenTasks).Error; err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+//----------------------------------------------------------------------------------------//
+
+// GetSchemaVersion returns the latest version the tables were migrated to, 0
+// when they were never migrated successfully.
+func (r *RepositoryImpl) GetSchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := r.db.WithContext(ctx).Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// Ping checks that the database can still be reached.
+func (r *RepositoryImpl) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+//----------------------------------------------------------------------------------------//
+
+// Transaction runs fn against a repository bound to a single database transaction.
+// Calling Transaction again inside fn opens a savepoint instead of a new transaction.
+func (r *RepositoryImpl) Transaction(ctx context.Context, fn func(repo Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&RepositoryImpl{db: tx, logger: r.logger})
+	})
+}
+
+// Close closes the connection pool once in-flight queries have finished.
+func (r *RepositoryImpl) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
 //----------------------------------------------------------------------------------------//
@@ -0,0 +1,74 @@
+package dal
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracerName = "github.com/Boobuh/golang-school-project/dal"
+
+	// querySpanKey is the statement setting the span of a query is kept under.
+	querySpanKey = "tracing:query_span"
+)
+
+// QueryTracing returns a GORM plugin that records a span for every statement,
+// as a child of the span in the statement's context, with the SQL text, the
+// table and the number of rows affected. Failed statements mark their span as
+// an error, except for "record not found", which the callers decide about.
+func QueryTracing() gorm.Plugin {
+	return queryTracing{}
+}
+
+type queryTracing struct {
+	tracer trace.Tracer
+}
+
+func (queryTracing) Name() string {
+	return "tracing"
+}
+
+func (p queryTracing) Initialize(db *gorm.DB) error {
+	p.tracer = otel.Tracer(tracerName)
+	return registerAround(db, p.Name(), p.start, end)
+}
+
+func (p queryTracing) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := p.tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemSqlite, semconv.DBOperationKey.String(operation)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+func end(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(querySpanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+		span.SetAttributes(
+			semconv.DBStatementKey.String(db.Statement.SQL.String()),
+			semconv.DBSQLTableKey.String(db.Statement.Table),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package board
+
+import (
+	"context"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// Handler serves the board, projects down to comments, over GraphQL.
+type Handler struct {
+	logger   logging.Logger
+	projects ProjectService
+	columns  ColumnService
+	tasks    TaskService
+	comments CommentService
+	schema   *graphql.Schema
+}
+
+type ProjectService interface {
+	GetProjects(ctx context.Context) ([]dal.Project, error)
+	GetProjectsByIDs(ctx context.Context, ids []int) ([]dal.Project, error)
+	CreateProject(ctx context.Context, project *dal.Project) error
+	UpdateProject(ctx context.Context, updatedProject *dal.Project) error
+	DeleteProject(ctx context.Context, id int) error
+}
+
+type ColumnService interface {
+	GetByProjectIDs(ctx context.Context, projectIDs []int) ([]dal.Column, error)
+	CreateColumn(ctx context.Context, column *dal.Column) error
+	UpdateColumn(ctx context.Context, updatedColumn *dal.Column) error
+	DeleteColumn(ctx context.Context, projectID, columnID int) error
+}
+
+type TaskService interface {
+	GetByColumnIDs(ctx context.Context, columnIDs []int) ([]dal.Task, error)
+	CreateTask(ctx context.Context, task *dal.Task) error
+	UpdateTask(ctx context.Context, task *dal.Task) error
+	DeleteTask(ctx context.Context, projectID, columnID, taskID int) error
+}
+
+type CommentService interface {
+	GetByTaskIDs(ctx context.Context, taskIDs []int) ([]dal.Comment, error)
+	CreateComment(ctx context.Context, comment *dal.Comment) error
+	UpdateComment(ctx context.Context, comment *dal.Comment) error
+	DeleteComment(ctx context.Context, projectID, columnID, taskID, commentID int) error
+}
+
+func NewHandler(projects ProjectService, columns ColumnService, tasks TaskService, comments CommentService,
+	logger logging.Logger) *Handler {
+	h := &Handler{projects: projects, columns: columns, tasks: tasks, comments: comments, logger: logger}
+	h.schema = h.newSchema()
+	return h
+}
+
+//===========================================================================//
+
+// Request is a GraphQL request, as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Result is the response to a request. Data is left out when the request
+// failed before it could be executed.
+type Result struct {
+	Data   interface{}             `json:"data,omitempty"`
+	Errors []*gqlerrors.QueryError `json:"errors,omitempty"`
+}
+
+// Query executes a GraphQL request. Like any GraphQL server it answers 200
+// once the request is decoded, reporting what went wrong in the errors of
+// the result.
+func (h *Handler) Query(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new graphql request")
+
+	var req Request
+	if err := request.DecodeJSON(r, &req); err != nil {
+		return nil, err
+	}
+	response := h.schema.Exec(h.withLoaders(r.Context()), req.Query, req.OperationName, req.Variables)
+	result := Result{Errors: response.Errors}
+	if len(response.Data) > 0 {
+		result.Data = response.Data
+	}
+	return web.JSON(http.StatusOK, result), nil
+}
+
+//---------------------------------------------------------------------------//
+
+// Schema serves the schema in the GraphQL schema language.
+func (h *Handler) Schema(r *http.Request) (*web.Response, error) {
+	return web.Raw(http.StatusOK, "text/plain; charset=utf-8", []byte(schema)), nil
+}
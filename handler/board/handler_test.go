@@ -0,0 +1,171 @@
+package board
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
+
+	columnsUseCase "github.com/Boobuh/golang-school-project/service/columns"
+	commentUseCase "github.com/Boobuh/golang-school-project/service/comments"
+	projectUseCase "github.com/Boobuh/golang-school-project/service/projects"
+	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
+)
+
+// countingRepository counts the batched reads the loaders make.
+type countingRepository struct {
+	dal.Repository
+	reads map[string]int
+}
+
+func (r *countingRepository) GetProjectsByIDs(ctx context.Context, ids []int) ([]dal.Project, error) {
+	r.reads["projects"]++
+	return r.Repository.GetProjectsByIDs(ctx, ids)
+}
+
+func (r *countingRepository) GetColumnsByProjectIDs(ctx context.Context, projectIDs []int) ([]dal.Column, error) {
+	r.reads["columns"]++
+	return r.Repository.GetColumnsByProjectIDs(ctx, projectIDs)
+}
+
+func (r *countingRepository) GetTasksByColumnIDs(ctx context.Context, columnIDs []int) ([]dal.Task, error) {
+	r.reads["tasks"]++
+	return r.Repository.GetTasksByColumnIDs(ctx, columnIDs)
+}
+
+func (r *countingRepository) GetCommentsByTaskIDs(ctx context.Context, taskIDs []int) ([]dal.Comment, error) {
+	r.reads["comments"]++
+	return r.Repository.GetCommentsByTaskIDs(ctx, taskIDs)
+}
+
+// newTestHandler serves a board of two projects, each with its default column
+// and one more, two tasks in every extra column and a comment on each task.
+func newTestHandler(t *testing.T) (*Handler, *countingRepository) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	t.Cleanup(func() { repo.Close() })
+	ctx := context.Background()
+	projects := projectUseCase.NewUseCase(repo, logging.Nop())
+	for p := 1; p <= 2; p++ {
+		project := dal.Project{Name: "project " + strconv.Itoa(p)}
+		assert.NoError(t, projects.CreateProject(ctx, &project))
+		column := dal.Column{Name: "doing " + strconv.Itoa(p), ProjectID: project.ID, OrderNum: 1}
+		assert.NoError(t, repo.CreateColumn(ctx, &column))
+		for i := 0; i < 2; i++ {
+			task := dal.Task{Name: "task", ColumnID: column.ID}
+			assert.NoError(t, repo.CreateTask(ctx, &task))
+			assert.NoError(t, repo.CreateComment(ctx, &dal.Comment{Description: "looks good", TaskID: task.ID}))
+		}
+	}
+
+	counting := &countingRepository{Repository: repo, reads: map[string]int{}}
+	h := NewHandler(
+		projectUseCase.NewUseCase(counting, logging.Nop()),
+		columnsUseCase.NewUseCase(counting, logging.Nop()),
+		taskUseCase.NewUseCase(counting, logging.Nop()),
+		commentUseCase.NewUseCase(counting, logging.Nop()),
+		logging.Nop(),
+	)
+	return h, counting
+}
+
+func TestHandler_Query(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		code     int
+		expected string
+		reads    map[string]int
+	}{
+		{
+			name: "a read per level",
+			body: `{"query":"{ projects { name columns { name tasks { id commentCount comments { description } } } } }"}`,
+			code: http.StatusOK,
+			expected: `{"data":{"projects":[` +
+				`{"name":"project 1","columns":[{"name":"project 1_default","tasks":[]},{"name":"doing 1","tasks":[` +
+				`{"id":1,"commentCount":1,"comments":[{"description":"looks good"}]},` +
+				`{"id":2,"commentCount":1,"comments":[{"description":"looks good"}]}]}]},` +
+				`{"name":"project 2","columns":[{"name":"project 2_default","tasks":[]},{"name":"doing 2","tasks":[` +
+				`{"id":3,"commentCount":1,"comments":[{"description":"looks good"}]},` +
+				`{"id":4,"commentCount":1,"comments":[{"description":"looks good"}]}]}]}]}}`,
+			reads: map[string]int{"columns": 1, "tasks": 1, "comments": 1},
+		},
+		{
+			name:     "projects by id, each read once",
+			body:     `{"query":"{ a: project(id: 2) { name } b: project(id: 2) { id } c: project(id: 9) { name } }"}`,
+			code:     http.StatusOK,
+			expected: `{"data":{"a":{"name":"project 2"},"b":{"id":2},"c":null}}`,
+			reads:    map[string]int{"projects": 2},
+		},
+		{
+			name:     "mutations",
+			body:     `{"query":"mutation { createColumn(projectId: 1, input: {name: \"done\", orderNumber: 3}) { id projectId orderNumber } deleteComment(projectId: 1, columnId: 3, taskId: 1, id: 1) createTask(projectId: 1, columnId: 3, input: {name: \"later\", dueDate: \"2026-10-19T12:00:00Z\"}) }"}`,
+			code:     http.StatusOK,
+			expected: `{"data":{"createColumn":{"id":5,"projectId":1,"orderNumber":3},"deleteComment":true,"createTask":true}}`,
+			reads:    map[string]int{},
+		},
+		{
+			name:     "errors in the result",
+			body:     `{"query":"{ project { name } }"}`,
+			code:     http.StatusOK,
+			expected: `{"errors":[{"message":"Field \"project\" argument \"id\" of type \"Int!\" is required but not provided.","locations":[{"line":1,"column":3}]}]}`,
+			reads:    map[string]int{},
+		},
+		{
+			name:     "invalid DateTime",
+			body:     `{"query":"mutation ($due: DateTime) { createTask(projectId: 1, columnId: 3, input: {name: \"later\", dueDate: $due}) }","variables":{"due":"tomorrow"}}`,
+			code:     http.StatusOK,
+			expected: `{"data":{},"errors":[{"message":"\"tomorrow\" isn't a DateTime: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\""}]}`,
+			reads:    map[string]int{},
+		},
+		{
+			name:     "introspection",
+			body:     `{"query":"{ __type(name: \"Column\") { fields { name } } }"}`,
+			code:     http.StatusOK,
+			expected: `{"data":{"__type":{"fields":[{"name":"id"},{"name":"name"},{"name":"status"},{"name":"orderNumber"},{"name":"projectId"},{"name":"tasks"}]}}}`,
+			reads:    map[string]int{},
+		},
+		{
+			name:     "undecodable request",
+			body:     `{"query":"{ projects { name } }","extra":true}`,
+			code:     http.StatusBadRequest,
+			expected: ``,
+			reads:    map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, repo := newTestHandler(t)
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			web.Handle(h.Query, logging.Nop())(rec, req)
+
+			assert.Equal(t, tt.code, rec.Code)
+			if tt.expected != "" {
+				assert.JSONEq(t, tt.expected, rec.Body.String())
+			}
+			assert.Equal(t, tt.reads, repo.reads)
+		})
+	}
+}
+
+func TestHandler_Schema(t *testing.T) {
+	h, _ := newTestHandler(t)
+	rec := httptest.NewRecorder()
+	web.Handle(h.Schema, logging.Nop())(rec, httptest.NewRequest(http.MethodGet, "/graphql/schema.graphql", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "type Task {")
+	assert.Contains(t, rec.Body.String(), "scalar DateTime")
+	assert.Contains(t, rec.Body.String(), "createProject(input: ProjectInput!): Project!")
+}
@@ -0,0 +1,161 @@
+package board
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// loader batches the lookups of one request by key. The resolvers run
+// concurrently, so instead of waiting for their siblings a loader is primed
+// with the keys its parent level found: the first lookup fetches all of them,
+// and the others find their value already there.
+type loader struct {
+	fetch func(keys []int) (map[int]interface{}, error)
+
+	mu      sync.Mutex
+	queue   []int
+	queued  map[int]bool
+	results map[int]interface{}
+	errors  map[int]error
+}
+
+func newLoader(fetch func(keys []int) (map[int]interface{}, error)) *loader {
+	return &loader{
+		fetch:   fetch,
+		queued:  map[int]bool{},
+		results: map[int]interface{}{},
+		errors:  map[int]error{},
+	}
+}
+
+// prime queues keys to be fetched with the next lookup.
+func (l *loader) prime(keys ...int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		l.enqueue(key)
+	}
+}
+
+// load returns the value of key, fetching it with the queued keys unless an
+// earlier fetch already did.
+func (l *loader) load(key int) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.done(key) {
+		l.enqueue(key)
+		l.dispatch()
+	}
+	return l.results[key], l.errors[key]
+}
+
+func (l *loader) enqueue(key int) {
+	if !l.done(key) && !l.queued[key] {
+		l.queue = append(l.queue, key)
+		l.queued[key] = true
+	}
+}
+
+func (l *loader) done(key int) bool {
+	_, resolved := l.results[key]
+	_, failed := l.errors[key]
+	return resolved || failed
+}
+
+func (l *loader) dispatch() {
+	keys := l.queue
+	l.queue = nil
+	l.queued = map[int]bool{}
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errors[key] = err
+			continue
+		}
+		l.results[key] = values[key]
+	}
+}
+
+//===========================================================================//
+
+// loaders batch the reads of one request, so that a query over n projects
+// costs a query per level of the board instead of one per project, column
+// and task. Each level primes the loader of the next one with what it read.
+type loaders struct {
+	projects *loader
+	columns  *loader
+	tasks    *loader
+	comments *loader
+}
+
+type loadersKey struct{}
+
+func (h *Handler) withLoaders(ctx context.Context) context.Context {
+	l := &loaders{}
+	l.projects = newLoader(func(ids []int) (map[int]interface{}, error) {
+		projects, err := h.projects.GetProjectsByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := map[int]interface{}{}
+		for _, project := range projects {
+			byID[project.ID] = project
+			l.columns.prime(project.ID)
+		}
+		return byID, nil
+	})
+	l.columns = newLoader(func(projectIDs []int) (map[int]interface{}, error) {
+		columns, err := h.columns.GetByProjectIDs(ctx, projectIDs)
+		if err != nil {
+			return nil, err
+		}
+		byProject := map[int][]dal.Column{}
+		for _, column := range columns {
+			byProject[column.ProjectID] = append(byProject[column.ProjectID], column)
+			l.tasks.prime(column.ID)
+		}
+		grouped := map[int]interface{}{}
+		for _, id := range projectIDs {
+			grouped[id] = append([]dal.Column{}, byProject[id]...)
+		}
+		return grouped, nil
+	})
+	l.tasks = newLoader(func(columnIDs []int) (map[int]interface{}, error) {
+		tasks, err := h.tasks.GetByColumnIDs(ctx, columnIDs)
+		if err != nil {
+			return nil, err
+		}
+		byColumn := map[int][]dal.Task{}
+		for _, task := range tasks {
+			byColumn[task.ColumnID] = append(byColumn[task.ColumnID], task)
+			l.comments.prime(task.ID)
+		}
+		grouped := map[int]interface{}{}
+		for _, id := range columnIDs {
+			grouped[id] = append([]dal.Task{}, byColumn[id]...)
+		}
+		return grouped, nil
+	})
+	l.comments = newLoader(func(taskIDs []int) (map[int]interface{}, error) {
+		comments, err := h.comments.GetByTaskIDs(ctx, taskIDs)
+		if err != nil {
+			return nil, err
+		}
+		byTask := map[int][]dal.Comment{}
+		for _, comment := range comments {
+			byTask[comment.TaskID] = append(byTask[comment.TaskID], comment)
+		}
+		grouped := map[int]interface{}{}
+		for _, id := range taskIDs {
+			grouped[id] = append([]dal.Comment{}, byTask[id]...)
+		}
+		return grouped, nil
+	})
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
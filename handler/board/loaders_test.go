@@ -0,0 +1,55 @@
+package board
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	var batches [][]int
+	loader := newLoader(func(keys []int) (map[int]interface{}, error) {
+		batches = append(batches, keys)
+		values := map[int]interface{}{}
+		for _, key := range keys {
+			if key != 3 {
+				values[key] = key * 10
+			}
+		}
+		return values, nil
+	})
+	loader.prime(1, 2, 3, 1)
+
+	var wg sync.WaitGroup
+	for key, expected := range map[int]interface{}{1: 10, 2: 20, 3: nil} {
+		wg.Add(1)
+		go func(key int, expected interface{}) {
+			defer wg.Done()
+			value, err := loader.load(key)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+		}(key, expected)
+	}
+	wg.Wait()
+	assert.Equal(t, [][]int{{1, 2, 3}}, batches)
+
+	cached, _ := loader.load(2)
+	assert.Equal(t, 20, cached)
+	missed, _ := loader.load(4)
+	assert.Equal(t, 40, missed)
+	assert.Equal(t, [][]int{{1, 2, 3}, {4}}, batches)
+}
+
+func TestLoader_Error(t *testing.T) {
+	loader := newLoader(func(keys []int) (map[int]interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	loader.prime(1, 2)
+	for _, key := range []int{1, 2} {
+		value, err := loader.load(key)
+		assert.EqualError(t, err, "failed")
+		assert.Nil(t, value)
+	}
+}
@@ -0,0 +1,421 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// The schema only has edges from a project down to its comments, so the depth
+// of a query is bounded by the board itself.
+const schema = `schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Column {
+  id: Int!
+  name: String!
+  status: String!
+  orderNumber: Int!
+  projectId: Int!
+  tasks: [Task!]!
+}
+
+input ColumnInput {
+  name: String!
+  orderNumber: Int = 0
+  status: String = ""
+}
+
+type Comment {
+  id: Int!
+  description: String!
+  taskId: Int!
+}
+
+input CommentInput {
+  description: String!
+}
+
+"A time in RFC 3339 format, e.g. 2026-10-19T12:00:00Z."
+scalar DateTime
+
+type Mutation {
+  "Creates a project with a default column."
+  createProject(input: ProjectInput!): Project!
+  updateProject(id: Int!, input: ProjectInput!): Project!
+  deleteProject(id: Int!): Boolean!
+  createColumn(projectId: Int!, input: ColumnInput!): Column!
+  updateColumn(projectId: Int!, id: Int!, input: ColumnInput!): Column!
+  deleteColumn(projectId: Int!, id: Int!): Boolean!
+  "Creates a task. Like POST, it doesn't answer with the new task."
+  createTask(projectId: Int!, columnId: Int!, input: TaskInput!): Boolean!
+  updateTask(projectId: Int!, columnId: Int!, id: Int!, input: TaskInput!): Task!
+  deleteTask(projectId: Int!, columnId: Int!, id: Int!): Boolean!
+  "Comments on a task. Like POST, it doesn't answer with the new comment."
+  createComment(projectId: Int!, columnId: Int!, taskId: Int!, input: CommentInput!): Boolean!
+  updateComment(projectId: Int!, columnId: Int!, taskId: Int!, id: Int!, input: CommentInput!): Comment!
+  deleteComment(projectId: Int!, columnId: Int!, taskId: Int!, id: Int!): Boolean!
+}
+
+type Project {
+  id: Int!
+  name: String!
+  description: String!
+  columns: [Column!]!
+}
+
+input ProjectInput {
+  name: String!
+  description: String = ""
+}
+
+type Query {
+  projects: [Project!]!
+  project(id: Int!): Project
+}
+
+type Task {
+  id: Int!
+  name: String!
+  description: String!
+  "Whether the task is done."
+  status: Boolean!
+  dueDate: DateTime
+  columnId: Int!
+  comments: [Comment!]!
+  commentCount: Int!
+}
+
+input TaskInput {
+  name: String!
+  description: String = ""
+  status: Boolean = false
+  dueDate: DateTime
+}
+`
+
+func (h *Handler) newSchema() *graphql.Schema {
+	return graphql.MustParseSchema(schema, &resolver{h}, graphql.UseStringDescriptions())
+}
+
+// dateTime is the DateTime scalar.
+type dateTime struct {
+	time.Time
+}
+
+func (dateTime) ImplementsGraphQLType(name string) bool {
+	return name == "DateTime"
+}
+
+func (t *dateTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("%v isn't a DateTime", input)
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("%q isn't a DateTime: %v", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t dateTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.Format(time.RFC3339) + `"`), nil
+}
+
+//===========================================================================//
+
+// resolver resolves the fields of Query and Mutation.
+type resolver struct {
+	h *Handler
+}
+
+func (r *resolver) Projects(ctx context.Context) ([]*projectResolver, error) {
+	projects, err := r.h.projects.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	columns := loadersFrom(ctx).columns
+	resolvers := make([]*projectResolver, len(projects))
+	for i, project := range projects {
+		columns.prime(project.ID)
+		resolvers[i] = &projectResolver{project}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Project(ctx context.Context, args struct{ ID int32 }) (*projectResolver, error) {
+	project, err := loadersFrom(ctx).projects.load(int(args.ID))
+	if err != nil || project == nil {
+		return nil, err
+	}
+	return &projectResolver{project.(dal.Project)}, nil
+}
+
+//---------------------------------------------------------------------------//
+
+// Fields with a default aren't pointers: graphql-go fills the default in.
+
+type projectInput struct {
+	Name        string
+	Description string
+}
+
+func (r *resolver) CreateProject(ctx context.Context, args struct{ Input projectInput }) (*projectResolver, error) {
+	created := projectFrom(0, args.Input)
+	if err := r.h.projects.CreateProject(ctx, &created); err != nil {
+		return nil, err
+	}
+	return &projectResolver{created}, nil
+}
+
+func (r *resolver) UpdateProject(ctx context.Context, args struct {
+	ID    int32
+	Input projectInput
+}) (*projectResolver, error) {
+	updated := projectFrom(int(args.ID), args.Input)
+	if err := r.h.projects.UpdateProject(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &projectResolver{updated}, nil
+}
+
+func (r *resolver) DeleteProject(ctx context.Context, args struct{ ID int32 }) (bool, error) {
+	return true, r.h.projects.DeleteProject(ctx, int(args.ID))
+}
+
+//---------------------------------------------------------------------------//
+
+type columnInput struct {
+	Name        string
+	OrderNumber int32
+	Status      string
+}
+
+func (r *resolver) CreateColumn(ctx context.Context, args struct {
+	ProjectID int32
+	Input     columnInput
+}) (*columnResolver, error) {
+	created := columnFrom(0, int(args.ProjectID), args.Input)
+	if err := r.h.columns.CreateColumn(ctx, &created); err != nil {
+		return nil, err
+	}
+	return &columnResolver{created}, nil
+}
+
+func (r *resolver) UpdateColumn(ctx context.Context, args struct {
+	ProjectID int32
+	ID        int32
+	Input     columnInput
+}) (*columnResolver, error) {
+	updated := columnFrom(int(args.ID), int(args.ProjectID), args.Input)
+	if err := r.h.columns.UpdateColumn(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &columnResolver{updated}, nil
+}
+
+func (r *resolver) DeleteColumn(ctx context.Context, args struct {
+	ProjectID int32
+	ID        int32
+}) (bool, error) {
+	return true, r.h.columns.DeleteColumn(ctx, int(args.ProjectID), int(args.ID))
+}
+
+//---------------------------------------------------------------------------//
+
+type taskInput struct {
+	Name        string
+	Description string
+	Status      bool
+	DueDate     *dateTime
+}
+
+func (r *resolver) CreateTask(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	Input     taskInput
+}) (bool, error) {
+	created := taskFrom(0, int(args.ColumnID), args.Input)
+	return true, r.h.tasks.CreateTask(ctx, &created)
+}
+
+func (r *resolver) UpdateTask(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	ID        int32
+	Input     taskInput
+}) (*taskResolver, error) {
+	updated := taskFrom(int(args.ID), int(args.ColumnID), args.Input)
+	if err := r.h.tasks.UpdateTask(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &taskResolver{updated}, nil
+}
+
+func (r *resolver) DeleteTask(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	ID        int32
+}) (bool, error) {
+	return true, r.h.tasks.DeleteTask(ctx, int(args.ProjectID), int(args.ColumnID), int(args.ID))
+}
+
+//---------------------------------------------------------------------------//
+
+type commentInput struct {
+	Description string
+}
+
+func (r *resolver) CreateComment(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	TaskID    int32
+	Input     commentInput
+}) (bool, error) {
+	created := dal.Comment{TaskID: int(args.TaskID), Description: args.Input.Description}
+	return true, r.h.comments.CreateComment(ctx, &created)
+}
+
+func (r *resolver) UpdateComment(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	TaskID    int32
+	ID        int32
+	Input     commentInput
+}) (*commentResolver, error) {
+	updated := dal.Comment{ID: int(args.ID), TaskID: int(args.TaskID), Description: args.Input.Description}
+	if err := r.h.comments.UpdateComment(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &commentResolver{updated}, nil
+}
+
+func (r *resolver) DeleteComment(ctx context.Context, args struct {
+	ProjectID int32
+	ColumnID  int32
+	TaskID    int32
+	ID        int32
+}) (bool, error) {
+	return true, r.h.comments.DeleteComment(ctx, int(args.ProjectID), int(args.ColumnID), int(args.TaskID), int(args.ID))
+}
+
+//===========================================================================//
+
+type projectResolver struct {
+	project dal.Project
+}
+
+func (r *projectResolver) ID() int32           { return int32(r.project.ID) }
+func (r *projectResolver) Name() string        { return r.project.Name }
+func (r *projectResolver) Description() string { return r.project.Description }
+
+func (r *projectResolver) Columns(ctx context.Context) ([]*columnResolver, error) {
+	columns, err := loadersFrom(ctx).columns.load(r.project.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := []*columnResolver{}
+	for _, column := range columns.([]dal.Column) {
+		resolvers = append(resolvers, &columnResolver{column})
+	}
+	return resolvers, nil
+}
+
+//---------------------------------------------------------------------------//
+
+type columnResolver struct {
+	column dal.Column
+}
+
+func (r *columnResolver) ID() int32          { return int32(r.column.ID) }
+func (r *columnResolver) Name() string       { return r.column.Name }
+func (r *columnResolver) Status() string     { return r.column.Status }
+func (r *columnResolver) OrderNumber() int32 { return int32(r.column.OrderNum) }
+func (r *columnResolver) ProjectID() int32   { return int32(r.column.ProjectID) }
+
+func (r *columnResolver) Tasks(ctx context.Context) ([]*taskResolver, error) {
+	tasks, err := loadersFrom(ctx).tasks.load(r.column.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := []*taskResolver{}
+	for _, task := range tasks.([]dal.Task) {
+		resolvers = append(resolvers, &taskResolver{task})
+	}
+	return resolvers, nil
+}
+
+//---------------------------------------------------------------------------//
+
+type taskResolver struct {
+	task dal.Task
+}
+
+func (r *taskResolver) ID() int32           { return int32(r.task.ID) }
+func (r *taskResolver) Name() string        { return r.task.Name }
+func (r *taskResolver) Description() string { return r.task.Description }
+func (r *taskResolver) Status() bool        { return r.task.Status }
+func (r *taskResolver) ColumnID() int32     { return int32(r.task.ColumnID) }
+
+func (r *taskResolver) DueDate() *dateTime {
+	if r.task.DueDate == nil {
+		return nil
+	}
+	return &dateTime{*r.task.DueDate}
+}
+
+func (r *taskResolver) Comments(ctx context.Context) ([]*commentResolver, error) {
+	comments, err := loadersFrom(ctx).comments.load(r.task.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := []*commentResolver{}
+	for _, comment := range comments.([]dal.Comment) {
+		resolvers = append(resolvers, &commentResolver{comment})
+	}
+	return resolvers, nil
+}
+
+func (r *taskResolver) CommentCount(ctx context.Context) (int32, error) {
+	comments, err := loadersFrom(ctx).comments.load(r.task.ID)
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(comments.([]dal.Comment))), nil
+}
+
+//---------------------------------------------------------------------------//
+
+type commentResolver struct {
+	comment dal.Comment
+}
+
+func (r *commentResolver) ID() int32           { return int32(r.comment.ID) }
+func (r *commentResolver) Description() string { return r.comment.Description }
+func (r *commentResolver) TaskID() int32       { return int32(r.comment.TaskID) }
+
+//===========================================================================//
+
+func projectFrom(id int, input projectInput) dal.Project {
+	return dal.Project{ID: id, Name: input.Name, Description: input.Description}
+}
+
+func columnFrom(id, projectID int, input columnInput) dal.Column {
+	return dal.Column{ID: id, ProjectID: projectID, Name: input.Name, OrderNum: int(input.OrderNumber), Status: input.Status}
+}
+
+func taskFrom(id, columnID int, input taskInput) dal.Task {
+	task := dal.Task{ID: id, ColumnID: columnID, Name: input.Name, Description: input.Description, Status: input.Status}
+	if input.DueDate != nil {
+		task.DueDate = &input.DueDate.Time
+	}
+	return task
+}
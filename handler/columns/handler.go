@@ -3,259 +3,144 @@ package columns
 //go:generate   $GOPATH/bin/mockgen -package mocks -destination=mocks/mock_service.go -package=mocks github.com/Boobuh/golang-school-project/handler/columns Service
 
 import (
-	"encoding/json"
-	"log"
+	"context"
 	"net/http"
-	"strconv"
 
 	"github.com/Boobuh/golang-school-project/dal"
-	"github.com/gorilla/mux"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
 type Handler struct {
-	logger  *log.Logger
+	logger  logging.Logger
 	service Service
 }
 
 type Service interface {
-	GetColumns() ([]dal.Column, error)
-	GetProjectColumn(projectID, columnID int) (*dal.ExtendedColumn, error)
-	CreateColumn(column *dal.Column) error
-	DeleteColumn(projectID, columnID int) error
-	UpdateColumn(updatedColumn *dal.Column) error
-	GetAllByProjectID(projectID int) ([]dal.ExtendedColumn, error)
-	GetColumn(id int) (*dal.ExtendedColumn, error)
+	GetColumns(ctx context.Context) ([]dal.Column, error)
+	GetProjectColumn(ctx context.Context, projectID, columnID int) (*dal.ExtendedColumn, error)
+	CreateColumn(ctx context.Context, column *dal.Column) error
+	DeleteColumn(ctx context.Context, projectID, columnID int) error
+	UpdateColumn(ctx context.Context, updatedColumn *dal.Column) error
+	GetAllByProjectID(ctx context.Context, projectID int) ([]dal.ExtendedColumn, error)
+	GetColumn(ctx context.Context, id int) (*dal.ExtendedColumn, error)
 }
 
-func NewHandler(service Service, logger *log.Logger) *Handler {
+func NewHandler(service Service, logger logging.Logger) *Handler {
 	return &Handler{logger: logger, service: service}
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) GetAllColumns(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new get request")
+func (h *Handler) GetAllColumns(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new get request")
 
-	getColumns, err := h.service.GetColumns()
+	getColumns, err := h.service.GetColumns(r.Context())
 	if err != nil {
-		h.logger.Printf("error in GET getColumns call in service.GetColumns call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, err
 	}
-	payload, err := json.Marshal(getColumns)
-	if err != nil {
-		h.logger.Printf("error in GET getColumns call - can't marshal object from db:%s", err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, getColumns), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) GetColumn(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new get request")
+func (h *Handler) GetColumn(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new get request")
 
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "id is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("id is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "id is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("id is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	column, err := h.service.GetProjectColumn(projectID, columnID)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in receiving project by id:%s", err.Error())
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	columnID := params.Int("columnID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(column)
+	column, err := h.service.GetProjectColumn(r.Context(), projectID, columnID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in GET projects call - can't marshal object from db:%s", err.Error())
-		return
+		return nil, err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, column), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) CreateColumn(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new create request")
-	vars := mux.Vars(r)
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "projectID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("projectID is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+func (h *Handler) CreateColumn(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new create request")
+
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
 	var newColumn dal.Column
-	err = json.NewDecoder(r.Body).Decode(&newColumn)
-	if err != nil {
-		h.logger.Printf("error in POST column call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := request.DecodeJSON(r, &newColumn); err != nil {
+		return nil, err
 	}
 	if newColumn.ProjectID != projectID {
-		h.logger.Printf("error in POST column call - projectID mismatched")
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, web.Errorf(http.StatusBadRequest, "project_id %d doesn't match the path", newColumn.ProjectID)
 	}
-	err = h.service.CreateColumn(&newColumn)
-	if err != nil {
-		h.logger.Printf("error in CREATE column call - %s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := h.service.CreateColumn(r.Context(), &newColumn); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusCreated)
+	return web.Empty(http.StatusCreated), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) DeleteColumn(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new delete column request")
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "projectID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("projectID is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting projectID to int:%s", err.Error())
-		return
-	}
+func (h *Handler) DeleteColumn(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new delete column request")
 
-	err = h.service.DeleteColumn(projectID, columnID)
-	if err != nil {
-		h.logger.Printf("error in DELETE column call:%s", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	columnID := params.Int("columnID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusNoContent)
+	if err := h.service.DeleteColumn(r.Context(), projectID, columnID); err != nil {
+		return nil, err
+	}
+	return web.Empty(http.StatusNoContent), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) UpdateColumn(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new UpdateColumn request")
+func (h *Handler) UpdateColumn(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new UpdateColumn request")
 
-	vars := mux.Vars(r)
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "projectID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("projectID is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	columnID := params.Int("columnID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
 	var updatedColumn dal.Column
-	err = json.NewDecoder(r.Body).Decode(&updatedColumn)
-	if err != nil {
-		h.logger.Printf("error in POST column call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := request.DecodeJSON(r, &updatedColumn); err != nil {
+		return nil, err
 	}
-
 	if updatedColumn.ID != columnID || updatedColumn.ProjectID != projectID {
-		h.logger.Printf("error in PUT call columnID or projectID mismatched")
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, web.Errorf(http.StatusBadRequest, "id %d or project_id %d doesn't match the path",
+			updatedColumn.ID, updatedColumn.ProjectID)
 	}
-
-	err = h.service.UpdateColumn(&updatedColumn)
-
-	if err != nil {
-		h.logger.Printf("error in UPDATE column call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := h.service.UpdateColumn(r.Context(), &updatedColumn); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusOK)
+	return web.Empty(http.StatusOK), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) GetAllByProjectID(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new GetAllByProjectID request")
+func (h *Handler) GetAllByProjectID(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new GetAllByProjectID request")
 
-	vars := mux.Vars(r)
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "id is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("id is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	column, err := h.service.GetAllByProjectID(projectID)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in receiving project by id:%s", err.Error())
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(column)
+	columns, err := h.service.GetAllByProjectID(r.Context(), projectID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in GET projects call - can't marshal object from db:%s", err.Error())
-		return
+		return nil, err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, columns), nil
 }
 
 //---------------------------------------------------------------------------//
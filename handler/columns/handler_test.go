@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +12,8 @@ import (
 	"github.com/Boobuh/golang-school-project/handler/columns/mocks"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ func TestHandler_GetAllColumns(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -47,10 +48,10 @@ func TestHandler_GetAllColumns(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetColumns().Return([]dal.Column{}, nil).Times(1)
+					service.EXPECT().GetColumns(gomock.Any()).Return([]dal.Column{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -64,10 +65,10 @@ func TestHandler_GetAllColumns(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetColumns().Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetColumns(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -86,12 +87,13 @@ func TestHandler_GetAllColumns(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/columns/", h.GetAllColumns)
+			router.HandleFunc("/columns/", web.Handle(h.GetAllColumns, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -104,7 +106,7 @@ func TestHandler_GetColumn(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -128,10 +130,10 @@ func TestHandler_GetColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProjectColumn(1, 1).Return(&dal.ExtendedColumn{}, nil).Times(1)
+					service.EXPECT().GetProjectColumn(gomock.Any(), 1, 1).Return(&dal.ExtendedColumn{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -145,10 +147,10 @@ func TestHandler_GetColumn(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProjectColumn(0, 0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetProjectColumn(gomock.Any(), 0, 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -159,6 +161,18 @@ func TestHandler_GetColumn(t *testing.T) {
 			},
 			expected: expected{code: http.StatusBadRequest},
 		},
+		{
+			name: "invalid column id",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/1/columns/abc",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "columnID \"abc\" isn't a number\n"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -167,7 +181,7 @@ func TestHandler_GetColumn(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}", h.GetColumn)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}", web.Handle(h.GetColumn, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			//body, err := json.Marshal(tt.args.body)
@@ -176,6 +190,9 @@ func TestHandler_GetColumn(t *testing.T) {
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, recorder.Body.String())
+			}
 		})
 	}
 }
@@ -185,7 +202,7 @@ func TestHandler_CreateColumn(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -209,10 +226,10 @@ func TestHandler_CreateColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateColumn(&dal.Column{ProjectID: 1}).Return(nil).Times(1)
+					service.EXPECT().CreateColumn(gomock.Any(), &dal.Column{ProjectID: 1}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -228,10 +245,10 @@ func TestHandler_CreateColumn(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateColumn(&dal.Column{ProjectID: 1}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().CreateColumn(gomock.Any(), &dal.Column{ProjectID: 1}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -252,12 +269,13 @@ func TestHandler_CreateColumn(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/", h.CreateColumn)
+			router.HandleFunc("/projects/{projectID}/columns/", web.Handle(h.CreateColumn, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -270,7 +288,7 @@ func TestHandler_DeleteColumn(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -294,10 +312,10 @@ func TestHandler_DeleteColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteColumn(1, 1).Return(nil).Times(1)
+					service.EXPECT().DeleteColumn(gomock.Any(), 1, 1).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -311,10 +329,10 @@ func TestHandler_DeleteColumn(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteColumn(0, 0).Return(errors.New("failed")).Times(1)
+					service.EXPECT().DeleteColumn(gomock.Any(), 0, 0).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -333,12 +351,13 @@ func TestHandler_DeleteColumn(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}", h.DeleteColumn)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}", web.Handle(h.DeleteColumn, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -351,7 +370,7 @@ func TestHandler_UpdateColumn(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -375,10 +394,10 @@ func TestHandler_UpdateColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateColumn(&dal.Column{ID: 1, ProjectID: 1, Name: "one_default"}).Return(nil).Times(1)
+					service.EXPECT().UpdateColumn(gomock.Any(), &dal.Column{ID: 1, ProjectID: 1, Name: "one_default"}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -396,10 +415,10 @@ func TestHandler_UpdateColumn(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateColumn(&dal.Column{ID: 0, ProjectID: 0, Name: "one"}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().UpdateColumn(gomock.Any(), &dal.Column{ID: 0, ProjectID: 0, Name: "one"}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -422,12 +441,13 @@ func TestHandler_UpdateColumn(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}", h.UpdateColumn)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}", web.Handle(h.UpdateColumn, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -440,7 +460,7 @@ func TestHandler_GetAllByProjectID(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -464,10 +484,10 @@ func TestHandler_GetAllByProjectID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByProjectID(1).Return([]dal.ExtendedColumn{}, nil).Times(1)
+					service.EXPECT().GetAllByProjectID(gomock.Any(), 1).Return([]dal.ExtendedColumn{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -481,10 +501,10 @@ func TestHandler_GetAllByProjectID(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByProjectID(0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetAllByProjectID(gomock.Any(), 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -503,12 +523,13 @@ func TestHandler_GetAllByProjectID(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/", h.GetAllByProjectID)
+			router.HandleFunc("/projects/{projectID}/columns/", web.Handle(h.GetAllByProjectID, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	dal "github.com/Boobuh/golang-school-project/dal"
@@ -35,103 +36,103 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // CreateColumn mocks base method.
-func (m *MockService) CreateColumn(arg0 *dal.Column) error {
+func (m *MockService) CreateColumn(arg0 context.Context, arg1 *dal.Column) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateColumn", arg0)
+	ret := m.ctrl.Call(m, "CreateColumn", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateColumn indicates an expected call of CreateColumn.
-func (mr *MockServiceMockRecorder) CreateColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateColumn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockService)(nil).CreateColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockService)(nil).CreateColumn), arg0, arg1)
 }
 
 // DeleteColumn mocks base method.
-func (m *MockService) DeleteColumn(arg0, arg1 int) error {
+func (m *MockService) DeleteColumn(arg0 context.Context, arg1, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteColumn", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteColumn", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteColumn indicates an expected call of DeleteColumn.
-func (mr *MockServiceMockRecorder) DeleteColumn(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteColumn(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockService)(nil).DeleteColumn), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockService)(nil).DeleteColumn), arg0, arg1, arg2)
 }
 
 // GetAllByProjectID mocks base method.
-func (m *MockService) GetAllByProjectID(arg0 int) ([]dal.ExtendedColumn, error) {
+func (m *MockService) GetAllByProjectID(arg0 context.Context, arg1 int) ([]dal.ExtendedColumn, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByProjectID", arg0)
+	ret := m.ctrl.Call(m, "GetAllByProjectID", arg0, arg1)
 	ret0, _ := ret[0].([]dal.ExtendedColumn)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllByProjectID indicates an expected call of GetAllByProjectID.
-func (mr *MockServiceMockRecorder) GetAllByProjectID(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAllByProjectID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProjectID", reflect.TypeOf((*MockService)(nil).GetAllByProjectID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByProjectID", reflect.TypeOf((*MockService)(nil).GetAllByProjectID), arg0, arg1)
 }
 
 // GetColumn mocks base method.
-func (m *MockService) GetColumn(arg0 int) (*dal.ExtendedColumn, error) {
+func (m *MockService) GetColumn(arg0 context.Context, arg1 int) (*dal.ExtendedColumn, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetColumn", arg0)
+	ret := m.ctrl.Call(m, "GetColumn", arg0, arg1)
 	ret0, _ := ret[0].(*dal.ExtendedColumn)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetColumn indicates an expected call of GetColumn.
-func (mr *MockServiceMockRecorder) GetColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetColumn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockService)(nil).GetColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockService)(nil).GetColumn), arg0, arg1)
 }
 
 // GetColumns mocks base method.
-func (m *MockService) GetColumns() ([]dal.Column, error) {
+func (m *MockService) GetColumns(arg0 context.Context) ([]dal.Column, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetColumns")
+	ret := m.ctrl.Call(m, "GetColumns", arg0)
 	ret0, _ := ret[0].([]dal.Column)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetColumns indicates an expected call of GetColumns.
-func (mr *MockServiceMockRecorder) GetColumns() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetColumns(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockService)(nil).GetColumns))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockService)(nil).GetColumns), arg0)
 }
 
 // GetProjectColumn mocks base method.
-func (m *MockService) GetProjectColumn(arg0, arg1 int) (*dal.ExtendedColumn, error) {
+func (m *MockService) GetProjectColumn(arg0 context.Context, arg1, arg2 int) (*dal.ExtendedColumn, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectColumn", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetProjectColumn", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*dal.ExtendedColumn)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjectColumn indicates an expected call of GetProjectColumn.
-func (mr *MockServiceMockRecorder) GetProjectColumn(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetProjectColumn(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectColumn", reflect.TypeOf((*MockService)(nil).GetProjectColumn), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectColumn", reflect.TypeOf((*MockService)(nil).GetProjectColumn), arg0, arg1, arg2)
 }
 
 // UpdateColumn mocks base method.
-func (m *MockService) UpdateColumn(arg0 *dal.Column) error {
+func (m *MockService) UpdateColumn(arg0 context.Context, arg1 *dal.Column) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateColumn", arg0)
+	ret := m.ctrl.Call(m, "UpdateColumn", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateColumn indicates an expected call of UpdateColumn.
-func (mr *MockServiceMockRecorder) UpdateColumn(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateColumn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockService)(nil).UpdateColumn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumn", reflect.TypeOf((*MockService)(nil).UpdateColumn), arg0, arg1)
 }
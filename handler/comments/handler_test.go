@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/Boobuh/golang-school-project/dal"
 	"github.com/Boobuh/golang-school-project/handler/comments/mocks"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +22,7 @@ func TestHandler_GetAllComments(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -45,10 +46,10 @@ func TestHandler_GetAllComments(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetComments().Return([]dal.Comment{}, nil).Times(1)
+					service.EXPECT().GetComments(gomock.Any()).Return([]dal.Comment{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -62,10 +63,10 @@ func TestHandler_GetAllComments(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetComments().Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetComments(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -84,12 +85,13 @@ func TestHandler_GetAllComments(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/comments/", h.GetAllComments)
+			router.HandleFunc("/comments/", web.Handle(h.GetAllComments, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -102,7 +104,7 @@ func TestHandler_GetComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -126,10 +128,10 @@ func TestHandler_GetComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetComment(1, 1, 1, 1).Return(&dal.Comment{}, nil).Times(1)
+					service.EXPECT().GetComment(gomock.Any(), 1, 1, 1, 1).Return(&dal.Comment{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -143,10 +145,10 @@ func TestHandler_GetComment(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetComment(0, 0, 0, 0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetComment(gomock.Any(), 0, 0, 0, 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -165,7 +167,7 @@ func TestHandler_GetComment(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", h.GetComment)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", web.Handle(h.GetComment, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			//body, err := json.Marshal(tt.args.body)
@@ -183,7 +185,7 @@ func TestHandler_CreateComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -207,10 +209,10 @@ func TestHandler_CreateComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateComment(&dal.Comment{TaskID: 1}).Return(nil).Times(1)
+					service.EXPECT().CreateComment(gomock.Any(), &dal.Comment{TaskID: 1}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -226,10 +228,10 @@ func TestHandler_CreateComment(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateComment(&dal.Comment{TaskID: 1}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().CreateComment(gomock.Any(), &dal.Comment{TaskID: 1}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -250,12 +252,13 @@ func TestHandler_CreateComment(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", h.CreateComment)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", web.Handle(h.CreateComment, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -268,7 +271,7 @@ func TestHandler_DeleteComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -292,10 +295,10 @@ func TestHandler_DeleteComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteComment(1, 1, 1, 1).Return(nil).Times(1)
+					service.EXPECT().DeleteComment(gomock.Any(), 1, 1, 1, 1).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -309,10 +312,10 @@ func TestHandler_DeleteComment(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteComment(0, 0, 0, 0).Return(errors.New("failed")).Times(1)
+					service.EXPECT().DeleteComment(gomock.Any(), 0, 0, 0, 0).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -323,6 +326,18 @@ func TestHandler_DeleteComment(t *testing.T) {
 			},
 			expected: expected{code: http.StatusBadRequest},
 		},
+		{
+			name: "invalid comment id",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/1/columns/1/tasks/1/comments/abc",
+				method:     http.MethodDelete,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "commentID \"abc\" isn't a number\n"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -331,15 +346,19 @@ func TestHandler_DeleteComment(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", h.DeleteComment)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", web.Handle(h.DeleteComment, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, recorder.Body.String())
+			}
 		})
 	}
 }
@@ -349,7 +368,7 @@ func TestHandler_UpdateComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -373,10 +392,10 @@ func TestHandler_UpdateComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateComment(&dal.Comment{ID: 1, TaskID: 1, Description: "one_default"}).Return(nil).Times(1)
+					service.EXPECT().UpdateComment(gomock.Any(), &dal.Comment{ID: 1, TaskID: 1, Description: "one_default"}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -394,10 +413,10 @@ func TestHandler_UpdateComment(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateComment(&dal.Comment{ID: 0, TaskID: 0, Description: "one"}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().UpdateComment(gomock.Any(), &dal.Comment{ID: 0, TaskID: 0, Description: "one"}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -420,12 +439,13 @@ func TestHandler_UpdateComment(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", h.UpdateComment)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", web.Handle(h.UpdateComment, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -438,7 +458,7 @@ func TestHandler_GetAllByTaskID(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -462,10 +482,10 @@ func TestHandler_GetAllByTaskID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByTaskID(1).Return([]dal.Comment{}, nil).Times(1)
+					service.EXPECT().GetAllByTaskID(gomock.Any(), 1).Return([]dal.Comment{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -479,10 +499,10 @@ func TestHandler_GetAllByTaskID(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByTaskID(0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetAllByTaskID(gomock.Any(), 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -501,12 +521,13 @@ func TestHandler_GetAllByTaskID(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", h.GetAllByTaskID)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", web.Handle(h.GetAllByTaskID, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	dal "github.com/Boobuh/golang-school-project/dal"
@@ -35,88 +36,88 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // CreateComment mocks base method.
-func (m *MockService) CreateComment(arg0 *dal.Comment) error {
+func (m *MockService) CreateComment(arg0 context.Context, arg1 *dal.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComment", arg0)
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateComment indicates an expected call of CreateComment.
-func (mr *MockServiceMockRecorder) CreateComment(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), arg0, arg1)
 }
 
 // DeleteComment mocks base method.
-func (m *MockService) DeleteComment(arg0, arg1, arg2, arg3 int) error {
+func (m *MockService) DeleteComment(arg0 context.Context, arg1, arg2, arg3, arg4 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteComment indicates an expected call of DeleteComment.
-func (mr *MockServiceMockRecorder) DeleteComment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetAllByTaskID mocks base method.
-func (m *MockService) GetAllByTaskID(arg0 int) ([]dal.Comment, error) {
+func (m *MockService) GetAllByTaskID(arg0 context.Context, arg1 int) ([]dal.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByTaskID", arg0)
+	ret := m.ctrl.Call(m, "GetAllByTaskID", arg0, arg1)
 	ret0, _ := ret[0].([]dal.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllByTaskID indicates an expected call of GetAllByTaskID.
-func (mr *MockServiceMockRecorder) GetAllByTaskID(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAllByTaskID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByTaskID", reflect.TypeOf((*MockService)(nil).GetAllByTaskID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByTaskID", reflect.TypeOf((*MockService)(nil).GetAllByTaskID), arg0, arg1)
 }
 
 // GetComment mocks base method.
-func (m *MockService) GetComment(arg0, arg1, arg2, arg3 int) (*dal.Comment, error) {
+func (m *MockService) GetComment(arg0 context.Context, arg1, arg2, arg3, arg4 int) (*dal.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComment", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "GetComment", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*dal.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetComment indicates an expected call of GetComment.
-func (mr *MockServiceMockRecorder) GetComment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockService)(nil).GetComment), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockService)(nil).GetComment), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetComments mocks base method.
-func (m *MockService) GetComments() ([]dal.Comment, error) {
+func (m *MockService) GetComments(arg0 context.Context) ([]dal.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComments")
+	ret := m.ctrl.Call(m, "GetComments", arg0)
 	ret0, _ := ret[0].([]dal.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetComments indicates an expected call of GetComments.
-func (mr *MockServiceMockRecorder) GetComments() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetComments(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockService)(nil).GetComments))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockService)(nil).GetComments), arg0)
 }
 
 // UpdateComment mocks base method.
-func (m *MockService) UpdateComment(arg0 *dal.Comment) error {
+func (m *MockService) UpdateComment(arg0 context.Context, arg1 *dal.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateComment", arg0)
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateComment indicates an expected call of UpdateComment.
-func (mr *MockServiceMockRecorder) UpdateComment(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateComment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), arg0, arg1)
 }
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
+)
+
+// contractStep is a request of the contract test. Steps run in order against
+// the same database, so that later ones can use what earlier ones created.
+type contractStep struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+	// status is the one the step is expected to answer
+	status int
+	// save keeps a string field of the JSON response for later paths, which
+	// refer to it as {field}
+	save string
+	// fields are values the JSON response must have, as encoding/json
+	// decodes them
+	fields map[string]interface{}
+}
+
+// TestContract drives every operation of the OpenAPI document served by the
+// router, and checks that the responses have the statuses, content types and
+// fields the document describes, and the values the steps expect.
+func TestContract(t *testing.T) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	defer repo.Close()
+	cfg := config.Default()
+	cfg.RateLimit.Enabled = false
+	router := NewRouter(cfg, repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc openapi.Document
+	if err := json.Unmarshal(response.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("can't decode the document: %v", err)
+	}
+
+	const (
+		comment = "/v1/projects/1/columns/2/tasks/1/comments/"
+		csv     = "column,name,description,status\ndoing,imported,from a sheet,false\n"
+	)
+	steps := []contractStep{
+		{method: http.MethodGet, path: "/healthz", status: http.StatusOK},
+		{method: http.MethodGet, path: "/readyz", status: http.StatusOK},
+		{method: http.MethodGet, path: "/version", status: http.StatusOK},
+		{method: http.MethodGet, path: "/docs", status: http.StatusOK},
+		{method: http.MethodGet, path: "/openapi.json", status: http.StatusOK},
+
+		{method: http.MethodPost, path: "/v1/projects/", body: `{"name":"board","description":"contract"}`, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/v1/projects/", status: http.StatusOK},
+		{method: http.MethodPut, path: "/v1/projects/1", body: `{"id":1,"name":"renamed","description":"contract"}`, status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/abc", status: http.StatusBadRequest},
+
+		{method: http.MethodPost, path: "/v1/projects/1/columns/", body: `{"name":"doing","project_id":1,"order_number":2}`, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/v1/columns/", status: http.StatusOK},
+		{method: http.MethodPut, path: "/v1/projects/1/columns/2", body: `{"id":2,"name":"doing","project_id":1,"order_number":2,"status":"open"}`, status: http.StatusOK},
+
+		{method: http.MethodPost, path: "/v1/projects/1/columns/2/tasks/", body: `{"name":"write","column_id":2,"due_date":"2026-11-02T10:00:00Z"}`, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/v1/tasks/", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/columns/2/tasks/1", status: http.StatusOK,
+			fields: map[string]interface{}{"name": "write", "due_date": "2026-11-02T10:00:00Z"}},
+		{method: http.MethodPut, path: "/v1/projects/1/columns/2/tasks/1", status: http.StatusOK,
+			body: `{"id":1,"name":"written","column_id":2,"due_date":"2026-11-03T10:00:00Z"}`},
+		{method: http.MethodGet, path: "/v1/projects/1/columns/2/tasks/1", status: http.StatusOK,
+			fields: map[string]interface{}{"name": "written", "due_date": "2026-11-03T10:00:00Z"}},
+
+		{method: http.MethodPost, path: comment, body: `{"description":"first","task_id":1}`, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/v1/comments/", status: http.StatusOK},
+		{method: http.MethodGet, path: comment, status: http.StatusOK},
+		{method: http.MethodGet, path: comment + "1", status: http.StatusOK},
+		{method: http.MethodPut, path: comment + "1", body: `{"id":1,"description":"edited","task_id":1}`, status: http.StatusOK},
+
+		{method: http.MethodPost, path: "/v1/projects/1/tasks:batch", status: http.StatusOK,
+			body: `{"mode":"per_item","operations":[{"op":"create","task":{"name":"batched","column_id":2}}]}`},
+		{method: http.MethodPost, path: "/v1/projects/1/tasks:batch", status: http.StatusMultiStatus,
+			body: `{"mode":"per_item","operations":[{"op":"create","task":{"name":"batched","column_id":2}},{"op":"delete","task":{"id":999}}]}`},
+		{method: http.MethodPost, path: "/v1/projects/1/tasks:batch", status: http.StatusBadRequest,
+			body: `{"mode":"atomic","operations":[{"op":"create","task":{"name":"batched","column_id":2}},{"op":"delete","task":{"id":999}}]}`},
+		{method: http.MethodPost, path: "/v1/projects/1/tasks.csv?dry_run=true", contentType: "text/csv", body: csv, status: http.StatusOK},
+		{method: http.MethodPost, path: "/v1/projects/1/tasks.csv", contentType: "text/csv", body: csv, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/v1/projects/1/tasks.csv", status: http.StatusOK},
+
+		{method: http.MethodGet, path: "/v1/projects/1", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/columns/", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/columns/2", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/columns/2/tasks/", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/export", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/export.md", status: http.StatusOK},
+		{method: http.MethodGet, path: "/v1/projects/1/export.dot", status: http.StatusOK},
+		{method: http.MethodPost, path: "/v1/projects/1/calendar", status: http.StatusCreated, save: "url"},
+		{method: http.MethodGet, path: "{url}?component=vtodo", status: http.StatusOK},
+		{method: http.MethodPost, path: "/v1/projects/import", status: http.StatusCreated,
+			body: `{"schema_version":1,"exported_at":"2026-10-19T12:00:00Z","project":{"id":7,"name":"copy","description":"",` +
+				`"Columns":[{"id":7,"name":"copied","project_id":7,"order_number":1,"status":"",` +
+				`"Tasks":[{"id":7,"name":"copied","status":false,"description":"","column_id":7,"due_date":null,"Comments":null}]}]}}`},
+		{method: http.MethodPost, path: "/v1/projects/import/trello", status: http.StatusCreated,
+			body: `{"id":"b1","name":"trello","lists":[{"id":"l1","name":"backlog","pos":1}],` +
+				`"cards":[{"id":"c1","name":"card","idList":"l1","pos":1,"labels":[{"id":"x","name":"bug"}]}]}`},
+
+		{method: http.MethodGet, path: "/graphql/schema.graphql", status: http.StatusOK},
+		{method: http.MethodPost, path: "/graphql", status: http.StatusOK,
+			body: `{"query":"{ project(id: 1) { name columns { name tasks { name dueDate commentCount } } } }"}`},
+		{method: http.MethodPost, path: "/graphql", status: http.StatusOK,
+			body: `{"query":"mutation ($id: Int!) { updateProject(id: $id, input: {name: \"board\"}) { id } }","variables":{"id":1}}`},
+		// GraphQL reports errors in the result
+		{method: http.MethodPost, path: "/graphql", body: `{"query":"{ nope }"}`, status: http.StatusOK},
+		{method: http.MethodPost, path: "/graphql", body: `{"query":`, status: http.StatusBadRequest},
+
+		{method: http.MethodDelete, path: comment + "1", status: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/v1/projects/1/columns/2/tasks/1", status: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/v1/projects/1/columns/2", status: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/v1/projects/1", status: http.StatusNoContent},
+
+		{method: http.MethodGet, path: "/metrics", status: http.StatusOK},
+	}
+
+	values := map[string]string{}
+	driven := map[*openapi.Operation]bool{}
+	for _, step := range steps {
+		path := step.path
+		for name, value := range values {
+			path = strings.Replace(path, "{"+name+"}", value, -1)
+		}
+		req := httptest.NewRequest(step.method, path, strings.NewReader(step.body))
+		if step.body != "" {
+			contentType := step.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", contentType)
+		}
+		var match mux.RouteMatch
+		if !router.Match(req, &match) || match.Route == nil {
+			t.Errorf("%s %s: no route matches", step.method, path)
+			continue
+		}
+		template, _ := match.Route.GetPathTemplate()
+		operation := step.method + " " + template
+		driven[doc.Operation(step.method, template)] = true
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, req)
+		if response.Code != step.status {
+			t.Errorf("%s %s: status %d, expected %d: %s", step.method, path, response.Code, step.status, response.Body.String())
+		}
+		for _, problem := range doc.CheckResponse(step.method, template, response.Code, response.Header(), response.Body.Bytes()) {
+			t.Errorf("%s: %s", operation, problem)
+		}
+		var decoded map[string]interface{}
+		_ = json.Unmarshal(response.Body.Bytes(), &decoded)
+		for name, expected := range step.fields {
+			if decoded[name] != expected {
+				t.Errorf("%s %s: %s is %v, expected %v", step.method, path, name, decoded[name], expected)
+			}
+		}
+		if step.save != "" {
+			value, _ := decoded[step.save].(string)
+			values[step.save] = value
+		}
+	}
+
+	var missing []string
+	for path, item := range doc.Paths {
+		for method, operation := range item {
+			if !driven[operation] {
+				missing = append(missing, strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	sort.Strings(missing)
+	for _, operation := range missing {
+		t.Errorf("%s isn't driven by the contract test", operation)
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/handlers"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+// CORS returns the middleware answering preflight requests and adding the
+// Access-Control-* headers for the configured origins. It wraps the whole
+// router, since preflight requests match no route.
+func CORS(cfg config.CORS) func(http.Handler) http.Handler {
+	options := []handlers.CORSOption{
+		handlers.AllowedOrigins(cfg.AllowedOrigins),
+		handlers.AllowedHeaders(cfg.AllowedHeaders),
+		handlers.AllowedMethods(cfg.AllowedMethods),
+		handlers.ExposedHeaders(cfg.ExposedHeaders),
+		handlers.MaxAge(int(time.Duration(cfg.MaxAge).Seconds())),
+	}
+	if cfg.AllowCredentials {
+		options = append(options, handlers.AllowCredentials())
+	}
+	return handlers.CORS(options...)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+func TestCORS(t *testing.T) {
+	credentialed := config.Default().CORS
+	credentialed.AllowedOrigins = []string{"https://board.example.com"}
+	credentialed.AllowCredentials = true
+
+	type args struct {
+		cfg    config.CORS
+		method string
+		header map[string]string
+	}
+	type expected struct {
+		code   int
+		header map[string]string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+	}{
+		{
+			name: "preflight for a JSON request",
+			args: args{cfg: config.Default().CORS, method: http.MethodOptions, header: map[string]string{
+				"Origin":                         "https://board.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "Content-Type, Authorization",
+			}},
+			expected: expected{code: http.StatusOK, header: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Headers": "Content-Type,Authorization",
+				"Access-Control-Max-Age":       "600",
+			}},
+		},
+		{
+			name: "preflight for a header that isn't allowed",
+			args: args{cfg: config.Default().CORS, method: http.MethodOptions, header: map[string]string{
+				"Origin":                         "https://board.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "X-Debug",
+			}},
+			expected: expected{code: http.StatusForbidden, header: map[string]string{"Access-Control-Allow-Origin": ""}},
+		},
+		{
+			name: "credentials for a listed origin",
+			args: args{cfg: credentialed, method: http.MethodGet, header: map[string]string{
+				"Origin": "https://board.example.com",
+			}},
+			expected: expected{code: http.StatusNoContent, header: map[string]string{
+				"Access-Control-Allow-Origin":      "https://board.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Request-Id,Idempotent-Replayed,Ratelimit-Limit,Ratelimit-Remaining,Ratelimit-Reset,Retry-After,Deprecation,Sunset,Link",
+			}},
+		},
+		{
+			name: "origin that isn't listed",
+			args: args{cfg: credentialed, method: http.MethodGet, header: map[string]string{
+				"Origin": "https://evil.example.com",
+			}},
+			expected: expected{code: http.StatusNoContent, header: map[string]string{
+				"Access-Control-Allow-Origin":      "",
+				"Access-Control-Allow-Credentials": "",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CORS(tt.args.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			req := httptest.NewRequest(tt.args.method, "/projects/", nil)
+			for name, value := range tt.args.header {
+				req.Header.Set(name, value)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected.code, rr.Code)
+			for name, value := range tt.expected.header {
+				assert.Equal(t, value, rr.Header().Get(name), name)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package deadline
+
+import (
+	"net/http"
+	"time"
+)
+
+// Message is the body of the 503 response sent when a request runs out of time.
+const Message = "request timed out"
+
+// Middleware gives every request a deadline. Once it has passed the request
+// context is cancelled, which aborts the database queries still running for
+// the request, and the client gets a 503 instead of the late response.
+type Middleware struct {
+	timeout time.Duration
+}
+
+func NewMiddleware(timeout time.Duration) *Middleware {
+	return &Middleware{timeout: timeout}
+}
+
+//===========================================================================//
+
+// Wrap is a mux.MiddlewareFunc. The response is buffered until the handler
+// returns, so that nothing reaches the client before it is known whether the
+// deadline was met.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.TimeoutHandler(next, m.timeout, Message)
+}
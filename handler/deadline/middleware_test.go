@@ -0,0 +1,62 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_Wrap(t *testing.T) {
+	type expected struct {
+		statusCode int
+		body       string
+		ctxErr     error
+	}
+	tests := []struct {
+		name     string
+		handler  func(w http.ResponseWriter, r *http.Request) error
+		expected expected
+	}{
+		{
+			name: "fast request passes through",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("created"))
+				return r.Context().Err()
+			},
+			expected: expected{statusCode: http.StatusCreated, body: "created"},
+		},
+		{
+			name: "slow request is cancelled",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				return r.Context().Err()
+			},
+			expected: expected{statusCode: http.StatusServiceUnavailable, body: Message, ctxErr: context.DeadlineExceeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctxErr := make(chan error, 1)
+			m := NewMiddleware(50 * time.Millisecond)
+			handler := m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxErr <- tt.handler(w, r)
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/projects/1", nil))
+
+			assert.Equal(t, tt.expected.statusCode, recorder.Code)
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+			assert.Equal(t, tt.expected.ctxErr, <-ctxErr)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+	LinkHeader        = "Link"
+)
+
+// Middleware marks the responses of deprecated routes: Deprecation (RFC 9745)
+// says since when, Sunset (RFC 8594) until when they keep working and Link
+// points at the same route in the version replacing them.
+type Middleware struct {
+	deprecation string
+	sunset      string
+	successor   string
+}
+
+// NewMiddleware marks the legacy routes as deprecated in favour of the routes
+// under the successor prefix, e.g. /v1.
+func NewMiddleware(cfg config.API, successor string) *Middleware {
+	m := &Middleware{
+		deprecation: "@" + strconv.FormatInt(time.Time(cfg.LegacyDeprecated).Unix(), 10),
+		successor:   successor,
+	}
+	if !cfg.LegacySunset.IsZero() {
+		m.sunset = time.Time(cfg.LegacySunset).Format(http.TimeFormat)
+	}
+	return m
+}
+
+//===========================================================================//
+
+// Wrap is a mux.MiddlewareFunc for the router the legacy routes are
+// registered on.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set(DeprecationHeader, m.deprecation)
+		if m.sunset != "" {
+			header.Set(SunsetHeader, m.sunset)
+		}
+		successor := m.successor + r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			successor += "?" + r.URL.RawQuery
+		}
+		header.Add(LinkHeader, fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package deprecation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+func TestMiddleware_Wrap(t *testing.T) {
+	type args struct {
+		cfg config.API
+		url string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected map[string]string
+	}{
+		{
+			name: "defaults",
+			args: args{cfg: config.Default().API, url: "/projects/1"},
+			expected: map[string]string{
+				"Deprecation": "@1792368000",
+				"Sunset":      "Fri, 30 Apr 2027 00:00:00 GMT",
+				"Link":        `</v1/projects/1>; rel="successor-version"`,
+			},
+		},
+		{
+			name: "no sunset yet",
+			args: args{
+				cfg: config.API{LegacyDeprecated: config.Date(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))},
+				url: "/projects/1/tasks.csv?dry_run=true",
+			},
+			expected: map[string]string{
+				"Deprecation": "@1767225600",
+				"Sunset":      "",
+				"Link":        `</v1/projects/1/tasks.csv?dry_run=true>; rel="successor-version"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMiddleware(tt.args.cfg, "/v1").Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.args.url, nil))
+
+			assert.Equal(t, http.StatusNoContent, rr.Code)
+			for name, value := range tt.expected {
+				assert.Equal(t, value, rr.Header().Get(name), name)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/board"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
+
+	columnsUseCase "github.com/Boobuh/golang-school-project/service/columns"
+	commentUseCase "github.com/Boobuh/golang-school-project/service/comments"
+	projectUseCase "github.com/Boobuh/golang-school-project/service/projects"
+	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
+)
+
+// graphqlRoutes returns the GraphQL endpoint over the board. It stays outside
+// of the versions: a GraphQL schema grows by adding fields, and clients only
+// get the fields they ask for.
+func graphqlRoutes(repo dal.Repository, logger logging.Logger) []Route {
+	const tag = "graphql"
+	handle := func(fn web.HandlerFunc) http.HandlerFunc {
+		return web.Handle(fn, logger)
+	}
+
+	boardHandler := board.NewHandler(
+		projectUseCase.NewUseCase(repo, logger),
+		columnsUseCase.NewUseCase(repo, logger),
+		taskUseCase.NewUseCase(repo, logger),
+		commentUseCase.NewUseCase(repo, logger),
+		logger,
+	)
+
+	return []Route{
+		{http.MethodPost, "/graphql", handle(boardHandler.Query), openapi.Op{
+			ID: "queryGraphQL", Summary: "Run a GraphQL query or mutation over the board", Tag: tag,
+			Body: board.Request{}, Response: board.Result{},
+		}},
+		{http.MethodGet, "/graphql/schema.graphql", handle(boardHandler.Schema), openapi.Op{
+			ID: "getGraphQLSchema", Summary: "The GraphQL schema in the schema language", Tag: tag,
+			Response: "", ResponseType: "text/plain",
+		}},
+	}
+}
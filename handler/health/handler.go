@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/version"
+)
+
+const (
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+
+	// checkTimeout bounds every readiness check, a probe that hangs is as bad
+	// as one that fails.
+	checkTimeout = 2 * time.Second
+)
+
+// Check reports why a dependency of the server isn't ready, nil when it is.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Handler serves the liveness, readiness and build-info probes.
+type Handler struct {
+	logger logging.Logger
+	checks []namedCheck
+}
+
+func NewHandler(logger logging.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// AddCheck adds a check to readiness. Checks have to be added before the
+// server starts serving.
+func (h *Handler) AddCheck(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+type Status struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type BuildInfo struct {
+	Commit        string `json:"commit"`
+	BuildTime     string `json:"build_time"`
+	GoVersion     string `json:"go_version"`
+	SchemaVersion int    `json:"schema_version"`
+}
+
+//=======================================================================================//
+
+// Healthz answers as long as the process is able to serve requests at all.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, http.StatusOK, Status{Status: statusOK})
+}
+
+// Readyz runs every check and answers 503 when one of them fails, naming the
+// result of each check in the body.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.Ctx(r.Context())
+
+	status := Status{Status: statusOK, Checks: map[string]string{}}
+	code := http.StatusOK
+	for _, c := range h.checks {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		err := c.check(ctx)
+		cancel()
+		if err != nil {
+			logger.Warn("readiness check failed", "check", c.name, "error", err)
+			status.Checks[c.name] = err.Error()
+			status.Status = statusUnavailable
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		status.Checks[c.name] = statusOK
+	}
+	h.write(w, r, code, status)
+}
+
+// Version describes the running build and the database schema it expects.
+func (h *Handler) Version(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, http.StatusOK, BuildInfo{
+		Commit:        version.Commit,
+		BuildTime:     version.BuildTime,
+		GoVersion:     version.GoVersion(),
+		SchemaVersion: dal.SchemaVersion,
+	})
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, code int, body interface{}) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.logger.Ctx(r.Context()).Error("error in probe - can't marshal response", "error", err)
+		return
+	}
+	// probes must never be answered from a cache
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set(contentTypeHeader, jsonContentType)
+	w.WriteHeader(code)
+	w.Write(payload)
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/version"
+)
+
+func ok(context.Context) error { return nil }
+
+func TestHandler_Readyz(t *testing.T) {
+	type expected struct {
+		code   int
+		status Status
+	}
+	tests := []struct {
+		name     string
+		checks   map[string]Check
+		expected expected
+	}{
+		{
+			name:   "no checks",
+			checks: map[string]Check{},
+			expected: expected{
+				code:   http.StatusOK,
+				status: Status{Status: statusOK},
+			},
+		},
+		{
+			name:   "all checks pass",
+			checks: map[string]Check{"database": ok, "workers": ok},
+			expected: expected{
+				code:   http.StatusOK,
+				status: Status{Status: statusOK, Checks: map[string]string{"database": statusOK, "workers": statusOK}},
+			},
+		},
+		{
+			name: "shutting down",
+			checks: map[string]Check{
+				"database": ok,
+				"shutdown": func(context.Context) error { return errors.New("server is shutting down") },
+			},
+			expected: expected{
+				code: http.StatusServiceUnavailable,
+				status: Status{Status: statusUnavailable, Checks: map[string]string{
+					"database": statusOK,
+					"shutdown": "server is shutting down",
+				}},
+			},
+		},
+		{
+			name: "check that outlives its timeout",
+			checks: map[string]Check{
+				"database": func(ctx context.Context) error {
+					deadline, ok := ctx.Deadline()
+					if !ok || time.Until(deadline) > checkTimeout {
+						return errors.New("no deadline")
+					}
+					return context.DeadlineExceeded
+				},
+			},
+			expected: expected{
+				code:   http.StatusServiceUnavailable,
+				status: Status{Status: statusUnavailable, Checks: map[string]string{"database": "context deadline exceeded"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(logging.Nop())
+			for name, check := range tt.checks {
+				h.AddCheck(name, check)
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/readyz", h.Readyz)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+			var got Status
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			if len(tt.expected.status.Checks) == 0 {
+				tt.expected.status.Checks = map[string]string{}
+			}
+			if got.Checks == nil {
+				got.Checks = map[string]string{}
+			}
+			assert.Equal(t, tt.expected.status, got)
+		})
+	}
+}
+
+func TestHandler_HealthzAndVersion(t *testing.T) {
+	commit, buildTime := version.Commit, version.BuildTime
+	defer func() { version.Commit, version.BuildTime = commit, buildTime }()
+	version.Commit, version.BuildTime = "4f2a9c1", "2026-10-19T12:00:00Z"
+
+	h := NewHandler(logging.Nop())
+	h.AddCheck("database", func(context.Context) error { return errors.New("down") })
+	router := mux.NewRouter()
+	router.HandleFunc("/healthz", h.Healthz)
+	router.HandleFunc("/version", h.Version)
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{path: "/healthz", body: `{"status":"ok"}`},
+		{path: "/version", body: `{"commit":"4f2a9c1","build_time":"2026-10-19T12:00:00Z","go_version":"` + version.GoVersion() + `","schema_version":` + strconv.Itoa(dal.SchemaVersion) + `}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code, "liveness doesn't depend on the readiness checks")
+			assert.Equal(t, jsonContentType, recorder.Header().Get(contentTypeHeader))
+			assert.JSONEq(t, tt.body, recorder.Body.String())
+		})
+	}
+}
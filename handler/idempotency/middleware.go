@@ -0,0 +1,272 @@
+package idempotency
+
+//go:generate   $GOPATH/bin/mockgen -package mocks -destination=mocks/mock_store.go -package=mocks github.com/Boobuh/golang-school-project/handler/idempotency Store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	DefaultTTL = 24 * time.Hour
+	// PurgeInterval is how often expired keys are deleted by PurgeExpired.
+	PurgeInterval = time.Hour
+)
+
+type Store interface {
+	GetIdempotencyKey(ctx context.Context, key string) (*dal.IdempotencyKey, error)
+	SaveIdempotencyKey(ctx context.Context, record *dal.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error
+}
+
+// Middleware replays the stored response of a create request when a client
+// retries it with the same Idempotency-Key header.
+type Middleware struct {
+	logger logging.Logger
+	store  Store
+	ttl    time.Duration
+	now    func() time.Time
+
+	// locks serialize the requests of a key so that two concurrent retries
+	// can't both reach the wrapped handler before the first response is
+	// stored, while requests with other keys go ahead.
+	locks *keyLocks
+}
+
+func NewMiddleware(store Store, ttl time.Duration, logger logging.Logger) *Middleware {
+	return &Middleware{logger: logger, store: store, ttl: ttl, now: time.Now, locks: newKeyLocks()}
+}
+
+//===========================================================================//
+
+func (m *Middleware) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		logger := m.logger.Ctx(r.Context()).With("idempotency_key", key)
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			logger.Warn("error in idempotent request - can't read body", "error", err)
+			http.Error(w, err.Error(), request.Status(err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := Fingerprint(r, body)
+		scoped := ScopedKey(r, key)
+
+		unlock, err := m.locks.lock(r.Context(), scoped)
+		if err != nil {
+			logger.Warn("error in idempotent request - gave up waiting for the key", "error", err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer unlock()
+
+		now := m.now()
+		record, err := m.store.GetIdempotencyKey(r.Context(), scoped)
+		if err != nil {
+			logger.Error("error in idempotent request - can't load key", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if record != nil && record.ExpiresAt.After(now) {
+			if record.Fingerprint != fingerprint {
+				logger.Warn("idempotency key reused with a different request")
+				http.Error(w, "Idempotency-Key was already used with a different request", http.StatusUnprocessableEntity)
+				return
+			}
+			logger.Info("replaying stored response")
+			if record.ContentType != "" {
+				w.Header().Set("Content-Type", record.ContentType)
+			}
+			w.Header().Set(ReplayedHeader, "true")
+			w.WriteHeader(record.StatusCode)
+			w.Write(record.Body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		next(recorder, r)
+
+		// Only successful responses are kept: every failure in this API is
+		// reported as 400, including transient database errors, so a retry
+		// has to be allowed to reach the handler again.
+		if recorder.status() < http.StatusOK || recorder.status() >= http.StatusMultipleChoices {
+			return
+		}
+		// The response has been produced, so the key is stored even when the
+		// client went away meanwhile and cancelled the request context,
+		// otherwise its retry would run the request a second time.
+		err = m.store.SaveIdempotencyKey(context.Background(), &dal.IdempotencyKey{
+			Key:         scoped,
+			Fingerprint: fingerprint,
+			StatusCode:  recorder.status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+			ExpiresAt:   now.Add(m.ttl),
+		})
+		if err != nil {
+			logger.Error("error in idempotent request - can't store key", "error", err)
+		}
+	}
+}
+
+//---------------------------------------------------------------------------//
+
+// PurgeExpired deletes expired keys every interval until ctx is done. Expired
+// keys are already ignored by Wrap, purging only keeps the table small, so it
+// runs as a background worker instead of on the request path.
+func PurgeExpired(ctx context.Context, store Store, interval time.Duration, logger logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := store.DeleteExpiredIdempotencyKeys(ctx, now); err != nil {
+				logger.Error("error purging expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
+
+//---------------------------------------------------------------------------//
+
+// ScopedKey is the key a response is stored under: the Idempotency-Key of r
+// bound to the client that sent it and to its method and path, so that a key
+// never replays the response of another client or route. The client is told
+// by its API key, or else by its address; API keys are hashed so that they
+// don't end up in the database.
+func ScopedKey(r *http.Request, key string) string {
+	client := r.Header.Get(ratelimit.APIKeyHeader)
+	if client != "" {
+		client = "key:" + client
+	} else {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		client = "ip:" + host
+	}
+	hash := sha256.New()
+	io.WriteString(hash, client)
+	io.WriteString(hash, "\n")
+	io.WriteString(hash, r.Method+" "+r.URL.Path)
+	io.WriteString(hash, "\n")
+	io.WriteString(hash, key)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Fingerprint identifies a request by its method, path, query and body so that
+// a key can't be replayed against a different endpoint or payload.
+func Fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method)
+	io.WriteString(hash, "\n")
+	io.WriteString(hash, r.URL.RequestURI())
+	io.WriteString(hash, "\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+//---------------------------------------------------------------------------//
+
+// keyLocks hand out a lock per key. Locks are dropped once nobody holds or
+// waits for them, so the map only has the keys of requests in flight.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	// held has room for one token, sent by the holder of the lock
+	held  chan struct{}
+	users int
+}
+
+func newKeyLocks() *keyLocks {
+	return &keyLocks{locks: map[string]*keyLock{}}
+}
+
+// lock waits for the lock of key until ctx is done, and returns the function
+// releasing it.
+func (l *keyLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	kl, ok := l.locks[key]
+	if !ok {
+		kl = &keyLock{held: make(chan struct{}, 1)}
+		l.locks[key] = kl
+	}
+	kl.users++
+	l.mu.Unlock()
+
+	select {
+	case kl.held <- struct{}{}:
+		return func() {
+			<-kl.held
+			l.release(key, kl)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, kl)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *keyLocks) release(key string, kl *keyLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kl.users--
+	if kl.users == 0 {
+		delete(l.locks, key)
+	}
+}
+
+//---------------------------------------------------------------------------//
+
+type responseRecorder struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *responseRecorder) status() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
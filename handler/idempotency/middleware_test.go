@@ -0,0 +1,272 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/idempotency/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestMiddleware_Wrap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"name":"one"}`
+	fingerprint := Fingerprint(httptest.NewRequest(http.MethodPost, "/projects/", nil), []byte(body))
+	scopedReq, _ := http.NewRequest(http.MethodPost, "/projects/", nil)
+	scoped := ScopedKey(scopedReq, "abc")
+
+	type fields struct {
+		store Store
+	}
+	type args struct {
+		key  string
+		body string
+	}
+	type expected struct {
+		code         int
+		body         string
+		handlerCalls int
+		replayed     string
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "no key",
+			fields: fields{
+				store: mocks.NewMockStore(ctrl),
+			},
+			args:     args{body: body},
+			expected: expected{code: http.StatusCreated, body: "created", handlerCalls: 1},
+		},
+		{
+			name: "first request is stored",
+			fields: fields{
+				store: func() Store {
+					store := mocks.NewMockStore(ctrl)
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), scoped).Return(nil, nil).Times(1)
+					store.EXPECT().SaveIdempotencyKey(gomock.Any(), &dal.IdempotencyKey{
+						Key:         scoped,
+						Fingerprint: fingerprint,
+						StatusCode:  http.StatusCreated,
+						Body:        []byte("created"),
+						ExpiresAt:   now.Add(DefaultTTL),
+					}).Return(nil).Times(1)
+					return store
+				}(),
+			},
+			args:     args{key: "abc", body: body},
+			expected: expected{code: http.StatusCreated, body: "created", handlerCalls: 1},
+		},
+		{
+			name: "retry is replayed",
+			fields: fields{
+				store: func() Store {
+					store := mocks.NewMockStore(ctrl)
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), scoped).Return(&dal.IdempotencyKey{
+						Key:         scoped,
+						Fingerprint: fingerprint,
+						StatusCode:  http.StatusCreated,
+						Body:        []byte("stored"),
+						ExpiresAt:   now.Add(time.Hour),
+					}, nil).Times(1)
+					return store
+				}(),
+			},
+			args:     args{key: "abc", body: body},
+			expected: expected{code: http.StatusCreated, body: "stored", replayed: "true"},
+		},
+		{
+			name: "key reused with another body",
+			fields: fields{
+				store: func() Store {
+					store := mocks.NewMockStore(ctrl)
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), scoped).Return(&dal.IdempotencyKey{
+						Key:         scoped,
+						Fingerprint: fingerprint,
+						StatusCode:  http.StatusCreated,
+						ExpiresAt:   now.Add(time.Hour),
+					}, nil).Times(1)
+					return store
+				}(),
+			},
+			args:     args{key: "abc", body: `{"name":"two"}`},
+			expected: expected{code: http.StatusUnprocessableEntity, body: "Idempotency-Key was already used with a different request\n"},
+		},
+		{
+			name: "expired key is processed again",
+			fields: fields{
+				store: func() Store {
+					store := mocks.NewMockStore(ctrl)
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), scoped).Return(&dal.IdempotencyKey{
+						Key:         scoped,
+						Fingerprint: "outdated",
+						StatusCode:  http.StatusCreated,
+						ExpiresAt:   now.Add(-time.Minute),
+					}, nil).Times(1)
+					store.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+					return store
+				}(),
+			},
+			args:     args{key: "abc", body: body},
+			expected: expected{code: http.StatusCreated, body: "created", handlerCalls: 1},
+		},
+		{
+			name: "store failed",
+			fields: fields{
+				store: func() Store {
+					store := mocks.NewMockStore(ctrl)
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), scoped).Return(nil, errors.New("failed")).Times(1)
+					return store
+				}(),
+			},
+			args:     args{key: "abc", body: body},
+			expected: expected{code: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(tt.fields.store, DefaultTTL, logging.Nop())
+			m.now = func() time.Time { return now }
+
+			handlerCalls := 0
+			next := func(w http.ResponseWriter, r *http.Request) {
+				handlerCalls++
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("created"))
+			}
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/projects/", bytes.NewReader([]byte(tt.args.body)))
+			assert.NoError(t, err)
+			if tt.args.key != "" {
+				req.Header.Set(KeyHeader, tt.args.key)
+			}
+			m.Wrap(next)(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+			assert.Equal(t, tt.expected.handlerCalls, handlerCalls)
+			assert.Equal(t, tt.expected.replayed, recorder.Header().Get(ReplayedHeader))
+		})
+	}
+}
+
+func TestMiddleware_WrapLocksPerKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockStore(ctrl)
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	store.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m := NewMiddleware(store, DefaultTTL, logging.Nop())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	next := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(KeyHeader) == "slow" {
+			close(started)
+			<-release
+		}
+		w.WriteHeader(http.StatusCreated)
+	}
+	send := func(ctx context.Context, key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/projects/", bytes.NewReader([]byte(`{}`))).WithContext(ctx)
+		req.Header.Set(KeyHeader, key)
+		recorder := httptest.NewRecorder()
+		m.Wrap(next)(recorder, req)
+		return recorder.Code
+	}
+
+	done := make(chan int)
+	go func() { done <- send(context.Background(), "slow") }()
+	<-started
+
+	// another key doesn't wait for the slow request
+	assert.Equal(t, http.StatusCreated, send(context.Background(), "fast"))
+	// a retry of the slow request waits, but not past its deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, http.StatusServiceUnavailable, send(ctx, "slow"))
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, <-done)
+	assert.Empty(t, m.locks.locks)
+}
+
+func TestScopedKey(t *testing.T) {
+	request := func(method, target, remoteAddr, apiKey string) *http.Request {
+		r := httptest.NewRequest(method, target, nil)
+		r.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			r.Header.Set("X-API-Key", apiKey)
+		}
+		return r
+	}
+	key := ScopedKey(request(http.MethodPost, "/v1/projects/", "192.0.2.1:1234", ""), "abc")
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		key     string
+		same    bool
+	}{
+		{name: "same client from another port", request: request(http.MethodPost, "/v1/projects/", "192.0.2.1:5678", ""), key: "abc", same: true},
+		{name: "query", request: request(http.MethodPost, "/v1/projects/?dry_run=true", "192.0.2.1:1234", ""), key: "abc", same: true},
+		{name: "another key", request: request(http.MethodPost, "/v1/projects/", "192.0.2.1:1234", ""), key: "abd"},
+		{name: "another address", request: request(http.MethodPost, "/v1/projects/", "192.0.2.2:1234", ""), key: "abc"},
+		{name: "an API key", request: request(http.MethodPost, "/v1/projects/", "192.0.2.1:1234", "team-a"), key: "abc"},
+		{name: "another route", request: request(http.MethodPost, "/v1/projects/import", "192.0.2.1:1234", ""), key: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, ScopedKey(tt.request, tt.key) == key)
+		})
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := mocks.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(1),
+		store.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, now time.Time) error {
+			cancel()
+			return nil
+		}).Times(1),
+		// the ticker may fire once more before the cancellation is noticed
+		store.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any(), gomock.Any()).Return(nil).AnyTimes(),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		PurgeExpired(ctx, store, time.Millisecond, logging.Nop())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("PurgeExpired() didn't stop after the context was cancelled")
+	}
+}
@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version of the OpenAPI specification the documents follow.
+const Version = "3.0.3"
+
+const jsonType = "application/json"
+
+// Document is an OpenAPI 3 document, built from the routes of the router
+// with Add.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	names map[reflect.Type]string
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations of a path by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the subset of the OpenAPI schema object the documents use.
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Nullable   bool               `json:"nullable,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// Required lists the properties always present, those of fields without
+	// omitempty.
+	Required             []string `json:"required,omitempty"`
+	Items                *Schema  `json:"items,omitempty"`
+	AdditionalProperties *Schema  `json:"additionalProperties,omitempty"`
+	Enum                 []string `json:"enum,omitempty"`
+	// AllOf wraps a reference that may be null, since the siblings of $ref
+	// are ignored.
+	AllOf []*Schema `json:"allOf,omitempty"`
+}
+
+func NewDocument(info Info) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+//===========================================================================//
+
+// Op documents an operation the way a route declares it. Bodies are given as
+// values of their Go types, whose schemas are derived from the JSON encoding.
+type Op struct {
+	ID      string
+	Summary string
+	Tag     string
+	// Query lists the query parameters, path parameters are taken from the
+	// route template.
+	Query []Parameter
+	// Body is a value of the request body type, nil for requests without one.
+	Body interface{}
+	// BodyType is the media type of the request body, JSON if empty.
+	BodyType string
+	// Status answers a successful request, 200 if 0. Also lists other
+	// statuses answered with the same body.
+	Status int
+	Also   []int
+	// Response is a value of the response body type, nil for an empty body.
+	Response interface{}
+	// ResponseType is the media type of the response body, JSON if empty.
+	// ResponseTypes lists them instead for a body served in several formats.
+	ResponseType  string
+	ResponseTypes []string
+}
+
+// Add documents the operation of method on the route template path.
+func (d *Document) Add(method, path string, op Op) {
+	operation := &Operation{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Parameters:  append(pathParameters(path), op.Query...),
+		Responses: map[string]*Response{
+			"default": {
+				Description: "The request failed, the body says why.",
+				Content:     map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}},
+			},
+		},
+	}
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+	if op.Body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{mediaType(op.BodyType): {Schema: d.SchemaOf(op.Body)}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	for _, status := range append([]int{status}, op.Also...) {
+		response := &Response{Description: http.StatusText(status)}
+		if op.Response != nil {
+			types := op.ResponseTypes
+			if len(types) == 0 {
+				types = []string{mediaType(op.ResponseType)}
+			}
+			response.Content = map[string]MediaType{}
+			for _, t := range types {
+				response.Content[t] = MediaType{Schema: d.SchemaOf(op.Response)}
+			}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+
+	item, ok := d.Paths[openAPIPath(path)]
+	if !ok {
+		item = PathItem{}
+		d.Paths[openAPIPath(path)] = item
+	}
+	item[strings.ToLower(method)] = operation
+}
+
+// Operation returns the operation of method on the route template path, nil
+// if it isn't documented.
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[openAPIPath(path)][strings.ToLower(method)]
+}
+
+// Handler serves the document as JSON.
+func (d *Document) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := json.Marshal(d)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(payload)
+	}
+}
+
+func mediaType(t string) string {
+	if t == "" {
+		return jsonType
+	}
+	return t
+}
+
+// variable matches the variables of mux route templates, with their optional
+// pattern.
+var variable = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?\}`)
+
+// openAPIPath drops the patterns from the variables of a route template.
+func openAPIPath(path string) string {
+	return variable.ReplaceAllString(path, "{$1}")
+}
+
+// pathParameters documents the variables of a route template; IDs are
+// integers, anything else is a string.
+func pathParameters(path string) []Parameter {
+	var parameters []Parameter
+	for _, match := range variable.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "ID") {
+			schema = &Schema{Type: "integer"}
+		}
+		parameters = append(parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return parameters
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Created  time.Time `json:"created"`
+	Parent   *node     `json:"parent"`
+	Children []node
+	Secret   string `json:"-"`
+	hidden   bool
+}
+
+type named struct {
+	node
+	Tags map[string]string `json:"tags"`
+}
+
+func TestDocument_SchemaOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      interface{}
+		expected   *Schema
+		components []string
+	}{
+		{
+			name:     "string",
+			value:    "",
+			expected: &Schema{Type: "string"},
+		},
+		{
+			name:     "nullable integer",
+			value:    new(int64),
+			expected: &Schema{Type: "integer", Format: "int64", Nullable: true},
+		},
+		{
+			name:     "bytes",
+			value:    []byte{},
+			expected: &Schema{Type: "string", Format: "byte"},
+		},
+		{
+			name:       "slice of structs",
+			value:      []node{},
+			expected:   &Schema{Type: "array", Nullable: true, Items: &Schema{Ref: "#/components/schemas/node"}},
+			components: []string{"node"},
+		},
+		{
+			name:       "embedded struct",
+			value:      named{},
+			expected:   &Schema{Ref: "#/components/schemas/named"},
+			components: []string{"named", "node"},
+		},
+		{
+			name:  "unnamed struct",
+			value: struct{ OK bool }{},
+			expected: &Schema{Type: "object", Properties: map[string]*Schema{
+				"OK": {Type: "boolean"},
+			}, Required: []string{"OK"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument(Info{})
+			assert.Equal(t, tt.expected, doc.SchemaOf(tt.value))
+			var components []string
+			for name := range doc.Components.Schemas {
+				components = append(components, name)
+			}
+			assert.ElementsMatch(t, tt.components, components)
+		})
+	}
+}
+
+func TestDocument_SchemaOf_Fields(t *testing.T) {
+	doc := NewDocument(Info{})
+	doc.SchemaOf(named{})
+
+	assert.Equal(t, map[string]*Schema{
+		"id":       {Type: "integer"},
+		"name":     {Type: "string"},
+		"created":  {Type: "string", Format: "date-time"},
+		"parent":   {AllOf: []*Schema{{Ref: "#/components/schemas/node"}}, Nullable: true},
+		"Children": {Type: "array", Nullable: true, Items: &Schema{Ref: "#/components/schemas/node"}},
+		"tags":     {Type: "object", Nullable: true, AdditionalProperties: &Schema{Type: "string"}},
+	}, doc.Components.Schemas["named"].Properties)
+	// name is omitempty
+	assert.Equal(t, []string{"id", "created", "parent", "Children", "tags"}, doc.Components.Schemas["named"].Required)
+}
+
+func TestDocument_Add(t *testing.T) {
+	doc := NewDocument(Info{})
+	doc.Add(http.MethodPost, "/projects/{projectID}/columns/{name:[a-z]+}", Op{
+		ID:       "create",
+		Tag:      "columns",
+		Query:    []Parameter{{Name: "dry_run", In: "query", Schema: &Schema{Type: "boolean"}}},
+		Body:     "",
+		BodyType: "text/csv",
+		Status:   http.StatusCreated,
+		Also:     []int{http.StatusOK},
+		Response: node{},
+	})
+
+	operation := doc.Operation(http.MethodPost, "/projects/{projectID}/columns/{name}")
+	if !assert.NotNil(t, operation) {
+		return
+	}
+	assert.Equal(t, "create", operation.OperationID)
+	assert.Equal(t, []string{"columns"}, operation.Tags)
+	assert.Equal(t, []Parameter{
+		{Name: "projectID", In: "path", Required: true, Schema: &Schema{Type: "integer"}},
+		{Name: "name", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "dry_run", In: "query", Schema: &Schema{Type: "boolean"}},
+	}, operation.Parameters)
+	assert.Equal(t, &Schema{Type: "string"}, operation.RequestBody.Content["text/csv"].Schema)
+	for _, status := range []string{"201", "200"} {
+		if assert.Contains(t, operation.Responses, status) {
+			assert.Equal(t, &Schema{Ref: "#/components/schemas/node"}, operation.Responses[status].Content[jsonType].Schema)
+		}
+	}
+	assert.Contains(t, operation.Responses, "default")
+	assert.Nil(t, doc.Operation(http.MethodGet, "/projects/{projectID}/columns/{name}"))
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaOf returns the schema of the JSON encoding of v. Named structs become
+// components referenced by their type name, qualified with the package name
+// when two packages use the same one.
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	return d.schema(reflect.TypeOf(v))
+}
+
+func (d *Document) schema(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := d.schema(t.Elem())
+		if schema.Ref != "" {
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// nil slices are encoded as null
+		return &Schema{Type: "array", Items: d.schema(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schema(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+			d.fields(t, schema)
+			return schema
+		}
+		return &Schema{Ref: "#/components/schemas/" + d.component(t)}
+	}
+	// interfaces can hold anything
+	return &Schema{}
+}
+
+// component registers the schema of a named struct before filling it in, so
+// that types referring to themselves end.
+func (d *Document) component(t reflect.Type) string {
+	if name, ok := d.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := d.Components.Schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	d.names[t] = name
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.Components.Schemas[name] = schema
+	d.fields(t, schema)
+	return name
+}
+
+// fields adds the properties of a struct the way encoding/json names them,
+// with the fields of embedded structs promoted.
+func (d *Document) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		options := strings.Split(tag, ",")
+		name := options[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				d.fields(embedded, schema)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, seen := schema.Properties[name]; !seen && !contains(options[1:], "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = d.schema(field.Type)
+	}
+}
@@ -0,0 +1,16 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// UI serves a page rendering the document served next to it as openapi.json.
+// It is self-contained, so it works without access to the internet.
+func UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage)
+}
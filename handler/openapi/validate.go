@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckResponse validates a response of the operation of method on the route
+// template path against the document. It returns the problems found: statuses,
+// content types and fields the document doesn't mention, required fields that
+// are missing, and values of another type than documented.
+func (d *Document) CheckResponse(method, path string, status int, header http.Header, body []byte) []string {
+	operation := d.Operation(method, path)
+	if operation == nil {
+		return []string{fmt.Sprintf("%s %s isn't documented", method, path)}
+	}
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok && status >= http.StatusBadRequest {
+		response, ok = operation.Responses["default"]
+	}
+	if !ok {
+		return []string{fmt.Sprintf("status %d isn't documented", status)}
+	}
+
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			return []string{fmt.Sprintf("status %d is documented without a body, got %d bytes", status, len(body))}
+		}
+		return nil
+	}
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return []string{fmt.Sprintf("content type %q can't be parsed: %v", header.Get("Content-Type"), err)}
+	}
+	media, ok := response.Content[contentType]
+	if !ok {
+		return []string{fmt.Sprintf("content type %s isn't documented for status %d", contentType, status)}
+	}
+	if contentType != jsonType {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []string{fmt.Sprintf("body isn't JSON: %v", err)}
+	}
+	return d.Validate(media.Schema, value)
+}
+
+// Validate checks a value decoded from JSON, with numbers decoded as
+// json.Number, against schema.
+func (d *Document) Validate(schema *Schema, value interface{}) []string {
+	var problems []string
+	d.validate("$", schema, value, &problems)
+	return problems
+}
+
+func (d *Document) validate(at string, schema *Schema, value interface{}, problems *[]string) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		component, ok := d.Components.Schemas[name]
+		if !ok {
+			report("schema %s isn't defined", schema.Ref)
+			return
+		}
+		schema = component
+	}
+	if value == nil {
+		if !schema.Nullable && (schema.Type != "" || len(schema.AllOf) > 0) {
+			report("null isn't allowed")
+		}
+		return
+	}
+
+	for _, part := range schema.AllOf {
+		d.validate(at, part, value, problems)
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			report("expected an object, got %s", jsonKind(value))
+			return
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, ok := schema.Properties[key]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				report("field %q isn't documented", key)
+				continue
+			}
+			d.validate(at+"."+key, property, object[key], problems)
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				report("field %q is missing", name)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			report("expected an array, got %s", jsonKind(value))
+			return
+		}
+		for i, item := range array {
+			d.validate(fmt.Sprintf("%s[%d]", at, i), schema.Items, item, problems)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			report("expected a string, got %s", jsonKind(value))
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				report("%q isn't a date-time", text)
+			}
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, text) {
+			report("%q isn't one of %s", text, strings.Join(schema.Enum, ", "))
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			report("expected an integer, got %s", jsonKind(value))
+			return
+		}
+		if _, err := number.Int64(); err != nil {
+			report("%s isn't an integer", number)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			report("expected a number, got %s", jsonKind(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("expected a boolean, got %s", jsonKind(value))
+		}
+	}
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return "null"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
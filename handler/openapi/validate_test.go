@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_CheckResponse(t *testing.T) {
+	doc := NewDocument(Info{})
+	doc.Add(http.MethodGet, "/nodes/{id}", Op{Response: node{}, Also: []int{http.StatusMultiStatus}})
+	doc.Add(http.MethodDelete, "/nodes/{id}", Op{Status: http.StatusNoContent})
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		header   http.Header
+		body     string
+		expected []string
+	}{
+		{
+			name:   "valid",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			header: jsonHeader,
+			body:   `{"id":1,"name":"a","created":"2026-10-19T12:00:00Z","parent":null,"Children":[{"id":2,"created":"2026-10-19T12:00:00Z","parent":null,"Children":null}]}`,
+		},
+		{
+			name:     "undocumented field",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			header:   jsonHeader,
+			body:     `{"id":1,"created":"2026-10-19T12:00:00Z","parent":null,"Children":[{"id":2,"created":"2026-10-19T12:00:00Z","parent":null,"Children":null,"color":"red"}]}`,
+			expected: []string{`$.Children[0]: field "color" isn't documented`},
+		},
+		{
+			name:     "wrong types",
+			method:   http.MethodGet,
+			status:   http.StatusMultiStatus,
+			header:   jsonHeader,
+			body:     `{"id":1.5,"name":2,"created":"yesterday","parent":null,"Children":{}}`,
+			expected: []string{`$.Children: expected an array, got an object`, `$.created: "yesterday" isn't a date-time`, `$.id: 1.5 isn't an integer`, `$.name: expected a string, got a number`},
+		},
+		{
+			name:     "missing fields",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			header:   jsonHeader,
+			body:     `{"id":1,"parent":null}`,
+			expected: []string{`$: field "created" is missing`, `$: field "Children" is missing`},
+		},
+		{
+			name:     "null that isn't nullable",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			header:   jsonHeader,
+			body:     `{"id":null,"created":"2026-10-19T12:00:00Z","parent":null,"Children":null}`,
+			expected: []string{"$.id: null isn't allowed"},
+		},
+		{
+			name:     "undocumented status",
+			method:   http.MethodGet,
+			status:   http.StatusAccepted,
+			header:   jsonHeader,
+			body:     `{}`,
+			expected: []string{"status 202 isn't documented"},
+		},
+		{
+			name:     "undocumented content type",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			header:   http.Header{"Content-Type": {"text/csv"}},
+			body:     "id\n1\n",
+			expected: []string{"content type text/csv isn't documented for status 200"},
+		},
+		{
+			name:   "failure",
+			method: http.MethodGet,
+			status: http.StatusNotFound,
+			header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			body:   "not found\n",
+		},
+		{
+			name:   "empty",
+			method: http.MethodDelete,
+			status: http.StatusNoContent,
+		},
+		{
+			name:     "body of an empty response",
+			method:   http.MethodDelete,
+			status:   http.StatusNoContent,
+			body:     "{}",
+			expected: []string{"status 204 is documented without a body, got 2 bytes"},
+		},
+		{
+			name:     "undocumented operation",
+			method:   http.MethodPut,
+			status:   http.StatusOK,
+			expected: []string{"PUT /nodes/{id} isn't documented"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := doc.CheckResponse(tt.method, "/nodes/{id}", tt.status, tt.header, []byte(tt.body))
+			assert.Equal(t, tt.expected, problems)
+		})
+	}
+}
@@ -3,180 +3,244 @@ package projects
 //go:generate   $GOPATH/bin/mockgen -package mocks -destination=mocks/mock_service.go -package=mocks github.com/Boobuh/golang-school-project/handler/projects Service
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
-	"strconv"
 
 	"github.com/Boobuh/golang-school-project/dal"
-
-	"github.com/gorilla/mux"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/service/calendar"
+	"github.com/Boobuh/golang-school-project/service/trello"
 )
 
 type Handler struct {
-	logger  *log.Logger
+	logger  logging.Logger
 	service Service
 }
 
-const (
-	contentTypeHeader = "Content-Type"
-	jsonContentType   = "application/json; charset=utf-8"
-)
-
 type Service interface {
 	//--------------------------------------------------------------//
-	GetProjects() ([]dal.Project, error)
-	GetProject(id int) (*dal.ExtendedProjectEntities, error)
-	CreateProject(project *dal.Project) error
-	DeleteProject(id int) error
-	UpdateProject(updatedProject *dal.Project) error
+	GetProjects(ctx context.Context) ([]dal.Project, error)
+	GetProject(ctx context.Context, id int) (*dal.ExtendedProjectEntities, error)
+	CreateProject(ctx context.Context, project *dal.Project) error
+	DeleteProject(ctx context.Context, id int) error
+	UpdateProject(ctx context.Context, updatedProject *dal.Project) error
+	//--------------------------------------------------------------//
+	ExportProject(ctx context.Context, id int) (*dal.ProjectArchive, error)
+	ImportProject(ctx context.Context, archive *dal.ProjectArchive) (*dal.ImportReport, error)
+	ImportTrelloBoard(ctx context.Context, board *trello.Board) (*dal.ImportReport, error)
+	RenderProject(ctx context.Context, id int, format string, w io.Writer) (string, error)
+	//--------------------------------------------------------------//
+	CreateCalendarFeed(ctx context.Context, projectID int) (*dal.CalendarFeed, error)
+	RenderCalendarFeed(ctx context.Context, token, component string, w io.Writer) error
 	//--------------------------------------------------------------//
 
 }
 
-func NewHandler(service Service, logger *log.Logger) *Handler {
+func NewHandler(service Service, logger logging.Logger) *Handler {
 	return &Handler{service: service, logger: logger}
 }
 
 //===========================================================================//
 
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new get request")
+func (h *Handler) GetAll(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new get request")
 
-	getProjects, err := h.service.GetProjects()
+	getProjects, err := h.service.GetProjects(r.Context())
 	if err != nil {
-		h.logger.Printf("error in GET getProjects call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, err
+	}
+	return web.JSON(http.StatusOK, getProjects), nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) Get(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new get request")
+
+	params := web.Params(r)
+	id := params.Int("id")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(getProjects)
+	project, err := h.service.GetProject(r.Context(), id)
 	if err != nil {
-		h.logger.Printf("error in GET getProjects call - can't marshal object from db:%s", err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, project), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new get request")
+func (h *Handler) Create(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new create request")
 
-	vars := mux.Vars(r)
-	idRaw, ok := vars["id"]
-	if !ok {
-		http.Error(w, "id is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("id is missing in parameters")
+	var newProject dal.Project
+	if err := request.DecodeJSON(r, &newProject); err != nil {
+		return nil, err
 	}
-	id, err := strconv.Atoi(idRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+	if err := h.service.CreateProject(r.Context(), &newProject); err != nil {
+		return nil, err
 	}
-	project, err := h.service.GetProject(id)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in receiving project by id:%s", err.Error())
-		return
+	return web.JSON(http.StatusCreated, newProject), nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) Delete(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new delete request")
+
+	params := web.Params(r)
+	id := params.Int("id")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(project)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in GET projects call - can't marshal object from db:%s", err.Error())
-		return
+	if err := h.service.DeleteProject(r.Context(), id); err != nil {
+		return nil, err
 	}
-	//w.Header().Set("Content-Type", "application/json")
+	return web.Empty(http.StatusNoContent), nil
+}
 
-	w.Header().Set(contentTypeHeader, jsonContentType)
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+//---------------------------------------------------------------------------//
+
+func (h *Handler) Update(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new update request")
+
+	params := web.Params(r)
+	id := params.Int("id")
+	if err := params.Err(); err != nil {
+		return nil, err
+	}
+	var updatedProject dal.Project
+	if err := request.DecodeJSON(r, &updatedProject); err != nil {
+		return nil, err
+	}
+	updatedProject.ID = id
+	if err := h.service.UpdateProject(r.Context(), &updatedProject); err != nil {
+		return nil, err
+	}
+	return web.JSON(http.StatusOK, map[string]string{"message": "success"}), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new create request")
+func (h *Handler) Export(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new export request")
 
-	var newProject dal.Project
-	err := json.NewDecoder(r.Body).Decode(&newProject)
-	if err != nil {
-		h.logger.Printf("error in POST project call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	params := web.Params(r)
+	id := params.Int("id")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	err = h.service.CreateProject(&newProject)
+	archive, err := h.service.ExportProject(r.Context(), id)
 	if err != nil {
-		h.logger.Printf("error in CREATE projects call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
-
+		return nil, err
 	}
-	w.WriteHeader(http.StatusCreated)
+	return web.JSON(http.StatusOK, archive).
+		WithHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%d.json"`, id)), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new create request")
-	vars := mux.Vars(r)
-	idRaw, ok := vars["id"]
-	if !ok {
-		fmt.Println("id is missing in parameters")
+func (h *Handler) Import(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new import request")
+
+	var archive dal.ProjectArchive
+	if err := request.DecodeJSON(r, &archive); err != nil {
+		return nil, err
 	}
-	id, err := strconv.Atoi(idRaw)
+	report, err := h.service.ImportProject(r.Context(), &archive)
 	if err != nil {
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+		return nil, err
 	}
+	return web.JSON(http.StatusCreated, report), nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) ImportTrello(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new trello import request")
 
-	err = h.service.DeleteProject(id)
+	var board trello.Board
+	if err := request.DecodeJSONAllowUnknown(r, &board); err != nil {
+		return nil, err
+	}
+	report, err := h.service.ImportTrelloBoard(r.Context(), &board)
 	if err != nil {
-		h.logger.Printf("error in DELETE projects call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, err
 	}
-	w.WriteHeader(http.StatusNoContent)
+	return web.JSON(http.StatusCreated, report), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new update request")
+func (h *Handler) Render(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new render request")
 
-	vars := mux.Vars(r)
-	idRaw, ok := vars["id"]
-	if !ok {
-		fmt.Println("id is missing in parameters")
+	params := web.Params(r)
+	id := params.Int("id")
+	format := params.String("format")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	id, errConv := strconv.Atoi(idRaw)
-	if errConv != nil {
-		h.logger.Printf("error in converting id to int:%s", errConv.Error())
-		return
+	var payload bytes.Buffer
+	contentType, err := h.service.RenderProject(r.Context(), id, format, &payload)
+	if err != nil {
+		return nil, err
 	}
+	return web.Raw(http.StatusOK, contentType, payload.Bytes()).
+		WithHeader("Content-Disposition", fmt.Sprintf(`inline; filename="project-%d.%s"`, id, format)), nil
+}
 
-	var updatedProject dal.Project
-	err := json.NewDecoder(r.Body).Decode(&updatedProject)
-	if err != nil {
-		h.logger.Printf("error in POST project call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
+//---------------------------------------------------------------------------//
+
+func (h *Handler) CreateCalendarFeed(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new calendar feed request")
 
-		return
+	params := web.Params(r)
+	id := params.Int("id")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	updatedProject.ID = id
-	err = h.service.UpdateProject(&updatedProject)
+	feed, err := h.service.CreateCalendarFeed(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	feed.URL = fmt.Sprintf("/v1/calendar/%s.ics", feed.Token)
+	return web.JSON(http.StatusCreated, feed).WithHeader("Location", feed.URL), nil
+}
 
+//---------------------------------------------------------------------------//
+
+// CalendarFeed serves the feed of a token. The component query parameter picks
+// between events (the default) and to-dos.
+func (h *Handler) CalendarFeed(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new calendar feed download")
+
+	params := web.Params(r)
+	token := params.String("token")
+	if err := params.Err(); err != nil {
+		return nil, err
+	}
+	component, err := calendar.ParseComponent(r.URL.Query().Get("component"))
 	if err != nil {
-		h.logger.Printf("error in UPDATE projects call - can't marshal object from db:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte(`{"message":"success"}`))
-	w.WriteHeader(http.StatusOK)
+	var payload bytes.Buffer
+	err = h.service.RenderCalendarFeed(r.Context(), token, component, &payload)
+	if errors.Is(err, calendar.ErrFeedNotFound) {
+		// the token is the credential, so unknown tokens don't say any more than that
+		return nil, web.Errorf(http.StatusNotFound, "404 page not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return web.Raw(http.StatusOK, calendar.ContentType, payload.Bytes()).
+		WithHeader("Content-Disposition", `inline; filename="tasks.ics"`), nil
 }
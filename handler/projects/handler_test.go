@@ -2,22 +2,27 @@ package projects
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/Boobuh/golang-school-project/handler/projects/mocks"
+	"github.com/Boobuh/golang-school-project/service/calendar"
+	"github.com/Boobuh/golang-school-project/service/trello"
 )
 
 func TestHandler_Get(t *testing.T) {
@@ -25,7 +30,7 @@ func TestHandler_Get(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -49,10 +54,10 @@ func TestHandler_Get(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProject(1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
+					service.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -66,10 +71,10 @@ func TestHandler_Get(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProject(1).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -80,6 +85,18 @@ func TestHandler_Get(t *testing.T) {
 			},
 			expected: expected{code: http.StatusBadRequest},
 		},
+		{
+			name: "invalid id",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/abc",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "id \"abc\" isn't a number\n"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,15 +105,19 @@ func TestHandler_Get(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{id}", h.Get)
+			router.HandleFunc("/projects/{id}", web.Handle(h.Get, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, recorder.Body.String())
+			}
 		})
 	}
 }
@@ -106,7 +127,7 @@ func TestHandler_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -130,31 +151,33 @@ func TestHandler_Create(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateProject(&dal.Project{ID: 1, Name: "one", Description: "success"}).Return(nil).Times(1)
+					service.EXPECT().CreateProject(gomock.Any(), &dal.Project{Name: "one", Description: "success"}).DoAndReturn(func(_ context.Context, project *dal.Project) error {
+						project.ID = 7
+						return nil
+					}).Times(1)
 					return service
 				}(),
 			},
 			args: args{
 				urlRequest: "/projects/",
 				body: dal.Project{
-					ID:          1,
 					Name:        "one",
 					Description: "success",
 				},
 				method: http.MethodPost,
 			},
-			expected: expected{code: http.StatusCreated},
+			expected: expected{code: http.StatusCreated, body: `{"id":7,"name":"one","description":"success"}`},
 		},
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateProject(&dal.Project{}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().CreateProject(gomock.Any(), &dal.Project{}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -173,15 +196,19 @@ func TestHandler_Create(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/", h.Create)
+			router.HandleFunc("/projects/", web.Handle(h.Create, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			if tt.expected.body != "" {
+				assert.JSONEq(t, tt.expected.body, recorder.Body.String())
+			}
 		})
 	}
 }
@@ -191,7 +218,7 @@ func TestHandler_Delete(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -215,10 +242,10 @@ func TestHandler_Delete(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteProject(1).Return(nil).Times(1)
+					service.EXPECT().DeleteProject(gomock.Any(), 1).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -232,10 +259,10 @@ func TestHandler_Delete(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteProject(1).Return(errors.New("failed")).Times(1)
+					service.EXPECT().DeleteProject(gomock.Any(), 1).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -254,12 +281,13 @@ func TestHandler_Delete(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{id}", h.Delete)
+			router.HandleFunc("/projects/{id}", web.Handle(h.Delete, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -272,7 +300,7 @@ func TestHandler_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -296,10 +324,10 @@ func TestHandler_Update(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateProject(&dal.Project{ID: 1, Name: "one", Description: "success"}).Return(nil).Times(1)
+					service.EXPECT().UpdateProject(gomock.Any(), &dal.Project{ID: 1, Name: "one", Description: "success"}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -317,10 +345,10 @@ func TestHandler_Update(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateProject(&dal.Project{ID: 1, Name: "one", Description: "failed"}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().UpdateProject(gomock.Any(), &dal.Project{ID: 1, Name: "one", Description: "failed"}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -339,12 +367,13 @@ func TestHandler_Update(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{id}", h.Update)
+			router.HandleFunc("/projects/{id}", web.Handle(h.Update, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -357,7 +386,7 @@ func TestHandler_GetAll(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -381,10 +410,10 @@ func TestHandler_GetAll(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProjects().Return([]dal.Project{}, nil).Times(1)
+					service.EXPECT().GetProjects(gomock.Any()).Return([]dal.Project{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -398,10 +427,10 @@ func TestHandler_GetAll(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetProjects().Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetProjects(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -420,14 +449,547 @@ func TestHandler_GetAll(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/", h.GetAll)
+			router.HandleFunc("/projects/", web.Handle(h.GetAll, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, tt.args.body)
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+		})
+	}
+}
+
+func TestHandler_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code        int
+		disposition string
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ExportProject(gomock.Any(), 1).Return(&dal.ProjectArchive{SchemaVersion: dal.ArchiveSchemaVersion}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/export",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusOK, disposition: `attachment; filename="project-1.json"`},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ExportProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/export",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{id}/export", web.Handle(h.Export, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, nil)
+			assert.NoError(t, err)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.disposition, recorder.Header().Get("Content-Disposition"))
+		})
+	}
+}
+
+func TestHandler_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		body       dal.ProjectArchive
+		method     string
+	}
+
+	type expected struct {
+		code int
+		body string
+	}
+
+	archive := dal.ProjectArchive{
+		SchemaVersion: dal.ArchiveSchemaVersion,
+		Project:       dal.ExtendedProjectEntities{Project: dal.Project{ID: 3, Name: "board"}},
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportProject(gomock.Any(), &archive).Return(&dal.ImportReport{Project: &dal.Project{ID: 7, Name: "board"}, Columns: 1}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/import",
+				body:       archive,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusCreated, body: `{"project":{"id":7,"name":"board","description":""},"columns":1,"tasks":0,"comments":0,"converted":null,"skipped":null}`},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportProject(gomock.Any(), &archive).Return(nil, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/import",
+				body:       archive,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "failed\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/import", web.Handle(h.Import, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			body, err := json.Marshal(tt.args.body)
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_ImportTrello(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		body       string
+		method     string
+	}
+
+	type expected struct {
+		code int
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportTrelloBoard(gomock.Any(), &trello.Board{Name: "board"}).Return(&dal.ImportReport{Project: &dal.Project{ID: 1}}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/import/trello",
+				body:       `{"name":"board"}`,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusCreated},
+		},
+		{
+			name: "invalid json",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/import/trello",
+				body:       `{"name":`,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportTrelloBoard(gomock.Any(), &trello.Board{Name: "board"}).Return(nil, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/import/trello",
+				body:       `{"name":"board"}`,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/import/trello", web.Handle(h.ImportTrello, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader([]byte(tt.args.body)))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+		})
+	}
+}
+
+func TestHandler_Render(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code        int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().RenderProject(gomock.Any(), 1, "md", gomock.Any()).DoAndReturn(func(_ context.Context, id int, format string, w io.Writer) (string, error) {
+						_, err := io.WriteString(w, "# board\n")
+						return "text/markdown; charset=utf-8", err
+					}).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/export.md",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusOK, contentType: "text/markdown; charset=utf-8", body: "# board\n"},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().RenderProject(gomock.Any(), 1, "pdf", gomock.Any()).Return("", errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/export.pdf",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, contentType: "text/plain; charset=utf-8", body: "failed\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{id}/export.{format}", web.Handle(h.Render, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, nil)
+			assert.NoError(t, err)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_CreateCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code     int
+		location string
+		body     string
+	}
+
+	created := time.Date(2021, time.October, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().CreateCalendarFeed(gomock.Any(), 1).Return(&dal.CalendarFeed{Token: "abc", ProjectID: 1, CreatedAt: created}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/calendar",
+				method:     http.MethodPost,
+			},
+			expected: expected{
+				code:     http.StatusCreated,
+				location: "/v1/calendar/abc.ics",
+				body:     `{"token":"abc","project_id":1,"created_at":"2021-10-01T09:00:00Z","url":"/v1/calendar/abc.ics"}`,
+			},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().CreateCalendarFeed(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/calendar",
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "failed\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{id}/calendar", web.Handle(h.CreateCalendarFeed, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, nil)
+			assert.NoError(t, err)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.location, recorder.Header().Get("Location"))
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_CalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code        int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "events",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().RenderCalendarFeed(gomock.Any(), "abc", calendar.ComponentEvent, gomock.Any()).DoAndReturn(func(_ context.Context, token, component string, w io.Writer) error {
+						_, err := io.WriteString(w, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+						return err
+					}).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/calendar/abc.ics",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusOK, contentType: calendar.ContentType, body: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"},
+		},
+		{
+			name: "todos",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().RenderCalendarFeed(gomock.Any(), "abc", calendar.ComponentTodo, gomock.Any()).Return(nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/calendar/abc.ics?component=vtodo",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusOK, contentType: calendar.ContentType},
+		},
+		{
+			name: "unknown component",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/calendar/abc.ics?component=vjournal",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, contentType: "text/plain; charset=utf-8", body: calendar.ErrUnknownComponent.Error() + "\n"},
+		},
+		{
+			name: "unknown token",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().RenderCalendarFeed(gomock.Any(), "abc", calendar.ComponentEvent, gomock.Any()).Return(calendar.ErrFeedNotFound).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/calendar/abc.ics",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusNotFound, contentType: "text/plain; charset=utf-8", body: "404 page not found\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/calendar/{token}.ics", web.Handle(h.CalendarFeed, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, nil)
+			assert.NoError(t, err)
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
 		})
 	}
 }
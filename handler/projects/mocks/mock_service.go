@@ -5,9 +5,12 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	dal "github.com/Boobuh/golang-school-project/dal"
+	trello "github.com/Boobuh/golang-school-project/service/trello"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,74 +37,163 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateCalendarFeed mocks base method.
+func (m *MockService) CreateCalendarFeed(arg0 context.Context, arg1 int) (*dal.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(*dal.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCalendarFeed indicates an expected call of CreateCalendarFeed.
+func (mr *MockServiceMockRecorder) CreateCalendarFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendarFeed", reflect.TypeOf((*MockService)(nil).CreateCalendarFeed), arg0, arg1)
+}
+
 // CreateProject mocks base method.
-func (m *MockService) CreateProject(arg0 *dal.Project) error {
+func (m *MockService) CreateProject(arg0 context.Context, arg1 *dal.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", arg0)
+	ret := m.ctrl.Call(m, "CreateProject", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateProject indicates an expected call of CreateProject.
-func (mr *MockServiceMockRecorder) CreateProject(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockService)(nil).CreateProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockService)(nil).CreateProject), arg0, arg1)
 }
 
 // DeleteProject mocks base method.
-func (m *MockService) DeleteProject(arg0 int) error {
+func (m *MockService) DeleteProject(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProject", arg0)
+	ret := m.ctrl.Call(m, "DeleteProject", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProject indicates an expected call of DeleteProject.
-func (mr *MockServiceMockRecorder) DeleteProject(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteProject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockService)(nil).DeleteProject), arg0, arg1)
+}
+
+// ExportProject mocks base method.
+func (m *MockService) ExportProject(arg0 context.Context, arg1 int) (*dal.ProjectArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProject", arg0, arg1)
+	ret0, _ := ret[0].(*dal.ProjectArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProject indicates an expected call of ExportProject.
+func (mr *MockServiceMockRecorder) ExportProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockService)(nil).DeleteProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProject", reflect.TypeOf((*MockService)(nil).ExportProject), arg0, arg1)
 }
 
 // GetProject mocks base method.
-func (m *MockService) GetProject(arg0 int) (*dal.ExtendedProjectEntities, error) {
+func (m *MockService) GetProject(arg0 context.Context, arg1 int) (*dal.ExtendedProjectEntities, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProject", arg0)
+	ret := m.ctrl.Call(m, "GetProject", arg0, arg1)
 	ret0, _ := ret[0].(*dal.ExtendedProjectEntities)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProject indicates an expected call of GetProject.
-func (mr *MockServiceMockRecorder) GetProject(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockService)(nil).GetProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockService)(nil).GetProject), arg0, arg1)
 }
 
 // GetProjects mocks base method.
-func (m *MockService) GetProjects() ([]dal.Project, error) {
+func (m *MockService) GetProjects(arg0 context.Context) ([]dal.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjects")
+	ret := m.ctrl.Call(m, "GetProjects", arg0)
 	ret0, _ := ret[0].([]dal.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProjects indicates an expected call of GetProjects.
-func (mr *MockServiceMockRecorder) GetProjects() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockService)(nil).GetProjects), arg0)
+}
+
+// ImportProject mocks base method.
+func (m *MockService) ImportProject(arg0 context.Context, arg1 *dal.ProjectArchive) (*dal.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProject", arg0, arg1)
+	ret0, _ := ret[0].(*dal.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProject indicates an expected call of ImportProject.
+func (mr *MockServiceMockRecorder) ImportProject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProject", reflect.TypeOf((*MockService)(nil).ImportProject), arg0, arg1)
+}
+
+// ImportTrelloBoard mocks base method.
+func (m *MockService) ImportTrelloBoard(arg0 context.Context, arg1 *trello.Board) (*dal.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTrelloBoard", arg0, arg1)
+	ret0, _ := ret[0].(*dal.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTrelloBoard indicates an expected call of ImportTrelloBoard.
+func (mr *MockServiceMockRecorder) ImportTrelloBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTrelloBoard", reflect.TypeOf((*MockService)(nil).ImportTrelloBoard), arg0, arg1)
+}
+
+// RenderCalendarFeed mocks base method.
+func (m *MockService) RenderCalendarFeed(arg0 context.Context, arg1, arg2 string, arg3 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderCalendarFeed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenderCalendarFeed indicates an expected call of RenderCalendarFeed.
+func (mr *MockServiceMockRecorder) RenderCalendarFeed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderCalendarFeed", reflect.TypeOf((*MockService)(nil).RenderCalendarFeed), arg0, arg1, arg2, arg3)
+}
+
+// RenderProject mocks base method.
+func (m *MockService) RenderProject(arg0 context.Context, arg1 int, arg2 string, arg3 io.Writer) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderProject", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderProject indicates an expected call of RenderProject.
+func (mr *MockServiceMockRecorder) RenderProject(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockService)(nil).GetProjects))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderProject", reflect.TypeOf((*MockService)(nil).RenderProject), arg0, arg1, arg2, arg3)
 }
 
 // UpdateProject mocks base method.
-func (m *MockService) UpdateProject(arg0 *dal.Project) error {
+func (m *MockService) UpdateProject(arg0 context.Context, arg1 *dal.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", arg0)
+	ret := m.ctrl.Call(m, "UpdateProject", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockServiceMockRecorder) UpdateProject(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateProject(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockService)(nil).UpdateProject), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockService)(nil).UpdateProject), arg0, arg1)
 }
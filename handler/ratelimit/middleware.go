@@ -0,0 +1,197 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	LimitHeader      = "RateLimit-Limit"
+	RemainingHeader  = "RateLimit-Remaining"
+	ResetHeader      = "RateLimit-Reset"
+	RetryAfterHeader = "Retry-After"
+
+	// Message is the body of the 429 response.
+	Message = "rate limit exceeded"
+	// PurgeInterval is how often refilled buckets are forgotten by PurgeIdle.
+	PurgeInterval = time.Minute
+)
+
+// Middleware rejects the requests of a client that has used up its token
+// bucket with 429. Clients are told apart by their API key, or by their IP
+// address when they send none, and get one bucket for reads, one for writes
+// and one for every route with a limit of its own.
+type Middleware struct {
+	logger logging.Logger
+	store  Store
+	now    func() time.Time
+
+	read              config.Limit
+	write             config.Limit
+	routes            map[string]config.Limit
+	apiKeys           map[string]bool
+	trustForwardedFor bool
+	exempt            map[string]bool
+	versions          []string
+}
+
+func NewMiddleware(cfg config.RateLimit, store Store, logger logging.Logger) *Middleware {
+	m := &Middleware{
+		logger:            logger,
+		store:             store,
+		now:               time.Now,
+		read:              cfg.Read,
+		write:             cfg.Write,
+		routes:            map[string]config.Limit{},
+		apiKeys:           map[string]bool{},
+		trustForwardedFor: cfg.TrustForwardedFor,
+		exempt:            map[string]bool{},
+	}
+	for _, route := range cfg.Routes {
+		m.routes[route.Method+" "+route.Route] = config.Limit{Rate: route.Rate, Burst: route.Burst}
+	}
+	for _, key := range cfg.APIKeys {
+		m.apiKeys[key] = true
+	}
+	return m
+}
+
+// Exempt lets every request to the given route templates through, e.g. the
+// probes of a load balancer that would otherwise share a bucket.
+func (m *Middleware) Exempt(routes ...string) *Middleware {
+	for _, route := range routes {
+		m.exempt[route] = true
+	}
+	return m
+}
+
+// Versions names the path prefixes of the API versions, e.g. /v1. Route limits
+// are written without them and apply to the route of every version, which
+// draws from the same bucket in all of them.
+func (m *Middleware) Versions(prefixes ...string) *Middleware {
+	m.versions = append(m.versions, prefixes...)
+	return m
+}
+
+//===========================================================================//
+
+// Wrap is a mux.MiddlewareFunc. When the store fails the request is let
+// through: an unavailable limiter shouldn't take the API down with it.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := m.route(r)
+		if m.exempt[route] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		policy, limit := m.policy(r.Method, route)
+		client := m.client(r)
+
+		decision, err := m.store.Take(r.Context(), client+" "+policy, limit, m.now())
+		if err != nil {
+			m.logger.Ctx(r.Context()).Error("error in rate limit - can't take a token", "client", client, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(LimitHeader, strconv.Itoa(decision.Limit))
+		w.Header().Set(RemainingHeader, strconv.Itoa(decision.Remaining))
+		w.Header().Set(ResetHeader, wholeSeconds(decision.Reset))
+		if !decision.Allowed {
+			m.logger.Ctx(r.Context()).Warn("rate limit exceeded", "client", client, "policy", policy)
+			w.Header().Set(RetryAfterHeader, wholeSeconds(decision.RetryAfter))
+			http.Error(w, Message, http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// policy names the bucket a request draws from and returns its limit.
+func (m *Middleware) policy(method, route string) (string, config.Limit) {
+	if limit, ok := m.routes[method+" "+route]; ok {
+		return method + " " + route, limit
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return "read", m.read
+	}
+	return "write", m.write
+}
+
+// client identifies the sender of r. API keys are hashed so that they don't
+// end up in the database or the logs.
+func (m *Middleware) client(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); m.apiKeys[key] {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:8])
+	}
+	if m.trustForwardedFor {
+		// the proxy appends the address it saw, earlier entries are the client's to forge
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return "ip:" + ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// route returns the template of the route r matched without its version
+// prefix.
+func (m *Middleware) route(r *http.Request) string {
+	template := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if t, err := route.GetPathTemplate(); err == nil {
+			template = t
+		}
+	}
+	for _, prefix := range m.versions {
+		if strings.HasPrefix(template, prefix+"/") {
+			return strings.TrimPrefix(template, prefix)
+		}
+	}
+	return template
+}
+
+func wholeSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+//---------------------------------------------------------------------------//
+
+// PurgeIdle forgets refilled buckets every interval until ctx is done. A
+// missing bucket counts as a full one, so purging only keeps the store small.
+func PurgeIdle(ctx context.Context, store Store, interval time.Duration, logger logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := store.Purge(ctx, now); err != nil {
+				logger.Error("error purging idle rate limit buckets", "error", err)
+			}
+		}
+	}
+}
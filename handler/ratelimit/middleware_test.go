@@ -0,0 +1,161 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// takeFunc is a Store answering Take with a function. A mock would have to
+// import this package for Decision, which a test in it can't.
+type takeFunc func(ctx context.Context, key string, limit config.Limit, now time.Time) (Decision, error)
+
+func (f takeFunc) Take(ctx context.Context, key string, limit config.Limit, now time.Time) (Decision, error) {
+	return f(ctx, key, limit, now)
+}
+
+func (f takeFunc) Purge(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+func TestMiddleware_Wrap(t *testing.T) {
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.RateLimit{
+		APIKeys:           []string{"secret"},
+		TrustForwardedFor: true,
+		Read:              config.Limit{Rate: 20, Burst: 40},
+		Write:             config.Limit{Rate: 5, Burst: 10},
+		Routes:            []config.RouteLimit{{Method: "POST", Route: "/projects/import", Rate: 0.2, Burst: 3}},
+	}
+	// the first 8 bytes of sha256("secret")
+	const keyClient = "key:2bb80d537b1da3e3"
+
+	type fields struct {
+		store Store
+	}
+	type args struct {
+		method string
+		path   string
+		header http.Header
+	}
+	type expected struct {
+		code         int
+		handlerCalls int
+		header       map[string]string
+	}
+
+	allowed := Decision{Allowed: true, Limit: 40, Remaining: 39, Reset: 50 * time.Millisecond}
+	takes := func(key string, limit config.Limit, decision Decision, err error) Store {
+		return takeFunc(func(_ context.Context, gotKey string, gotLimit config.Limit, gotNow time.Time) (Decision, error) {
+			assert.Equal(t, key, gotKey)
+			assert.Equal(t, limit, gotLimit)
+			assert.Equal(t, now, gotNow)
+			return decision, err
+		})
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name:   "read allowed",
+			fields: fields{store: takes("ip:192.0.2.1 read", cfg.Read, allowed, nil)},
+			args:   args{method: http.MethodGet, path: "/tasks/"},
+			expected: expected{code: http.StatusOK, handlerCalls: 1, header: map[string]string{
+				LimitHeader: "40", RemainingHeader: "39", ResetHeader: "1", RetryAfterHeader: "",
+			}},
+		},
+		{
+			name: "write rejected",
+			fields: fields{store: takes("ip:192.0.2.1 write", cfg.Write,
+				Decision{Limit: 10, RetryAfter: 150 * time.Millisecond, Reset: 2 * time.Second}, nil)},
+			args: args{method: http.MethodPost, path: "/projects/"},
+			expected: expected{code: http.StatusTooManyRequests, header: map[string]string{
+				LimitHeader: "10", RemainingHeader: "0", ResetHeader: "2", RetryAfterHeader: "1",
+			}},
+		},
+		{
+			name:     "route with a limit of its own",
+			fields:   fields{store: takes("ip:192.0.2.1 POST /projects/import", config.Limit{Rate: 0.2, Burst: 3}, allowed, nil)},
+			args:     args{method: http.MethodPost, path: "/projects/import"},
+			expected: expected{code: http.StatusOK, handlerCalls: 1},
+		},
+		{
+			name:     "route of an API version shares the bucket",
+			fields:   fields{store: takes("ip:192.0.2.1 POST /projects/import", config.Limit{Rate: 0.2, Burst: 3}, allowed, nil)},
+			args:     args{method: http.MethodPost, path: "/v1/projects/import"},
+			expected: expected{code: http.StatusOK, handlerCalls: 1},
+		},
+		{
+			name:   "known API key",
+			fields: fields{store: takes(keyClient+" read", cfg.Read, allowed, nil)},
+			args: args{method: http.MethodGet, path: "/tasks/", header: http.Header{
+				APIKeyHeader: {"secret"}, "X-Forwarded-For": {"198.51.100.7"},
+			}},
+			expected: expected{code: http.StatusOK, handlerCalls: 1},
+		},
+		{
+			name:   "unknown API key falls back to the address",
+			fields: fields{store: takes("ip:198.51.100.7 read", cfg.Read, allowed, nil)},
+			args: args{method: http.MethodGet, path: "/tasks/", header: http.Header{
+				APIKeyHeader: {"guess"}, "X-Forwarded-For": {"203.0.113.9, 198.51.100.7"},
+			}},
+			expected: expected{code: http.StatusOK, handlerCalls: 1},
+		},
+		{
+			name:     "exempt route",
+			fields:   fields{store: takes("", config.Limit{}, Decision{}, errors.New("exempt route reached the store"))},
+			args:     args{method: http.MethodGet, path: "/healthz"},
+			expected: expected{code: http.StatusOK, handlerCalls: 1, header: map[string]string{LimitHeader: ""}},
+		},
+		{
+			name:     "store error lets the request through",
+			fields:   fields{store: takes("ip:192.0.2.1 read", cfg.Read, Decision{}, errors.New("database is locked"))},
+			args:     args{method: http.MethodGet, path: "/tasks/"},
+			expected: expected{code: http.StatusOK, handlerCalls: 1, header: map[string]string{LimitHeader: ""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(cfg, tt.fields.store, logging.Nop()).Exempt("/healthz").Versions("/v1")
+			m.now = func() time.Time { return now }
+
+			calls := 0
+			next := func(w http.ResponseWriter, r *http.Request) { calls++ }
+			router := mux.NewRouter()
+			router.Use(m.Wrap)
+			router.HandleFunc("/healthz", next).Methods(http.MethodGet)
+			router.HandleFunc("/tasks/", next).Methods(http.MethodGet)
+			router.HandleFunc("/projects/", next).Methods(http.MethodPost)
+			router.HandleFunc("/projects/import", next).Methods(http.MethodPost)
+			router.HandleFunc("/v1/projects/import", next).Methods(http.MethodPost)
+
+			req := httptest.NewRequest(tt.args.method, tt.args.path, nil)
+			for name, values := range tt.args.header {
+				for _, value := range values {
+					req.Header.Add(name, value)
+				}
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected.code, rr.Code)
+			assert.Equal(t, tt.expected.handlerCalls, calls)
+			for name, value := range tt.expected.header {
+				assert.Equal(t, value, rr.Header().Get(name), name)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Decision is the outcome of taking a token from a client's bucket.
+type Decision struct {
+	Allowed bool
+	// Limit is the size of the bucket and Remaining the whole tokens left in it.
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a rejected client has to wait for the next token.
+	RetryAfter time.Duration
+	// Reset is how long it takes the bucket to refill completely.
+	Reset time.Duration
+}
+
+// Store keeps the token buckets of all clients. Take has to be atomic per key,
+// otherwise concurrent requests could spend the same token.
+type Store interface {
+	Take(ctx context.Context, key string, limit config.Limit, now time.Time) (Decision, error)
+	// Purge forgets the buckets that have refilled by now.
+	Purge(ctx context.Context, now time.Time) error
+}
+
+// NewStore returns the store named by config.RateLimit.Store.
+func NewStore(kind string, repo dal.Repository) Store {
+	if kind == config.RateLimitStoreDatabase {
+		return NewDatabaseStore(repo)
+	}
+	return NewMemoryStore()
+}
+
+//===========================================================================//
+
+type bucket struct {
+	tokens     float64
+	refilledAt time.Time
+	fullAt     time.Time
+}
+
+// take refills b for the time passed since it was last used and spends one
+// token if there is one. A nil bucket is a new client's, which starts full.
+func take(b *bucket, limit config.Limit, now time.Time) (bucket, Decision) {
+	burst := float64(limit.Burst)
+	tokens := burst
+	if b != nil {
+		tokens = math.Min(burst, b.tokens+now.Sub(b.refilledAt).Seconds()*limit.Rate)
+	}
+
+	decision := Decision{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	decision.Remaining = int(tokens)
+	decision.Reset = seconds((burst - tokens) / limit.Rate)
+
+	return bucket{tokens: tokens, refilledAt: now, fullAt: now.Add(decision.Reset)}, decision
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+//---------------------------------------------------------------------------//
+
+// MemoryStore keeps the buckets in the server process, so every server
+// behind a load balancer limits clients on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]bucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]bucket{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit config.Limit, now time.Time) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var current *bucket
+	if b, ok := s.buckets[key]; ok {
+		current = &b
+	}
+	next, decision := take(current, limit, now)
+	s.buckets[key] = next
+	return decision, nil
+}
+
+func (s *MemoryStore) Purge(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if !b.fullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
+
+//---------------------------------------------------------------------------//
+
+// DatabaseStore keeps the buckets in the database, so that servers sharing it
+// also share the limits of every client.
+type DatabaseStore struct {
+	repo dal.Repository
+
+	// mu spares the requests of this process from competing for the
+	// database lock, the transaction guards against other processes.
+	mu sync.Mutex
+}
+
+func NewDatabaseStore(repo dal.Repository) *DatabaseStore {
+	return &DatabaseStore{repo: repo}
+}
+
+func (s *DatabaseStore) Take(ctx context.Context, key string, limit config.Limit, now time.Time) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var decision Decision
+	err := s.repo.Transaction(ctx, func(repo dal.Repository) error {
+		stored, err := repo.GetRateLimitBucket(ctx, key)
+		if err != nil {
+			return err
+		}
+		var current *bucket
+		if stored != nil {
+			current = &bucket{tokens: stored.Tokens, refilledAt: stored.RefilledAt, fullAt: stored.FullAt}
+		}
+		var next bucket
+		next, decision = take(current, limit, now)
+		return repo.SaveRateLimitBucket(ctx, &dal.RateLimitBucket{
+			Key:        key,
+			Tokens:     next.tokens,
+			RefilledAt: next.refilledAt,
+			FullAt:     next.fullAt,
+		})
+	})
+	return decision, err
+}
+
+func (s *DatabaseStore) Purge(ctx context.Context, now time.Time) error {
+	return s.repo.DeleteFullRateLimitBuckets(ctx, now)
+}
@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	limit := config.Limit{Rate: 2, Burst: 3}
+
+	type take struct {
+		key   string
+		after time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		takes    []take
+		expected Decision
+	}{
+		{
+			name:     "new client starts with a full bucket",
+			takes:    []take{{key: "a"}},
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:     "burst used up",
+			takes:    []take{{key: "a"}, {key: "a"}, {key: "a"}, {key: "a"}},
+			expected: Decision{Allowed: false, Limit: 3, Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: 1500 * time.Millisecond},
+		},
+		{
+			name:     "tokens refill with the rate",
+			takes:    []take{{key: "a"}, {key: "a"}, {key: "a"}, {key: "a", after: 750 * time.Millisecond}},
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 0, Reset: 1250 * time.Millisecond},
+		},
+		{
+			name:     "refill stops at the burst",
+			takes:    []take{{key: "a"}, {key: "a", after: time.Hour}},
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:     "clients have buckets of their own",
+			takes:    []take{{key: "a"}, {key: "a"}, {key: "a"}, {key: "b"}},
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			now := start
+			var got Decision
+			for _, take := range tt.takes {
+				now = now.Add(take.after)
+				var err error
+				got, err = store.Take(context.Background(), take.key, limit, now)
+				if err != nil {
+					t.Fatalf("Take() error = %v", err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Take() got = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Purge(t *testing.T) {
+	start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.Take(context.Background(), "refilled", config.Limit{Rate: 10, Burst: 1}, start)
+	store.Take(context.Background(), "empty", config.Limit{Rate: 0.1, Burst: 1}, start)
+
+	if err := store.Purge(context.Background(), start.Add(time.Second)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if _, ok := store.buckets["refilled"]; ok {
+		t.Errorf("Purge() kept the refilled bucket")
+	}
+	if _, ok := store.buckets["empty"]; !ok {
+		t.Errorf("Purge() deleted the empty bucket")
+	}
+}
+
+func TestDatabaseStore_Take(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	limit := config.Limit{Rate: 1, Burst: 5}
+	dbErr := errors.New("database is locked")
+
+	type fields struct {
+		repo dal.Repository
+	}
+	type expected struct {
+		decision Decision
+		err      error
+	}
+
+	inTransaction := func(repo *mocks.MockRepository) {
+		repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(dal.Repository) error) error { return fn(repo) })
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		expected expected
+	}{
+		{
+			name: "new bucket",
+			fields: fields{
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetRateLimitBucket(gomock.Any(), "ip:10.0.0.1 read").Return(nil, nil)
+					repo.EXPECT().SaveRateLimitBucket(gomock.Any(), &dal.RateLimitBucket{
+						Key: "ip:10.0.0.1 read", Tokens: 4, RefilledAt: now, FullAt: now.Add(time.Second),
+					}).Return(nil)
+					return repo
+				}(),
+			},
+			expected: expected{decision: Decision{Allowed: true, Limit: 5, Remaining: 4, Reset: time.Second}},
+		},
+		{
+			name: "stored bucket is refilled",
+			fields: fields{
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetRateLimitBucket(gomock.Any(), "ip:10.0.0.1 read").Return(&dal.RateLimitBucket{
+						Key: "ip:10.0.0.1 read", Tokens: 0, RefilledAt: now.Add(-2 * time.Second), FullAt: now.Add(3 * time.Second),
+					}, nil)
+					repo.EXPECT().SaveRateLimitBucket(gomock.Any(), &dal.RateLimitBucket{
+						Key: "ip:10.0.0.1 read", Tokens: 1, RefilledAt: now, FullAt: now.Add(4 * time.Second),
+					}).Return(nil)
+					return repo
+				}(),
+			},
+			expected: expected{decision: Decision{Allowed: true, Limit: 5, Remaining: 1, Reset: 4 * time.Second}},
+		},
+		{
+			name: "database error",
+			fields: fields{
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetRateLimitBucket(gomock.Any(), "ip:10.0.0.1 read").Return(nil, dbErr)
+					return repo
+				}(),
+			},
+			expected: expected{err: dbErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewDatabaseStore(tt.fields.repo)
+			got, err := store.Take(context.Background(), "ip:10.0.0.1 read", limit, now)
+			if !errors.Is(err, tt.expected.err) {
+				t.Errorf("Take() error = %v, want %v", err, tt.expected.err)
+			}
+			if tt.expected.err == nil && !reflect.DeepEqual(got, tt.expected.decision) {
+				t.Errorf("Take() got = %+v, want %+v", got, tt.expected.decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const jsonContentType = "application/json"
+
+// Error is a request body that can't be decoded, Status is the response code
+// it calls for.
+type Error struct {
+	Status int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status returns the response code for an error returned by DecodeJSON, or by
+// anything else reading a body limited by BodyLimit: 413 for a body over the
+// limit, 415 for one that isn't JSON and 400 otherwise.
+func Status(err error) int {
+	var decodeErr *Error
+	switch {
+	case errors.As(err, &decodeErr):
+		return decodeErr.Status
+	case TooLarge(err):
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// TooLarge reports whether err comes from reading past the limit set by
+// http.MaxBytesReader, which doesn't export its error.
+func TooLarge(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "http: request body too large")
+}
+
+//===========================================================================//
+
+// DecodeJSON decodes the body of r into v. The body has to be declared as
+// application/json and hold exactly one JSON value without fields v doesn't
+// have, so that typos in field names don't go unnoticed.
+func DecodeJSON(r *http.Request, v interface{}) error {
+	return decodeJSON(r, v, true)
+}
+
+// DecodeJSONAllowUnknown is DecodeJSON for documents written by other
+// software, e.g. Trello exports, of which only some fields are read.
+func DecodeJSONAllowUnknown(r *http.Request, v interface{}) error {
+	return decodeJSON(r, v, false)
+}
+
+func decodeJSON(r *http.Request, v interface{}, strict bool) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jsonContentType {
+		return &Error{
+			Status: http.StatusUnsupportedMediaType,
+			Err:    fmt.Errorf("Content-Type must be %s, got %q", jsonContentType, r.Header.Get("Content-Type")),
+		}
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("request body is empty")
+		}
+		return &Error{Status: Status(err), Err: err}
+	}
+	// anything but white space after the value is rejected
+	if err := decoder.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		if TooLarge(err) {
+			return &Error{Status: http.StatusRequestEntityTooLarge, Err: err}
+		}
+		return &Error{Status: http.StatusBadRequest, Err: errors.New("request body must hold a single JSON value")}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type target struct {
+		Name string `json:"name"`
+	}
+	type args struct {
+		contentType  string
+		body         string
+		maxBytes     int64
+		allowUnknown bool
+	}
+	type expected struct {
+		value  target
+		status int
+		err    string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+	}{
+		{
+			name:     "valid",
+			args:     args{contentType: "application/json", body: `{"name":"one"}` + "\n"},
+			expected: expected{value: target{Name: "one"}},
+		},
+		{
+			name:     "charset parameter",
+			args:     args{contentType: "application/json; charset=utf-8", body: `{"name":"one"}`},
+			expected: expected{value: target{Name: "one"}},
+		},
+		{
+			name:     "missing content type",
+			args:     args{body: `{"name":"one"}`},
+			expected: expected{status: http.StatusUnsupportedMediaType, err: `Content-Type must be application/json, got ""`},
+		},
+		{
+			name:     "form",
+			args:     args{contentType: "application/x-www-form-urlencoded", body: "name=one"},
+			expected: expected{status: http.StatusUnsupportedMediaType, err: `Content-Type must be application/json, got "application/x-www-form-urlencoded"`},
+		},
+		{
+			name:     "unknown field",
+			args:     args{contentType: "application/json", body: `{"name":"one","nmae":"two"}`},
+			expected: expected{value: target{Name: "one"}, status: http.StatusBadRequest, err: `json: unknown field "nmae"`},
+		},
+		{
+			name:     "unknown field allowed",
+			args:     args{contentType: "application/json", body: `{"name":"one","nmae":"two"}`, allowUnknown: true},
+			expected: expected{value: target{Name: "one"}},
+		},
+		{
+			name:     "trailing value",
+			args:     args{contentType: "application/json", body: `{"name":"one"}{"name":"two"}`},
+			expected: expected{value: target{Name: "one"}, status: http.StatusBadRequest, err: "request body must hold a single JSON value"},
+		},
+		{
+			name:     "trailing garbage",
+			args:     args{contentType: "application/json", body: `{"name":"one"} x`},
+			expected: expected{value: target{Name: "one"}, status: http.StatusBadRequest, err: "request body must hold a single JSON value"},
+		},
+		{
+			name:     "empty body",
+			args:     args{contentType: "application/json"},
+			expected: expected{status: http.StatusBadRequest, err: "request body is empty"},
+		},
+		{
+			name:     "wrong type",
+			args:     args{contentType: "application/json", body: `{"name":1}`},
+			expected: expected{status: http.StatusBadRequest, err: "json: cannot unmarshal number into Go struct field target.name of type string"},
+		},
+		{
+			name:     "too large",
+			args:     args{contentType: "application/json", body: `{"name":"` + strings.Repeat("x", 100) + `"}`, maxBytes: 50},
+			expected: expected{status: http.StatusRequestEntityTooLarge, err: "http: request body too large"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/projects/", strings.NewReader(tt.args.body))
+			if tt.args.contentType != "" {
+				req.Header.Set("Content-Type", tt.args.contentType)
+			}
+			if tt.args.maxBytes > 0 {
+				req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, tt.args.maxBytes)
+			}
+
+			var got target
+			var err error
+			if tt.args.allowUnknown {
+				err = DecodeJSONAllowUnknown(req, &got)
+			} else {
+				err = DecodeJSON(req, &got)
+			}
+
+			assert.Equal(t, tt.expected.value, got)
+			if tt.expected.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected.err)
+			assert.Equal(t, tt.expected.status, Status(err))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+// BodyLimit caps the size of request bodies, with larger caps for the routes
+// that take whole documents, such as imports.
+type BodyLimit struct {
+	maxBytes int64
+	routes   map[string]int64
+	versions []string
+}
+
+func NewBodyLimit(cfg config.Body) *BodyLimit {
+	l := &BodyLimit{maxBytes: cfg.MaxBytes, routes: map[string]int64{}}
+	for _, route := range cfg.Routes {
+		l.routes[route.Method+" "+route.Route] = route.MaxBytes
+	}
+	return l
+}
+
+// Versions names the path prefixes of the API versions, e.g. /v1. Route caps
+// are written without them and apply to the route of every version.
+func (l *BodyLimit) Versions(prefixes ...string) *BodyLimit {
+	l.versions = append(l.versions, prefixes...)
+	return l
+}
+
+//===========================================================================//
+
+// Wrap is a mux.MiddlewareFunc. A body declared larger than the cap is
+// rejected with 413 right away; one sent without a length fails to read once
+// it passes the cap, see Status.
+func (l *BodyLimit) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxBytes := l.maxBytes
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				for _, prefix := range l.versions {
+					if strings.HasPrefix(template, prefix+"/") {
+						template = strings.TrimPrefix(template, prefix)
+						break
+					}
+				}
+				if routeMax, ok := l.routes[r.Method+" "+template]; ok {
+					maxBytes = routeMax
+				}
+			}
+		}
+		if r.ContentLength > maxBytes {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,97 @@
+package request
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+// unknownLength hides the length of a body, as a chunked request does.
+type unknownLength struct {
+	io.Reader
+}
+
+func TestBodyLimit_Wrap(t *testing.T) {
+	cfg := config.Body{
+		MaxBytes: 10,
+		Routes:   []config.RouteBodyLimit{{Method: http.MethodPost, Route: "/projects/import", MaxBytes: 100}},
+	}
+
+	type args struct {
+		path          string
+		body          string
+		unknownLength bool
+	}
+	type expected struct {
+		code int
+		body string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+	}{
+		{
+			name:     "within the cap",
+			args:     args{path: "/projects/", body: "0123456789"},
+			expected: expected{code: http.StatusOK, body: "read 10 bytes"},
+		},
+		{
+			name:     "declared over the cap",
+			args:     args{path: "/projects/", body: "0123456789x"},
+			expected: expected{code: http.StatusRequestEntityTooLarge, body: "request body too large\n"},
+		},
+		{
+			name:     "read over the cap",
+			args:     args{path: "/projects/", body: "0123456789x", unknownLength: true},
+			expected: expected{code: http.StatusRequestEntityTooLarge, body: "http: request body too large\n"},
+		},
+		{
+			name:     "route with a cap of its own",
+			args:     args{path: "/projects/import", body: strings.Repeat("x", 100)},
+			expected: expected{code: http.StatusOK, body: "read 100 bytes"},
+		},
+		{
+			name:     "route of an API version",
+			args:     args{path: "/v1/projects/import", body: strings.Repeat("x", 100)},
+			expected: expected{code: http.StatusOK, body: "read 100 bytes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			read := func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), Status(err))
+					return
+				}
+				fmt.Fprintf(w, "read %d bytes", len(body))
+			}
+			router := mux.NewRouter()
+			router.Use(NewBodyLimit(cfg).Versions("/v1").Wrap)
+			router.HandleFunc("/projects/", read).Methods(http.MethodPost)
+			router.HandleFunc("/projects/import", read).Methods(http.MethodPost)
+			router.HandleFunc("/v1/projects/import", read).Methods(http.MethodPost)
+
+			var body io.Reader = strings.NewReader(tt.args.body)
+			if tt.args.unknownLength {
+				body = unknownLength{body}
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.args.path, body)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected.code, rr.Code)
+			assert.Equal(t, tt.expected.body, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package requestlog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength bounds the IDs accepted from clients, longer or
+	// non-printable ones are replaced by a generated ID.
+	maxRequestIDLength = 128
+)
+
+// Middleware tags every request with an ID, taken from the X-Request-ID header
+// or generated, and logs one line per request with its status and latency.
+// The ID, method, route template and trace ID are stored in the request context
+// so that every line logged through Logger.Ctx while serving the request
+// carries them.
+type Middleware struct {
+	logger logging.Logger
+	now    func() time.Time
+}
+
+func NewMiddleware(logger logging.Logger) *Middleware {
+	return &Middleware{logger: logger, now: time.Now}
+}
+
+//===========================================================================//
+
+// Wrap is a mux.MiddlewareFunc. Routes are matched before it runs, so the
+// route template is known.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := m.now()
+
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		fields := []interface{}{"request_id", id, "method", r.Method}
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				fields = append(fields, "route", template)
+			}
+		} else {
+			fields = append(fields, "path", r.URL.Path)
+		}
+		if span := trace.SpanContextFromContext(r.Context()); span.IsValid() {
+			fields = append(fields, "trace_id", span.TraceID().String())
+		}
+		ctx := logging.WithFields(r.Context(), fields...)
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		status := recorder.status()
+		logger := m.logger.Ctx(ctx)
+		keysAndValues := []interface{}{"status", status, "latency", m.now().Sub(start), "bytes", recorder.bytes}
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("request completed", keysAndValues...)
+		case status >= http.StatusBadRequest:
+			logger.Warn("request completed", keysAndValues...)
+		default:
+			logger.Info("request completed", keysAndValues...)
+		}
+	})
+}
+
+//---------------------------------------------------------------------------//
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		// crypto/rand doesn't fail on supported platforms, an ID that isn't
+		// unique is still better than none
+		return "unknown"
+	}
+	return hex.EncodeToString(raw)
+}
+
+//---------------------------------------------------------------------------//
+
+type statusRecorder struct {
+	http.ResponseWriter
+	code  int
+	bytes int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) status() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
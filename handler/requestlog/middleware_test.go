@@ -0,0 +1,120 @@
+package requestlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("log line %q isn't JSON: %v", raw, err)
+		}
+		delete(line, "time")
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestMiddleware_Wrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		requestID string
+		wantID    string
+		wantLines []map[string]interface{}
+	}{
+		{
+			name:      "propagated request ID",
+			url:       "/projects/7",
+			requestID: "abc-123",
+			wantID:    "abc-123",
+			wantLines: []map[string]interface{}{
+				{"level": "debug", "msg": "handling", "request_id": "abc-123", "method": "GET", "route": "/projects/{id}"},
+				{"level": "warn", "msg": "request completed", "request_id": "abc-123", "method": "GET", "route": "/projects/{id}",
+					"status": float64(http.StatusTeapot), "latency": "25ms", "bytes": float64(6)},
+			},
+		},
+		{
+			name:      "unprintable request ID is replaced",
+			url:       "/projects/7",
+			requestID: "bad\nid",
+		},
+		{
+			name: "generated request ID",
+			url:  "/projects/7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, _ := logging.New(&buf, logging.LevelDebug, logging.FormatJSON)
+			m := NewMiddleware(logger)
+			start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+			calls := 0
+			m.now = func() time.Time {
+				calls++
+				return start.Add(time.Duration(calls-1) * 25 * time.Millisecond)
+			}
+
+			router := mux.NewRouter()
+			router.Use(m.Wrap)
+			router.HandleFunc("/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+				logger.Ctx(r.Context()).Debug("handling")
+				w.WriteHeader(http.StatusTeapot)
+				w.Write([]byte("teapot"))
+			})
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			router.ServeHTTP(recorder, req)
+
+			id := recorder.Header().Get(RequestIDHeader)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantID, id)
+			} else {
+				assert.Len(t, id, 32)
+				assert.NotEqual(t, tt.requestID, id)
+			}
+			lines := decodeLines(t, &buf)
+			if tt.wantLines != nil {
+				assert.Equal(t, tt.wantLines, lines)
+			}
+			for _, line := range lines {
+				assert.Equal(t, id, line["request_id"])
+			}
+		})
+	}
+}
+
+func TestMiddleware_UnmatchedRoute(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := logging.New(&buf, logging.LevelInfo, logging.FormatJSON)
+	m := NewMiddleware(logger)
+
+	recorder := httptest.NewRecorder()
+	m.Wrap(http.NotFoundHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+
+	lines := decodeLines(t, &buf)
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "/nowhere", lines[0]["path"])
+		assert.Equal(t, float64(http.StatusNotFound), lines[0]["status"])
+		assert.Equal(t, recorder.Header().Get(RequestIDHeader), lines[0]["request_id"])
+	}
+}
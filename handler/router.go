@@ -1,65 +1,144 @@
 package handler
 
 import (
-	"log"
 	"net/http"
+	"time"
 
+	"github.com/Boobuh/golang-school-project/config"
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
 
-	"github.com/Boobuh/golang-school-project/handler/columns"
-	"github.com/Boobuh/golang-school-project/handler/comments"
-	"github.com/Boobuh/golang-school-project/handler/projects"
-	"github.com/Boobuh/golang-school-project/handler/tasks"
-
-	columnsUseCase "github.com/Boobuh/golang-school-project/service/columns"
-	commentUseCase "github.com/Boobuh/golang-school-project/service/comments"
-	projectUseCase "github.com/Boobuh/golang-school-project/service/projects"
-	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
+	"github.com/Boobuh/golang-school-project/handler/deadline"
+	"github.com/Boobuh/golang-school-project/handler/deprecation"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/idempotency"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/handler/requestlog"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
-func NewRouter(repo dal.Repository, logger *log.Logger) *mux.Router {
-	router := mux.NewRouter()
-
-	projectService := projectUseCase.NewUseCase(repo, logger)
-	projectHandler := projects.NewHandler(projectService, logger)
-
-	router.HandleFunc("/projects/", projectHandler.GetAll).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{id}", projectHandler.Get).Methods(http.MethodGet)
-	router.HandleFunc("/projects/", projectHandler.Create).Methods(http.MethodPost)
-	router.HandleFunc("/projects/{id}", projectHandler.Delete).Methods(http.MethodDelete)
-	router.HandleFunc("/projects/{id}", projectHandler.Update).Methods(http.MethodPut)
+// Route is a handler registered for a method and a path template, along with
+// its description in the OpenAPI document.
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.Handler
+	Doc     openapi.Op
+}
 
-	columnService := columnsUseCase.NewUseCase(repo, logger)
-	columnHandler := columns.NewHandler(columnService, logger)
+// APIVersion is a set of API routes mounted under a path prefix. Every version
+// registers handlers of its own, so that a new one can change responses
+// without breaking the clients bound to the versions before it.
+type APIVersion struct {
+	// Prefix is the path the routes are mounted under, e.g. /v1.
+	Prefix string
+	// Routes are the routes of the version, their paths written without the
+	// prefix.
+	Routes []Route
+}
 
-	router.HandleFunc("/columns/", columnHandler.GetAllColumns).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/", columnHandler.GetAllByProjectID).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}", columnHandler.GetColumn).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/", columnHandler.CreateColumn).Methods(http.MethodPost)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}", columnHandler.DeleteColumn).Methods(http.MethodDelete)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}", columnHandler.UpdateColumn).Methods(http.MethodPut)
+// LegacyVersion is the version the routes from before versioning, without
+// a prefix, are deprecated aliases of.
+const LegacyVersion = "/v1"
 
-	taskService := taskUseCase.NewUseCase(repo, logger)
-	taskHandler := tasks.NewHandler(taskService, logger)
+// apiVersions lists the versions served side by side, oldest first.
+func apiVersions(repo dal.Repository, logger logging.Logger) []APIVersion {
+	idempotent := idempotency.NewMiddleware(repo, idempotency.DefaultTTL, logger)
+	return []APIVersion{
+		{Prefix: "/v1", Routes: v1Routes(repo, idempotent, logger)},
+	}
+}
 
-	router.HandleFunc("/tasks/", taskHandler.GetAllTasks).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", taskHandler.GetAllByColumnID).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", taskHandler.GetTask).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", taskHandler.CreateTask).Methods(http.MethodPost)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", taskHandler.DeleteTask).Methods(http.MethodDelete)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", taskHandler.UpdateTask).Methods(http.MethodPut)
+func NewRouter(cfg *config.Config, repo dal.Repository, limits ratelimit.Store, m *metrics.Metrics, probes *health.Handler, logger logging.Logger) *mux.Router {
+	router := mux.NewRouter()
+	versions := apiVersions(repo, logger)
+	prefixes := make([]string, 0, len(versions))
+	for _, version := range versions {
+		prefixes = append(prefixes, version.Prefix)
+	}
 
-	commentService := commentUseCase.NewUseCase(repo, logger)
-	commentHandler := comments.NewHandler(commentService, logger)
+	requestLog := requestlog.NewMiddleware(logger)
+	requestDeadline := deadline.NewMiddleware(time.Duration(cfg.Server.RequestTimeout))
+	// the server span comes first, so that the request log can name its trace
+	middleware := []mux.MiddlewareFunc{otelmux.Middleware(cfg.Tracing.ServiceName), requestLog.Wrap, m.Wrap}
+	if cfg.RateLimit.Enabled {
+		rateLimit := ratelimit.NewMiddleware(cfg.RateLimit, limits, logger).
+			Exempt("/metrics", "/healthz", "/readyz", "/version", "/openapi.json", "/docs").
+			Versions(prefixes...)
+		middleware = append(middleware, rateLimit.Wrap)
+	}
+	router.Use(append(middleware, request.NewBodyLimit(cfg.Body).Versions(prefixes...).Wrap, requestDeadline.Wrap)...)
+	// mux only runs middleware for matched routes
+	router.NotFoundHandler = requestLog.Wrap(m.Wrap(http.NotFoundHandler()))
+	router.MethodNotAllowedHandler = requestLog.Wrap(m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})))
 
-	router.HandleFunc("/comments/", commentHandler.GetAllComments).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", commentHandler.GetComment).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", commentHandler.GetAllByTaskID).Methods(http.MethodGet)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", commentHandler.CreateComment).Methods(http.MethodPost)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", commentHandler.DeleteComment).Methods(http.MethodDelete)
-	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", commentHandler.UpdateComment).Methods(http.MethodPut)
+	doc := openapi.NewDocument(openapi.Info{
+		Title: "Golang School Project",
+		Description: "Projects, columns, tasks and comments of a kanban board. The API is served under /v1; " +
+			"the routes without a prefix are deprecated aliases of /v1.",
+		Version: LegacyVersion[1:],
+	})
+	for _, route := range append(opsRoutes(m, probes, doc), graphqlRoutes(repo, logger)...) {
+		router.Handle(route.Path, route.Handler).Methods(route.Method)
+		doc.Add(route.Method, route.Path, route.Doc)
+	}
+	for _, version := range versions {
+		subrouter := router.PathPrefix(version.Prefix).Subrouter()
+		for _, route := range version.Routes {
+			subrouter.Handle(route.Path, route.Handler).Methods(route.Method)
+			doc.Add(route.Method, version.Prefix+route.Path, route.Doc)
+		}
+	}
+	if cfg.API.LegacyRoutes {
+		for _, version := range versions {
+			if version.Prefix == LegacyVersion {
+				legacy := router.NewRoute().Subrouter()
+				legacy.Use(deprecation.NewMiddleware(cfg.API, LegacyVersion).Wrap)
+				for _, route := range version.Routes {
+					legacy.Handle(route.Path, route.Handler).Methods(route.Method)
+				}
+			}
+		}
+	}
 
 	return router
 }
+
+// opsRoutes lists the routes serving operators and tooling rather than API
+// clients, which stay outside of the versions.
+func opsRoutes(m *metrics.Metrics, probes *health.Handler, doc *openapi.Document) []Route {
+	const tag = "operations"
+	return []Route{
+		{http.MethodGet, "/metrics", m.Handler(), openapi.Op{
+			ID: "getMetrics", Summary: "Prometheus metrics", Tag: tag,
+			Response: "", ResponseType: "text/plain",
+		}},
+		{http.MethodGet, "/healthz", http.HandlerFunc(probes.Healthz), openapi.Op{
+			ID: "getHealth", Summary: "Liveness probe", Tag: tag,
+			Response: health.Status{},
+		}},
+		{http.MethodGet, "/readyz", http.HandlerFunc(probes.Readyz), openapi.Op{
+			ID: "getReadiness", Summary: "Readiness probe, failing while a dependency is down", Tag: tag,
+			Also: []int{http.StatusServiceUnavailable}, Response: health.Status{},
+		}},
+		{http.MethodGet, "/version", http.HandlerFunc(probes.Version), openapi.Op{
+			ID: "getVersion", Summary: "Build of the running server", Tag: tag,
+			Response: health.BuildInfo{},
+		}},
+		{http.MethodGet, "/openapi.json", doc.Handler(), openapi.Op{
+			ID: "getOpenAPI", Summary: "This document", Tag: tag,
+			Response: map[string]interface{}{},
+		}},
+		{http.MethodGet, "/docs", http.HandlerFunc(openapi.UI), openapi.Op{
+			ID: "getDocs", Summary: "Browsable documentation of the API", Tag: tag,
+			Response: "", ResponseType: "text/html",
+		}},
+	}
+}
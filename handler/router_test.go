@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
+	"github.com/Boobuh/golang-school-project/tracing"
+)
+
+func TestNewRouter_Tracing(t *testing.T) {
+	cfg := config.Default()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(tracing.NewProvider(cfg.Tracing, sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop(), dal.QueryTracing())
+	defer repo.Close()
+	project, err := repo.CreateProject(context.Background(), &dal.Project{Name: "board"})
+	if err != nil {
+		t.Fatalf("can't create project: %v", err)
+	}
+	assert.NoError(t, repo.CreateColumn(context.Background(), &dal.Column{Name: "todo", ProjectID: project.ID}))
+	router := NewRouter(cfg, repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+
+	const traceID, parentID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	request := httptest.NewRequest(http.MethodGet, "/projects/1", nil)
+	request.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	spans := map[string][]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() == traceID {
+			spans[span.Name()] = append(spans[span.Name()], span)
+		}
+	}
+	if !assert.Len(t, spans["/projects/{id}"], 1, "server span") ||
+		!assert.Len(t, spans["projects.GetProject"], 1, "service span") {
+		return
+	}
+	server, service := spans["/projects/{id}"][0], spans["projects.GetProject"][0]
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, parentID, server.Parent().SpanID().String(), "server span continues the caller's trace")
+	assert.Equal(t, server.SpanContext().SpanID(), service.Parent().SpanID())
+
+	// the project, its columns and the tasks of its single column
+	queries := spans["gorm.query"]
+	if assert.Len(t, queries, 3) {
+		for _, query := range queries {
+			assert.Equal(t, service.SpanContext().SpanID(), query.Parent().SpanID())
+			assert.Equal(t, trace.SpanKindClient, query.SpanKind())
+		}
+		attributes := map[string]string{}
+		for _, attribute := range queries[0].Attributes() {
+			attributes[string(attribute.Key)] = attribute.Value.Emit()
+		}
+		assert.Equal(t, "projects", attributes["db.sql.table"])
+		assert.Contains(t, attributes["db.statement"], "SELECT * FROM `projects`")
+	}
+}
+
+func TestNewRouter_RequestBodies(t *testing.T) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	defer repo.Close()
+	router := NewRouter(config.Default(), repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+
+	// a valid project padded with white space to over the default 1 MiB cap
+	large := `{"name":"board"}` + strings.Repeat(" ", 1<<20)
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		code        int
+	}{
+		{name: "valid", path: "/projects/", contentType: "application/json", body: `{"name":"board"}`, code: http.StatusCreated},
+		{name: "not JSON", path: "/projects/", contentType: "text/plain", body: `{"name":"board"}`, code: http.StatusUnsupportedMediaType},
+		{name: "unknown field", path: "/projects/", contentType: "application/json", body: `{"nmae":"board"}`, code: http.StatusBadRequest},
+		{name: "trailing data", path: "/projects/", contentType: "application/json", body: `{"name":"board"}]`, code: http.StatusBadRequest},
+		{name: "over the cap", path: "/projects/", contentType: "application/json", body: large, code: http.StatusRequestEntityTooLarge},
+		{name: "import has a cap of its own", path: "/projects/import", contentType: "application/json", body: large, code: http.StatusBadRequest},
+		{name: "in every API version", path: "/v1/projects/import", contentType: "application/json", body: large, code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+			assert.Equal(t, tt.code, response.Code, response.Body.String())
+		})
+	}
+}
+
+func TestNewRouter_Versions(t *testing.T) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	defer repo.Close()
+	if _, err := repo.CreateProject(context.Background(), &dal.Project{Name: "board"}); err != nil {
+		t.Fatalf("can't create project: %v", err)
+	}
+	withoutLegacy := config.Default()
+	withoutLegacy.API.LegacyRoutes = false
+
+	type expected struct {
+		code        int
+		deprecation string
+		link        string
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		method   string
+		path     string
+		expected expected
+	}{
+		{
+			name:     "v1",
+			cfg:      config.Default(),
+			method:   http.MethodGet,
+			path:     "/v1/projects/1",
+			expected: expected{code: http.StatusOK},
+		},
+		{
+			name:     "legacy alias",
+			cfg:      config.Default(),
+			method:   http.MethodGet,
+			path:     "/projects/1",
+			expected: expected{code: http.StatusOK, deprecation: "@1792368000", link: `</v1/projects/1>; rel="successor-version"`},
+		},
+		{
+			name:     "legacy alias of a failing request",
+			cfg:      config.Default(),
+			method:   http.MethodGet,
+			path:     "/projects/abc",
+			expected: expected{code: http.StatusBadRequest, deprecation: "@1792368000", link: `</v1/projects/abc>; rel="successor-version"`},
+		},
+		{
+			name:     "unknown v1 route",
+			cfg:      config.Default(),
+			method:   http.MethodGet,
+			path:     "/v1/boards/",
+			expected: expected{code: http.StatusNotFound},
+		},
+		{
+			name:     "method not allowed in v1",
+			cfg:      config.Default(),
+			method:   http.MethodPatch,
+			path:     "/v1/projects/1",
+			expected: expected{code: http.StatusMethodNotAllowed},
+		},
+		{
+			name:     "legacy routes turned off",
+			cfg:      withoutLegacy,
+			method:   http.MethodGet,
+			path:     "/projects/1",
+			expected: expected{code: http.StatusNotFound},
+		},
+		{
+			name:     "probes aren't versioned",
+			cfg:      config.Default(),
+			method:   http.MethodGet,
+			path:     "/healthz",
+			expected: expected{code: http.StatusOK},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(tt.cfg, repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expected.code, response.Code, response.Body.String())
+			assert.Equal(t, tt.expected.deprecation, response.Header().Get("Deprecation"))
+			assert.Equal(t, tt.expected.link, response.Header().Get("Link"))
+			if tt.expected.deprecation != "" {
+				assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", response.Header().Get("Sunset"))
+			}
+		})
+	}
+}
+
+func TestNewRouter_OpenAPI(t *testing.T) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	defer repo.Close()
+	router := NewRouter(config.Default(), repo, ratelimit.NewMemoryStore(), metrics.New(), health.NewHandler(logging.Nop()), logging.Nop())
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	var doc openapi.Document
+	if err := json.Unmarshal(response.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("can't decode the document: %v", err)
+	}
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	routes := 0
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routes++
+			// the legacy routes are documented as the version they alias
+			if doc.Operation(method, path) == nil && doc.Operation(method, LegacyVersion+path) == nil {
+				t.Errorf("%s %s isn't documented", method, path)
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, routes)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/html; charset=utf-8", response.Header().Get("Content-Type"))
+}
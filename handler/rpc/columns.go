@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/Boobuh/golang-school-project/handler/columns"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+)
+
+// ColumnServer serves the Columns service with the service of the REST
+// handler.
+type ColumnServer struct {
+	pb.UnimplementedColumnsServer
+	service columns.Service
+}
+
+func NewColumnServer(service columns.Service) *ColumnServer {
+	return &ColumnServer{service: service}
+}
+
+//===========================================================================//
+
+func (s *ColumnServer) ListColumns(ctx context.Context, req *pb.ListColumnsRequest) (*pb.ListColumnsResponse, error) {
+	list, err := s.service.GetColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.ListColumnsResponse{}
+	for _, column := range list {
+		response.Columns = append(response.Columns, columnToPB(column))
+	}
+	return response, nil
+}
+
+func (s *ColumnServer) ListProjectColumns(ctx context.Context, req *pb.ListProjectColumnsRequest) (*pb.ListProjectColumnsResponse, error) {
+	list, err := s.service.GetAllByProjectID(ctx, int(req.GetProjectId()))
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.ListProjectColumnsResponse{}
+	for _, column := range list {
+		response.Columns = append(response.Columns, extendedColumnToPB(column))
+	}
+	return response, nil
+}
+
+func (s *ColumnServer) GetColumn(ctx context.Context, req *pb.GetColumnRequest) (*pb.ExtendedColumn, error) {
+	column, err := s.service.GetProjectColumn(ctx, int(req.GetProjectId()), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return extendedColumnToPB(*column), nil
+}
+
+func (s *ColumnServer) CreateColumn(ctx context.Context, req *pb.CreateColumnRequest) (*pb.Column, error) {
+	column := columnFromPB(req.GetColumn())
+	if err := s.service.CreateColumn(ctx, &column); err != nil {
+		return nil, err
+	}
+	return columnToPB(column), nil
+}
+
+func (s *ColumnServer) UpdateColumn(ctx context.Context, req *pb.UpdateColumnRequest) (*emptypb.Empty, error) {
+	column := columnFromPB(req.GetColumn())
+	if err := s.service.UpdateColumn(ctx, &column); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ColumnServer) DeleteColumn(ctx context.Context, req *pb.DeleteColumnRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteColumn(ctx, int(req.GetProjectId()), int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/comments"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+)
+
+// CommentServer serves the Comments service with the service of the REST
+// handler.
+type CommentServer struct {
+	pb.UnimplementedCommentsServer
+	service comments.Service
+}
+
+func NewCommentServer(service comments.Service) *CommentServer {
+	return &CommentServer{service: service}
+}
+
+//===========================================================================//
+
+func (s *CommentServer) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	list, err := s.service.GetComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return commentsToPB(list), nil
+}
+
+func (s *CommentServer) ListTaskComments(ctx context.Context, req *pb.ListTaskCommentsRequest) (*pb.ListCommentsResponse, error) {
+	list, err := s.service.GetAllByTaskID(ctx, int(req.GetTaskId()))
+	if err != nil {
+		return nil, err
+	}
+	return commentsToPB(list), nil
+}
+
+func (s *CommentServer) GetComment(ctx context.Context, req *pb.GetCommentRequest) (*pb.Comment, error) {
+	comment, err := s.service.GetComment(ctx, int(req.GetProjectId()), int(req.GetColumnId()), int(req.GetTaskId()), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return commentToPB(*comment), nil
+}
+
+func (s *CommentServer) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*emptypb.Empty, error) {
+	comment := commentFromPB(req.GetComment())
+	if err := s.service.CreateComment(ctx, &comment); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*emptypb.Empty, error) {
+	comment := commentFromPB(req.GetComment())
+	if err := s.service.UpdateComment(ctx, &comment); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	err := s.service.DeleteComment(ctx, int(req.GetProjectId()), int(req.GetColumnId()), int(req.GetTaskId()), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func commentsToPB(list []dal.Comment) *pb.ListCommentsResponse {
+	response := &pb.ListCommentsResponse{}
+	for _, comment := range list {
+		response.Comments = append(response.Comments, commentToPB(comment))
+	}
+	return response
+}
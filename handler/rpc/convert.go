@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+)
+
+// The messages mirror the dal models field by field; ids are int64 on the wire.
+
+func projectToPB(p dal.Project) *pb.Project {
+	return &pb.Project{Id: int64(p.ID), Name: p.Name, Description: p.Description}
+}
+
+func projectFromPB(p *pb.Project) dal.Project {
+	return dal.Project{ID: int(p.GetId()), Name: p.GetName(), Description: p.GetDescription()}
+}
+
+func columnToPB(c dal.Column) *pb.Column {
+	return &pb.Column{Id: int64(c.ID), Name: c.Name, ProjectId: int64(c.ProjectID), OrderNumber: int64(c.OrderNum), Status: c.Status}
+}
+
+func columnFromPB(c *pb.Column) dal.Column {
+	return dal.Column{ID: int(c.GetId()), Name: c.GetName(), ProjectID: int(c.GetProjectId()), OrderNum: int(c.GetOrderNumber()), Status: c.GetStatus()}
+}
+
+func taskToPB(t dal.Task) *pb.Task {
+	task := &pb.Task{Id: int64(t.ID), Name: t.Name, Status: t.Status, Description: t.Description, ColumnId: int64(t.ColumnID)}
+	if t.DueDate != nil {
+		task.DueDate = timestamppb.New(*t.DueDate)
+	}
+	return task
+}
+
+func taskFromPB(t *pb.Task) dal.Task {
+	task := dal.Task{ID: int(t.GetId()), Name: t.GetName(), Status: t.GetStatus(), Description: t.GetDescription(), ColumnID: int(t.GetColumnId())}
+	if t.GetDueDate() != nil {
+		due := t.GetDueDate().AsTime()
+		task.DueDate = &due
+	}
+	return task
+}
+
+func commentToPB(c dal.Comment) *pb.Comment {
+	return &pb.Comment{Id: int64(c.ID), Description: c.Description, TaskId: int64(c.TaskID)}
+}
+
+func commentFromPB(c *pb.Comment) dal.Comment {
+	return dal.Comment{ID: int(c.GetId()), Description: c.GetDescription(), TaskID: int(c.GetTaskId())}
+}
+
+//---------------------------------------------------------------------------//
+
+func extendedProjectToPB(p dal.ExtendedProjectEntities) *pb.ExtendedProject {
+	project := &pb.ExtendedProject{Project: projectToPB(p.Project)}
+	for _, column := range p.Columns {
+		project.Columns = append(project.Columns, extendedColumnToPB(column))
+	}
+	return project
+}
+
+func extendedProjectFromPB(p *pb.ExtendedProject) dal.ExtendedProjectEntities {
+	project := dal.ExtendedProjectEntities{Project: projectFromPB(p.GetProject())}
+	for _, column := range p.GetColumns() {
+		extended := dal.ExtendedColumn{Column: columnFromPB(column.GetColumn())}
+		for _, task := range column.GetTasks() {
+			extendedTask := dal.ExtendedTask{Task: taskFromPB(task.GetTask())}
+			for _, comment := range task.GetComments() {
+				extendedTask.Comments = append(extendedTask.Comments, commentFromPB(comment))
+			}
+			extended.Tasks = append(extended.Tasks, extendedTask)
+		}
+		project.Columns = append(project.Columns, extended)
+	}
+	return project
+}
+
+func extendedColumnToPB(c dal.ExtendedColumn) *pb.ExtendedColumn {
+	column := &pb.ExtendedColumn{Column: columnToPB(c.Column)}
+	for _, task := range c.Tasks {
+		column.Tasks = append(column.Tasks, extendedTaskToPB(task))
+	}
+	return column
+}
+
+func extendedTaskToPB(t dal.ExtendedTask) *pb.ExtendedTask {
+	task := &pb.ExtendedTask{Task: taskToPB(t.Task)}
+	for _, comment := range t.Comments {
+		task.Comments = append(task.Comments, commentToPB(comment))
+	}
+	return task
+}
+
+func timestampFromPB(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/projects"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+)
+
+// ProjectServer serves the Projects service with the service of the REST
+// handler.
+type ProjectServer struct {
+	pb.UnimplementedProjectsServer
+	service projects.Service
+}
+
+func NewProjectServer(service projects.Service) *ProjectServer {
+	return &ProjectServer{service: service}
+}
+
+//===========================================================================//
+
+func (s *ProjectServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	list, err := s.service.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.ListProjectsResponse{}
+	for _, project := range list {
+		response.Projects = append(response.Projects, projectToPB(project))
+	}
+	return response, nil
+}
+
+func (s *ProjectServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.ExtendedProject, error) {
+	project, err := s.service.GetProject(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return extendedProjectToPB(*project), nil
+}
+
+func (s *ProjectServer) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.Project, error) {
+	project := projectFromPB(req.GetProject())
+	if err := s.service.CreateProject(ctx, &project); err != nil {
+		return nil, err
+	}
+	return projectToPB(project), nil
+}
+
+func (s *ProjectServer) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*emptypb.Empty, error) {
+	project := projectFromPB(req.GetProject())
+	if err := s.service.UpdateProject(ctx, &project); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ProjectServer) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteProject(ctx, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (s *ProjectServer) ExportProject(ctx context.Context, req *pb.ExportProjectRequest) (*pb.ProjectArchive, error) {
+	archive, err := s.service.ExportProject(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProjectArchive{
+		SchemaVersion: int64(archive.SchemaVersion),
+		ExportedAt:    timestamppb.New(archive.ExportedAt),
+		Project:       extendedProjectToPB(archive.Project),
+	}, nil
+}
+
+func (s *ProjectServer) ImportProject(ctx context.Context, req *pb.ImportProjectRequest) (*pb.Project, error) {
+	archive := req.GetArchive()
+	report, err := s.service.ImportProject(ctx, &dal.ProjectArchive{
+		SchemaVersion: int(archive.GetSchemaVersion()),
+		ExportedAt:    timestampFromPB(archive.GetExportedAt()),
+		Project:       extendedProjectFromPB(archive.GetProject()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projectToPB(*report.Project), nil
+}
+
+func (s *ProjectServer) RenderProject(ctx context.Context, req *pb.RenderProjectRequest) (*pb.RenderProjectResponse, error) {
+	var content bytes.Buffer
+	contentType, err := s.service.RenderProject(ctx, int(req.GetId()), req.GetFormat(), &content)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RenderProjectResponse{ContentType: contentType, Content: content.Bytes()}, nil
+}
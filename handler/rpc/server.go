@@ -0,0 +1,124 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+	"github.com/Boobuh/golang-school-project/logging"
+
+	columnsUseCase "github.com/Boobuh/golang-school-project/service/columns"
+	commentUseCase "github.com/Boobuh/golang-school-project/service/comments"
+	projectUseCase "github.com/Boobuh/golang-school-project/service/projects"
+	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
+)
+
+// NewServer returns a gRPC server with the Projects, Columns, Tasks and
+// Comments services over repo, and reflection, so that tools like grpcurl
+// can list and call them. Every call gets timeout to finish, like requests
+// to the REST API.
+func NewServer(repo dal.Repository, timeout time.Duration, logger logging.Logger) *grpc.Server {
+	calls := &interceptor{logger: logger, timeout: timeout, now: time.Now}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(calls.unary))
+
+	pb.RegisterProjectsServer(srv, NewProjectServer(projectUseCase.NewUseCase(repo, logger)))
+	pb.RegisterColumnsServer(srv, NewColumnServer(columnsUseCase.NewUseCase(repo, logger)))
+	pb.RegisterTasksServer(srv, NewTaskServer(taskUseCase.NewUseCase(repo, logger)))
+	pb.RegisterCommentsServer(srv, NewCommentServer(commentUseCase.NewUseCase(repo, logger)))
+	reflection.Register(srv)
+	return srv
+}
+
+// Serve serves srv on listener until ctx is done, then stops it, letting
+// in-flight calls finish for at most shutdownTimeout. It has the signature of
+// a server.Server worker once the arguments are bound.
+func Serve(ctx context.Context, srv *grpc.Server, listener net.Listener, shutdownTimeout time.Duration, logger logging.Logger) {
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		logger.Error("grpc server stopped unexpectedly", "error", err)
+		return
+	case <-ctx.Done():
+	}
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		logger.Error("grpc calls still running after the shutdown timeout, cancelling them")
+		srv.Stop()
+	}
+	<-serveErr
+}
+
+//===========================================================================//
+
+// interceptor gives every call its deadline, turns the errors of the services
+// into statuses and logs one line per call, like the middleware of the REST
+// API.
+type interceptor struct {
+	logger  logging.Logger
+	timeout time.Duration
+	now     func() time.Time
+}
+
+func (i *interceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := i.now()
+	ctx = logging.WithFields(ctx, "grpc_method", info.FullMethod)
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	response, err := handler(ctx, req)
+	err = statusError(err)
+
+	code := status.Code(err)
+	logger := i.logger.Ctx(ctx)
+	keysAndValues := []interface{}{"code", code.String(), "latency", i.now().Sub(start)}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err)
+	}
+	switch code {
+	case codes.OK:
+		logger.Info("call completed", keysAndValues...)
+	case codes.Internal, codes.Unknown, codes.DeadlineExceeded, codes.Unavailable, codes.DataLoss:
+		logger.Error("call completed", keysAndValues...)
+	default:
+		logger.Warn("call completed", keysAndValues...)
+	}
+	return response, err
+}
+
+// statusError returns the status of err. Errors of the services answer with
+// InvalidArgument, as they answer with 400 over REST, except for records that
+// don't exist.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
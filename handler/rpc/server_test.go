@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// dial serves a new server over a temporary database on an in-process
+// listener and returns a connection to it.
+func dial(t *testing.T) *grpc.ClientConn {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	listener := bufconn.Listen(1 << 20)
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Serve(ctx, NewServer(repo, time.Second, logging.Nop()), listener, time.Second, logging.Nop())
+		close(done)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("can't dial: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		stop()
+		<-done
+		repo.Close()
+	})
+	return conn
+}
+
+func TestServer_Board(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+	projects, columns := pb.NewProjectsClient(conn), pb.NewColumnsClient(conn)
+	tasks, comments := pb.NewTasksClient(conn), pb.NewCommentsClient(conn)
+	due := time.Date(2026, time.November, 2, 10, 0, 0, 0, time.UTC)
+
+	project, err := projects.CreateProject(ctx, &pb.CreateProjectRequest{Project: &pb.Project{Name: "board"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), project.GetId())
+	column, err := columns.CreateColumn(ctx, &pb.CreateColumnRequest{Column: &pb.Column{Name: "doing", ProjectId: 1, OrderNumber: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), column.GetId())
+	_, err = tasks.CreateTask(ctx, &pb.CreateTaskRequest{Task: &pb.Task{Name: "write", ColumnId: 2, DueDate: timestamppb.New(due)}})
+	assert.NoError(t, err)
+	_, err = comments.CreateComment(ctx, &pb.CreateCommentRequest{Comment: &pb.Comment{Description: "first", TaskId: 1}})
+	assert.NoError(t, err)
+	_, err = comments.UpdateComment(ctx, &pb.UpdateCommentRequest{Comment: &pb.Comment{Id: 1, Description: "edited", TaskId: 1}})
+	assert.NoError(t, err)
+
+	board, err := projects.GetProject(ctx, &pb.GetProjectRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "board", board.GetProject().GetName())
+	if assert.Len(t, board.GetColumns(), 2) {
+		assert.Equal(t, "board_default", board.GetColumns()[0].GetColumn().GetName())
+		assert.Equal(t, int64(2), board.GetColumns()[1].GetColumn().GetOrderNumber())
+	}
+
+	task, err := tasks.GetTask(ctx, &pb.GetTaskRequest{ProjectId: 1, ColumnId: 2, Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), task.GetTask().GetColumnId())
+	if assert.Len(t, task.GetComments(), 1) {
+		assert.Equal(t, "edited", task.GetComments()[0].GetDescription())
+	}
+
+	batch, err := tasks.BatchTasks(ctx, &pb.BatchTasksRequest{ProjectId: 1, Mode: dal.BatchModePerItem, Operations: []*pb.TaskOperation{
+		{Op: dal.TaskOpCreate, Task: &pb.Task{Name: "batched", ColumnId: 2}},
+		{Op: dal.TaskOpDelete, Task: &pb.Task{Id: 999}},
+	}})
+	assert.NoError(t, err)
+	if assert.Len(t, batch.GetResults(), 2) {
+		assert.Equal(t, dal.TaskOpStatusOK, batch.GetResults()[0].GetStatus())
+		assert.Equal(t, dal.TaskOpStatusFailed, batch.GetResults()[1].GetStatus())
+	}
+
+	rendered, err := projects.RenderProject(ctx, &pb.RenderProjectRequest{Id: 1, Format: "md"})
+	assert.NoError(t, err)
+	assert.Contains(t, rendered.GetContentType(), "text/markdown")
+	assert.Contains(t, string(rendered.GetContent()), "board")
+
+	archive, err := projects.ExportProject(ctx, &pb.ExportProjectRequest{Id: 1})
+	assert.NoError(t, err)
+	archive.GetProject().GetProject().Name = "copy"
+	imported, err := projects.ImportProject(ctx, &pb.ImportProjectRequest{Archive: archive})
+	assert.NoError(t, err)
+	assert.Equal(t, "copy", imported.GetName())
+
+	_, err = comments.DeleteComment(ctx, &pb.DeleteCommentRequest{ProjectId: 1, ColumnId: 2, TaskId: 1, Id: 1})
+	assert.NoError(t, err)
+	list, err := comments.ListTaskComments(ctx, &pb.ListTaskCommentsRequest{ProjectId: 1, ColumnId: 2, TaskId: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, list.GetComments())
+}
+
+func TestServer_Errors(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+	projects, tasks := pb.NewProjectsClient(conn), pb.NewTasksClient(conn)
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{
+			name: "missing project",
+			call: func() error {
+				_, err := projects.GetProject(ctx, &pb.GetProjectRequest{Id: 42})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "invalid batch",
+			call: func() error {
+				_, err := tasks.BatchTasks(ctx, &pb.BatchTasksRequest{ProjectId: 1, Mode: "sometimes"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown format",
+			call: func() error {
+				_, err := projects.RenderProject(ctx, &pb.RenderProjectRequest{Id: 1, Format: "pdf"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(tt.call()))
+		})
+	}
+}
+
+func TestServer_Reflection(t *testing.T) {
+	conn := dial(t)
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("can't open the reflection stream: %v", err)
+	}
+	defer stream.CloseSend()
+	err = stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	})
+	assert.NoError(t, err)
+	response, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("can't list the services: %v", err)
+	}
+
+	var services []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	sort.Strings(services)
+	assert.Equal(t, []string{"board.v1.Columns", "board.v1.Comments", "board.v1.Projects", "board.v1.Tasks",
+		"grpc.reflection.v1alpha.ServerReflection"}, services)
+}
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/rpc/pb"
+	"github.com/Boobuh/golang-school-project/handler/tasks"
+)
+
+// TaskServer serves the Tasks service with the service of the REST handler.
+type TaskServer struct {
+	pb.UnimplementedTasksServer
+	service tasks.Service
+}
+
+func NewTaskServer(service tasks.Service) *TaskServer {
+	return &TaskServer{service: service}
+}
+
+//===========================================================================//
+
+func (s *TaskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	list, err := s.service.GetTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.ListTasksResponse{}
+	for _, task := range list {
+		response.Tasks = append(response.Tasks, taskToPB(task))
+	}
+	return response, nil
+}
+
+func (s *TaskServer) ListColumnTasks(ctx context.Context, req *pb.ListColumnTasksRequest) (*pb.ListColumnTasksResponse, error) {
+	list, err := s.service.GetAllByColumnID(ctx, int(req.GetColumnId()))
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.ListColumnTasksResponse{}
+	for _, task := range list {
+		response.Tasks = append(response.Tasks, extendedTaskToPB(task))
+	}
+	return response, nil
+}
+
+func (s *TaskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.ExtendedTask, error) {
+	task, err := s.service.GetTask(ctx, int(req.GetProjectId()), int(req.GetColumnId()), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return extendedTaskToPB(*task), nil
+}
+
+func (s *TaskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*emptypb.Empty, error) {
+	task := taskFromPB(req.GetTask())
+	if err := s.service.CreateTask(ctx, &task); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*emptypb.Empty, error) {
+	task := taskFromPB(req.GetTask())
+	if err := s.service.UpdateTask(ctx, &task); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteTask(ctx, int(req.GetProjectId()), int(req.GetColumnId()), int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// BatchTasks answers with the result of every operation. Unlike POST
+// tasks:batch, a rolled back atomic batch isn't an error: its results tell
+// which operation failed and that the others were rolled back.
+func (s *TaskServer) BatchTasks(ctx context.Context, req *pb.BatchTasksRequest) (*pb.BatchTasksResponse, error) {
+	batch := &dal.TaskBatch{Mode: req.GetMode()}
+	for _, operation := range req.GetOperations() {
+		batch.Operations = append(batch.Operations, dal.TaskOperation{Op: operation.GetOp(), Task: taskFromPB(operation.GetTask())})
+	}
+	results, err := s.service.Batch(ctx, int(req.GetProjectId()), batch)
+	if err != nil && results == nil {
+		return nil, err
+	}
+	response := &pb.BatchTasksResponse{}
+	for _, result := range results {
+		converted := &pb.TaskOperationResult{Index: int64(result.Index), Op: result.Op, Status: result.Status, Error: result.Error}
+		if result.Task != nil {
+			converted.Task = taskToPB(*result.Task)
+		}
+		response.Results = append(response.Results, converted)
+	}
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package security
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+// Middleware sets the response headers that keep browsers from sniffing
+// content types, framing responses or downgrading to plain HTTP.
+type Middleware struct {
+	hsts    string
+	csp     string
+	framing string
+}
+
+func NewMiddleware(cfg config.Security) *Middleware {
+	m := &Middleware{csp: "frame-ancestors " + strings.Join(cfg.FrameAncestors, " ")}
+	if cfg.HSTSMaxAge > 0 {
+		m.hsts = "max-age=" + strconv.Itoa(int(time.Duration(cfg.HSTSMaxAge).Seconds()))
+		if cfg.HSTSIncludeSubdomains {
+			m.hsts += "; includeSubDomains"
+		}
+	}
+	// X-Frame-Options is only understood by browsers without CSP support and
+	// can't name other origins
+	if len(cfg.FrameAncestors) == 1 {
+		switch cfg.FrameAncestors[0] {
+		case "'none'":
+			m.framing = "DENY"
+		case "'self'":
+			m.framing = "SAMEORIGIN"
+		}
+	}
+	return m
+}
+
+//===========================================================================//
+
+// Wrap wraps the whole router, so that 404 and 405 responses get the headers
+// too. Strict-Transport-Security is only sent over TLS, browsers ignore it on
+// plain HTTP responses.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Content-Security-Policy", m.csp)
+		header.Set("Referrer-Policy", "no-referrer")
+		if m.framing != "" {
+			header.Set("X-Frame-Options", m.framing)
+		}
+		if m.hsts != "" && r.TLS != nil {
+			header.Set("Strict-Transport-Security", m.hsts)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+func TestMiddleware_Wrap(t *testing.T) {
+	type args struct {
+		cfg config.Security
+		tls bool
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected map[string]string
+	}{
+		{
+			name: "defaults over TLS",
+			args: args{cfg: config.Default().Security, tls: true},
+			expected: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Content-Security-Policy":   "frame-ancestors 'none'",
+				"X-Frame-Options":           "DENY",
+				"Referrer-Policy":           "no-referrer",
+				"Strict-Transport-Security": "max-age=15552000",
+			},
+		},
+		{
+			name: "no HSTS over plain HTTP",
+			args: args{cfg: config.Default().Security},
+			expected: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Strict-Transport-Security": "",
+			},
+		},
+		{
+			name: "HSTS with subdomains",
+			args: args{cfg: config.Security{
+				HSTSMaxAge:            config.Duration(time.Hour),
+				HSTSIncludeSubdomains: true,
+				FrameAncestors:        []string{"'self'"},
+			}, tls: true},
+			expected: map[string]string{
+				"Strict-Transport-Security": "max-age=3600; includeSubDomains",
+				"X-Frame-Options":           "SAMEORIGIN",
+			},
+		},
+		{
+			name: "HSTS disabled",
+			args: args{cfg: config.Security{FrameAncestors: []string{"'self'", "https://board.example.com"}}, tls: true},
+			expected: map[string]string{
+				"Content-Security-Policy":   "frame-ancestors 'self' https://board.example.com",
+				"X-Frame-Options":           "",
+				"Strict-Transport-Security": "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMiddleware(tt.args.cfg).Wrap(http.NotFoundHandler())
+			req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+			if tt.args.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			for name, value := range tt.expected {
+				assert.Equal(t, value, rr.Header().Get(name), name)
+			}
+		})
+	}
+}
@@ -3,282 +3,233 @@ package tasks
 //go:generate   $GOPATH/bin/mockgen -package mocks -destination=mocks/mock_service.go -package=mocks github.com/Boobuh/golang-school-project/handler/tasks Service
 
 import (
-	"encoding/json"
-	"log"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
-	"strconv"
 
 	"github.com/Boobuh/golang-school-project/dal"
-	"github.com/gorilla/mux"
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
 type Service interface {
-	GetTasks() ([]dal.Task, error)
-	GetTask(projectID, columnID, taskID int) (*dal.ExtendedTask, error)
-	CreateTask(task *dal.Task) error
-	DeleteTask(projectID, columnID, taskID int) error
-	UpdateTask(task *dal.Task) error
-	GetAllByColumnID(columnID int) ([]dal.ExtendedTask, error)
+	GetTasks(ctx context.Context) ([]dal.Task, error)
+	GetTask(ctx context.Context, projectID, columnID, taskID int) (*dal.ExtendedTask, error)
+	CreateTask(ctx context.Context, task *dal.Task) error
+	DeleteTask(ctx context.Context, projectID, columnID, taskID int) error
+	UpdateTask(ctx context.Context, task *dal.Task) error
+	GetAllByColumnID(ctx context.Context, columnID int) ([]dal.ExtendedTask, error)
+	Batch(ctx context.Context, projectID int, batch *dal.TaskBatch) ([]dal.TaskOperationResult, error)
+	ExportCSV(ctx context.Context, projectID int, w io.Writer) error
+	ImportCSV(ctx context.Context, projectID int, r io.Reader, dryRun bool) (*dal.TaskImportReport, error)
 }
 
 type Handler struct {
-	logger  *log.Logger
+	logger  logging.Logger
 	service Service
 }
 
-func NewHandler(service Service, logger *log.Logger) *Handler {
+func NewHandler(service Service, logger logging.Logger) *Handler {
 	return &Handler{logger: logger, service: service}
 }
 
-func (h *Handler) GetAllTasks(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new get request")
+func (h *Handler) GetAllTasks(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new get request")
 
-	getTasks, err := h.service.GetTasks()
+	getTasks, err := h.service.GetTasks(r.Context())
 	if err != nil {
-		h.logger.Printf("error in GET getColumns call:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, err
 	}
-
-	payload, err := json.Marshal(getTasks)
-	if err != nil {
-		h.logger.Printf("error in GET getColumns call - can't marshal object from db:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, getTasks), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new GetTask request")
-
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "projectID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("projectID is missing in parameters")
-	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-	taskIdRaw, ok := vars["taskID"]
-	if !ok {
-		http.Error(w, "taskID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("taskID is missing in parameters")
-	}
-	taskID, err := strconv.Atoi(taskIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting taskID to int:%s", err.Error())
-		return
-	}
+func (h *Handler) GetTask(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new GetTask request")
 
-	task, err := h.service.GetTask(projectID, columnID, taskID)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in receiving task by id:%s", err.Error())
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	columnID := params.Int("columnID")
+	taskID := params.Int("taskID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(task)
+	task, err := h.service.GetTask(r.Context(), projectID, columnID, taskID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in GET task call - can't marshal object from db:%s", err.Error())
-		return
+		return nil, err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(http.StatusOK, task), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateTask(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new create task request")
 
-	h.logger.Print("new create task request")
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
+	params := web.Params(r)
+	columnID := params.Int("columnID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
-	}
-
 	var newTask dal.Task
-	err = json.NewDecoder(r.Body).Decode(&newTask)
-	if err != nil {
-		h.logger.Printf("error in POST column call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := request.DecodeJSON(r, &newTask); err != nil {
+		return nil, err
 	}
 	if newTask.ColumnID != columnID {
-		h.logger.Printf("error in POST task call - columnID mismatched")
-		w.WriteHeader(http.StatusBadRequest)
-		return
+		return nil, web.Errorf(http.StatusBadRequest, "column_id %d doesn't match the path", newTask.ColumnID)
 	}
-	err = h.service.CreateTask(&newTask)
-	if err != nil {
-		h.logger.Printf("error in CREATE task call - %s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	if err := h.service.CreateTask(r.Context(), &newTask); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusCreated)
+	return web.Empty(http.StatusCreated), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new delete task request")
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+func (h *Handler) DeleteTask(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new delete task request")
+
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	columnID := params.Int("columnID")
+	taskID := params.Int("taskID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	projectIdRaw, ok := vars["projectID"]
-	if !ok {
-		http.Error(w, "projectID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("projectID is missing in parameters")
+	if err := h.service.DeleteTask(r.Context(), projectID, columnID, taskID); err != nil {
+		return nil, err
 	}
-	projectID, err := strconv.Atoi(projectIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting projectID to int:%s", err.Error())
-		return
+	return web.Empty(http.StatusNoContent), nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) UpdateTask(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new UpdateTask request")
+
+	params := web.Params(r)
+	columnID := params.Int("columnID")
+	taskID := params.Int("taskID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	taskIdRaw, ok := vars["taskID"]
-	if !ok {
-		http.Error(w, "taskID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("taskID is missing in parameters")
+	var updatedTask dal.Task
+	if err := request.DecodeJSON(r, &updatedTask); err != nil {
+		return nil, err
 	}
-	taskID, err := strconv.Atoi(taskIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting taskID to int:%s", err.Error())
-		return
+	if updatedTask.ColumnID != columnID || updatedTask.ID != taskID {
+		return nil, web.Errorf(http.StatusBadRequest, "id %d or column_id %d doesn't match the path",
+			updatedTask.ID, updatedTask.ColumnID)
 	}
-
-	err = h.service.DeleteTask(projectID, columnID, taskID)
-	if err != nil {
-		h.logger.Printf("error in DELETE task call:%s", err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	if err := h.service.UpdateTask(r.Context(), &updatedTask); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusNoContent)
+	return web.Empty(http.StatusOK), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new UpdateTask request")
+func (h *Handler) GetAllByColumnID(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new GetAllByColumnID request")
 
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
+	params := web.Params(r)
+	columnID := params.Int("columnID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	columnID, err := strconv.Atoi(columnIDRaw)
+	tasks, err := h.service.GetAllByColumnID(r.Context(), columnID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+		return nil, err
 	}
-	taskIdRaw, ok := vars["taskID"]
-	if !ok {
-		http.Error(w, "taskID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("taskID is missing in parameters")
+	return web.JSON(http.StatusOK, tasks), nil
+}
+
+//---------------------------------------------------------------------------//
+
+func (h *Handler) Batch(r *http.Request) (*web.Response, error) {
+	logger := h.logger.Ctx(r.Context())
+	logger.Debug("new task batch request")
+
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	taskID, err := strconv.Atoi(taskIdRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting taskID to int:%s", err.Error())
-		return
+	var batch dal.TaskBatch
+	if err := request.DecodeJSON(r, &batch); err != nil {
+		return nil, err
 	}
-	var updatedTask dal.Task
-	err = json.NewDecoder(r.Body).Decode(&updatedTask)
-	if err != nil {
-		h.logger.Printf("error in PUT Task call - can't decode object from request:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+
+	results, batchErr := h.service.Batch(r.Context(), projectID, &batch)
+	if batchErr != nil && results == nil {
+		return nil, batchErr
 	}
 
-	if updatedTask.ColumnID != columnID || updatedTask.ID != taskID {
-		h.logger.Printf("error in PUT call columnID or taskID mismatched")
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	// A rolled back atomic batch is a failed request; a per_item batch with some
+	// failed operations partially succeeded.
+	status := http.StatusOK
+	if batchErr != nil {
+		logger.Error("error in task batch call", "error", batchErr)
+		status = http.StatusBadRequest
+	} else {
+		for _, result := range results {
+			if result.Status != dal.TaskOpStatusOK {
+				status = http.StatusMultiStatus
+				break
+			}
+		}
 	}
+	return web.JSON(status, results), nil
+}
 
-	err = h.service.UpdateTask(&updatedTask)
+//---------------------------------------------------------------------------//
 
-	if err != nil {
-		h.logger.Printf("error in UPDATE task call - can't marshal object from db:%s", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		return
+func (h *Handler) ExportCSV(r *http.Request) (*web.Response, error) {
+	h.logger.Ctx(r.Context()).Debug("new task csv export request")
+
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	w.WriteHeader(http.StatusOK)
+	var payload bytes.Buffer
+	if err := h.service.ExportCSV(r.Context(), projectID, &payload); err != nil {
+		return nil, err
+	}
+	return web.Raw(http.StatusOK, "text/csv; charset=utf-8", payload.Bytes()).
+		WithHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%d-tasks.csv"`, projectID)), nil
 }
 
 //---------------------------------------------------------------------------//
 
-func (h *Handler) GetAllByColumnID(w http.ResponseWriter, r *http.Request) {
-	h.logger.Print("new GetAllByProjectID request")
+func (h *Handler) ImportCSV(r *http.Request) (*web.Response, error) {
+	logger := h.logger.Ctx(r.Context())
+	logger.Debug("new task csv import request")
 
-	vars := mux.Vars(r)
-	columnIDRaw, ok := vars["columnID"]
-	if !ok {
-		http.Error(w, "columnID is missing in parameters", http.StatusBadRequest)
-		h.logger.Println("columnID is missing in parameters")
-	}
-	columnID, err := strconv.Atoi(columnIDRaw)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in converting id to int:%s", err.Error())
-		return
+	params := web.Params(r)
+	projectID := params.Int("projectID")
+	dryRun := params.QueryBool("dry_run")
+	if err := params.Err(); err != nil {
+		return nil, err
 	}
-	column, err := h.service.GetAllByColumnID(columnID)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in receiving tasks by columnID:%s", err.Error())
-		return
+
+	report, importErr := h.service.ImportCSV(r.Context(), projectID, r.Body, dryRun)
+	if importErr != nil && report == nil {
+		return nil, importErr
 	}
-	payload, err := json.Marshal(column)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		h.logger.Printf("error in GET projects call - can't marshal object from db:%s", err.Error())
-		return
+	status := http.StatusCreated
+	switch {
+	case importErr != nil:
+		logger.Error("error in task csv import call", "error", importErr)
+		status = request.Status(importErr)
+	case dryRun:
+		status = http.StatusOK
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(payload)
-	w.WriteHeader(http.StatusOK)
+	return web.JSON(status, report), nil
 }
 
 //---------------------------------------------------------------------------//
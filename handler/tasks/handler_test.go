@@ -2,19 +2,27 @@ package tasks
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/Boobuh/golang-school-project/dal"
 	"github.com/Boobuh/golang-school-project/handler/tasks/mocks"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+
+	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
 )
 
 func TestHandler_GetAllTasks(t *testing.T) {
@@ -22,7 +30,7 @@ func TestHandler_GetAllTasks(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -46,10 +54,10 @@ func TestHandler_GetAllTasks(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetTasks().Return([]dal.Task{}, nil).Times(1)
+					service.EXPECT().GetTasks(gomock.Any()).Return([]dal.Task{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -63,10 +71,10 @@ func TestHandler_GetAllTasks(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetTasks().Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetTasks(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -85,12 +93,13 @@ func TestHandler_GetAllTasks(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/tasks/", h.GetAllTasks)
+			router.HandleFunc("/tasks/", web.Handle(h.GetAllTasks, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -103,7 +112,7 @@ func TestHandler_GetTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -127,10 +136,10 @@ func TestHandler_GetTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetTask(1, 1, 1).Return(&dal.ExtendedTask{}, nil).Times(1)
+					service.EXPECT().GetTask(gomock.Any(), 1, 1, 1).Return(&dal.ExtendedTask{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -144,10 +153,10 @@ func TestHandler_GetTask(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetTask(0, 0, 0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetTask(gomock.Any(), 0, 0, 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -158,6 +167,18 @@ func TestHandler_GetTask(t *testing.T) {
 			},
 			expected: expected{code: http.StatusBadRequest},
 		},
+		{
+			name: "invalid task id",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/1/columns/1/tasks/abc",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, body: "taskID \"abc\" isn't a number\n"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -166,7 +187,7 @@ func TestHandler_GetTask(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", h.GetTask)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", web.Handle(h.GetTask, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			//body, err := json.Marshal(tt.args.body)
@@ -175,6 +196,9 @@ func TestHandler_GetTask(t *testing.T) {
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, recorder.Body.String())
+			}
 		})
 	}
 }
@@ -184,7 +208,7 @@ func TestHandler_CreateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -208,10 +232,10 @@ func TestHandler_CreateTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateTask(&dal.Task{ColumnID: 1}).Return(nil).Times(1)
+					service.EXPECT().CreateTask(gomock.Any(), &dal.Task{ColumnID: 1}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -227,10 +251,10 @@ func TestHandler_CreateTask(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().CreateTask(&dal.Task{ColumnID: 1}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().CreateTask(gomock.Any(), &dal.Task{ColumnID: 1}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -251,12 +275,13 @@ func TestHandler_CreateTask(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", h.CreateTask)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", web.Handle(h.CreateTask, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -264,12 +289,66 @@ func TestHandler_CreateTask(t *testing.T) {
 	}
 }
 
+// TestHandler_TaskRoundTrip stores tasks through the real service, so that
+// the fields a client sends are read back as they were sent.
+func TestHandler_TaskRoundTrip(t *testing.T) {
+	repo := dal.NewRepository(filepath.Join(t.TempDir(), "projects.db"), logging.Nop())
+	defer repo.Close()
+	ctx := context.Background()
+	_, err := repo.CreateProject(ctx, &dal.Project{Name: "board"})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateColumn(ctx, &dal.Column{Name: "doing", ProjectID: 1}))
+
+	h := NewHandler(taskUseCase.NewUseCase(repo, logging.Nop()), logging.Nop())
+	router := mux.NewRouter()
+	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", web.Handle(h.CreateTask, logging.Nop())).
+		Methods(http.MethodPost)
+	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", web.Handle(h.GetTask, logging.Nop())).
+		Methods(http.MethodGet)
+	router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", web.Handle(h.UpdateTask, logging.Nop())).
+		Methods(http.MethodPut)
+
+	steps := []struct {
+		method string
+		url    string
+		body   string
+		code   int
+		name   string
+		due    string
+	}{
+		{method: http.MethodPost, url: "/projects/1/columns/1/tasks/", code: http.StatusCreated,
+			body: `{"id":7,"name":"write","description":"the docs","column_id":1,"due_date":"2026-11-02T10:00:00Z"}`},
+		{method: http.MethodGet, url: "/projects/1/columns/1/tasks/1", code: http.StatusOK,
+			name: "write", due: "2026-11-02T10:00:00Z"},
+		{method: http.MethodPut, url: "/projects/1/columns/1/tasks/1", code: http.StatusOK,
+			body: `{"id":1,"name":"written","column_id":1,"due_date":"2026-11-03T10:00:00Z"}`},
+		{method: http.MethodGet, url: "/projects/1/columns/1/tasks/1", code: http.StatusOK,
+			name: "written", due: "2026-11-03T10:00:00Z"},
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, step.url, strings.NewReader(step.body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if !assert.Equal(t, step.code, recorder.Code, "%s %s: %s", step.method, step.url, recorder.Body) || step.name == "" {
+			continue
+		}
+		var task dal.ExtendedTask
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &task))
+		assert.Equal(t, step.name, task.Name)
+		if assert.NotNil(t, task.DueDate) {
+			assert.Equal(t, step.due, task.DueDate.Format(time.RFC3339))
+		}
+	}
+}
+
 func TestHandler_DeleteTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -293,10 +372,10 @@ func TestHandler_DeleteTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteTask(1, 1, 1).Return(nil).Times(1)
+					service.EXPECT().DeleteTask(gomock.Any(), 1, 1, 1).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -310,10 +389,10 @@ func TestHandler_DeleteTask(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().DeleteTask(0, 0, 0).Return(errors.New("failed")).Times(1)
+					service.EXPECT().DeleteTask(gomock.Any(), 0, 0, 0).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -332,12 +411,13 @@ func TestHandler_DeleteTask(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", h.DeleteTask)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", web.Handle(h.DeleteTask, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -350,7 +430,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -374,10 +454,10 @@ func TestHandler_UpdateTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateTask(&dal.Task{ID: 1, ColumnID: 1, Name: "one_default"}).Return(nil).Times(1)
+					service.EXPECT().UpdateTask(gomock.Any(), &dal.Task{ID: 1, ColumnID: 1, Name: "one_default"}).Return(nil).Times(1)
 					return service
 				}(),
 			},
@@ -395,10 +475,10 @@ func TestHandler_UpdateTask(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().UpdateTask(&dal.Task{ID: 0, ColumnID: 0, Name: "one"}).Return(errors.New("failed")).Times(1)
+					service.EXPECT().UpdateTask(gomock.Any(), &dal.Task{ID: 0, ColumnID: 0, Name: "one"}).Return(errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -421,12 +501,13 @@ func TestHandler_UpdateTask(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", h.UpdateTask)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/{taskID}", web.Handle(h.UpdateTask, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -439,7 +520,7 @@ func TestHandler_GetAllByColumnID(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		logger  *log.Logger
+		logger  logging.Logger
 		service Service
 	}
 	type args struct {
@@ -463,10 +544,10 @@ func TestHandler_GetAllByColumnID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByColumnID(1).Return([]dal.ExtendedTask{}, nil).Times(1)
+					service.EXPECT().GetAllByColumnID(gomock.Any(), 1).Return([]dal.ExtendedTask{}, nil).Times(1)
 					return service
 				}(),
 			},
@@ -480,10 +561,10 @@ func TestHandler_GetAllByColumnID(t *testing.T) {
 		{
 			name: "failed",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				service: func() Service {
 					service := mocks.NewMockService(ctrl)
-					service.EXPECT().GetAllByColumnID(0).Return(nil, errors.New("failed")).Times(1)
+					service.EXPECT().GetAllByColumnID(gomock.Any(), 0).Return(nil, errors.New("failed")).Times(1)
 					return service
 				}(),
 			},
@@ -502,12 +583,315 @@ func TestHandler_GetAllByColumnID(t *testing.T) {
 				service: tt.fields.service,
 			}
 			router := mux.NewRouter()
-			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", h.GetAllByColumnID)
+			router.HandleFunc("/projects/{projectID}/columns/{columnID}/tasks/", web.Handle(h.GetAllByColumnID, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			body, err := json.Marshal(tt.args.body)
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+		})
+	}
+}
+
+func TestHandler_Batch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		body       dal.TaskBatch
+		method     string
+	}
+
+	type expected struct {
+		code int
+	}
+
+	batch := dal.TaskBatch{Operations: []dal.TaskOperation{{Op: dal.TaskOpMove, Task: dal.Task{ID: 1, ColumnID: 2}}}}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().Batch(gomock.Any(), 1, &batch).Return([]dal.TaskOperationResult{{Op: dal.TaskOpMove, Status: dal.TaskOpStatusOK}}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks:batch",
+				body:       batch,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusOK},
+		},
+		{
+			name: "partially failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().Batch(gomock.Any(), 1, &batch).Return([]dal.TaskOperationResult{{Op: dal.TaskOpMove, Status: dal.TaskOpStatusFailed}}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks:batch",
+				body:       batch,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusMultiStatus},
+		},
+		{
+			name: "rolled back",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().Batch(gomock.Any(), 1, &batch).Return([]dal.TaskOperationResult{{Op: dal.TaskOpMove, Status: dal.TaskOpStatusFailed}}, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks:batch",
+				body:       batch,
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+		{
+			name: "invalid batch",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().Batch(gomock.Any(), 1, &dal.TaskBatch{}).Return(nil, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks:batch",
+				body:       dal.TaskBatch{},
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{projectID}/tasks:batch", web.Handle(h.Batch, tt.fields.logger))
 
 			recorder := httptest.NewRecorder()
 			body, err := json.Marshal(tt.args.body)
 			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader(body))
 			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+		})
+	}
+}
+
+func TestHandler_ExportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code        int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ExportCSV(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ context.Context, projectID int, w io.Writer) error {
+						_, err := io.WriteString(w, "project_id\n1\n")
+						return err
+					}).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusOK, contentType: "text/csv; charset=utf-8", body: "project_id\n1\n"},
+		},
+		{
+			name: "failed",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ExportCSV(gomock.Any(), 1, gomock.Any()).Return(errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv",
+				method:     http.MethodGet,
+			},
+			expected: expected{code: http.StatusBadRequest, contentType: "text/plain; charset=utf-8", body: "failed\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{projectID}/tasks.csv", web.Handle(h.ExportCSV, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, nil)
+			assert.NoError(t, err)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			assert.Equal(t, tt.expected.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_ImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		logger  logging.Logger
+		service Service
+	}
+	type args struct {
+		urlRequest string
+		method     string
+	}
+
+	type expected struct {
+		code int
+	}
+
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		expected expected
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportCSV(gomock.Any(), 1, gomock.Any(), false).Return(&dal.TaskImportReport{Created: 1}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv",
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusCreated},
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportCSV(gomock.Any(), 1, gomock.Any(), true).Return(&dal.TaskImportReport{DryRun: true, Created: 1}, nil).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv?dry_run=true",
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusOK},
+		},
+		{
+			name: "invalid rows",
+			fields: fields{
+				logger: logging.Nop(),
+				service: func() Service {
+					service := mocks.NewMockService(ctrl)
+					service.EXPECT().ImportCSV(gomock.Any(), 1, gomock.Any(), false).Return(&dal.TaskImportReport{Errors: []dal.TaskImportRowError{{Row: 2}}}, errors.New("failed")).Times(1)
+					return service
+				}(),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv",
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+		{
+			name: "invalid dry_run",
+			fields: fields{
+				logger:  logging.Nop(),
+				service: mocks.NewMockService(ctrl),
+			},
+			args: args{
+				urlRequest: "/projects/1/tasks.csv?dry_run=maybe",
+				method:     http.MethodPost,
+			},
+			expected: expected{code: http.StatusBadRequest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				logger:  tt.fields.logger,
+				service: tt.fields.service,
+			}
+			router := mux.NewRouter()
+			router.HandleFunc("/projects/{projectID}/tasks.csv", web.Handle(h.ImportCSV, tt.fields.logger))
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.args.method, tt.args.urlRequest, bytes.NewReader([]byte("column,name\ntodo,write\n")))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tt.expected.code, recorder.Code)
@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	dal "github.com/Boobuh/golang-school-project/dal"
@@ -34,89 +36,133 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockService) Batch(arg0 context.Context, arg1 int, arg2 *dal.TaskBatch) ([]dal.TaskOperationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dal.TaskOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockServiceMockRecorder) Batch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockService)(nil).Batch), arg0, arg1, arg2)
+}
+
 // CreateTask mocks base method.
-func (m *MockService) CreateTask(arg0 *dal.Task) error {
+func (m *MockService) CreateTask(arg0 context.Context, arg1 *dal.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", arg0)
+	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockServiceMockRecorder) CreateTask(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockService)(nil).CreateTask), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockService)(nil).CreateTask), arg0, arg1)
 }
 
 // DeleteTask mocks base method.
-func (m *MockService) DeleteTask(arg0, arg1, arg2 int) error {
+func (m *MockService) DeleteTask(arg0 context.Context, arg1, arg2, arg3 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockServiceMockRecorder) DeleteTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockService)(nil).DeleteTask), arg0, arg1, arg2, arg3)
+}
+
+// ExportCSV mocks base method.
+func (m *MockService) ExportCSV(arg0 context.Context, arg1 int, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCSV", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCSV indicates an expected call of ExportCSV.
+func (mr *MockServiceMockRecorder) ExportCSV(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockService)(nil).DeleteTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCSV", reflect.TypeOf((*MockService)(nil).ExportCSV), arg0, arg1, arg2)
 }
 
 // GetAllByColumnID mocks base method.
-func (m *MockService) GetAllByColumnID(arg0 int) ([]dal.ExtendedTask, error) {
+func (m *MockService) GetAllByColumnID(arg0 context.Context, arg1 int) ([]dal.ExtendedTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByColumnID", arg0)
+	ret := m.ctrl.Call(m, "GetAllByColumnID", arg0, arg1)
 	ret0, _ := ret[0].([]dal.ExtendedTask)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllByColumnID indicates an expected call of GetAllByColumnID.
-func (mr *MockServiceMockRecorder) GetAllByColumnID(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAllByColumnID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByColumnID", reflect.TypeOf((*MockService)(nil).GetAllByColumnID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByColumnID", reflect.TypeOf((*MockService)(nil).GetAllByColumnID), arg0, arg1)
 }
 
 // GetTask mocks base method.
-func (m *MockService) GetTask(arg0, arg1, arg2 int) (*dal.ExtendedTask, error) {
+func (m *MockService) GetTask(arg0 context.Context, arg1, arg2, arg3 int) (*dal.ExtendedTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*dal.ExtendedTask)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockServiceMockRecorder) GetTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockService)(nil).GetTask), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockService)(nil).GetTask), arg0, arg1, arg2, arg3)
 }
 
 // GetTasks mocks base method.
-func (m *MockService) GetTasks() ([]dal.Task, error) {
+func (m *MockService) GetTasks(arg0 context.Context) ([]dal.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks")
+	ret := m.ctrl.Call(m, "GetTasks", arg0)
 	ret0, _ := ret[0].([]dal.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockServiceMockRecorder) GetTasks() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockService)(nil).GetTasks), arg0)
+}
+
+// ImportCSV mocks base method.
+func (m *MockService) ImportCSV(arg0 context.Context, arg1 int, arg2 io.Reader, arg3 bool) (*dal.TaskImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCSV", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dal.TaskImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCSV indicates an expected call of ImportCSV.
+func (mr *MockServiceMockRecorder) ImportCSV(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockService)(nil).GetTasks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockService)(nil).ImportCSV), arg0, arg1, arg2, arg3)
 }
 
 // UpdateTask mocks base method.
-func (m *MockService) UpdateTask(arg0 *dal.Task) error {
+func (m *MockService) UpdateTask(arg0 context.Context, arg1 *dal.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", arg0)
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockServiceMockRecorder) UpdateTask(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockService)(nil).UpdateTask), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockService)(nil).UpdateTask), arg0, arg1)
 }
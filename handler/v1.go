@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/handler/columns"
+	"github.com/Boobuh/golang-school-project/handler/comments"
+	"github.com/Boobuh/golang-school-project/handler/idempotency"
+	"github.com/Boobuh/golang-school-project/handler/openapi"
+	"github.com/Boobuh/golang-school-project/handler/projects"
+	"github.com/Boobuh/golang-school-project/handler/tasks"
+	"github.com/Boobuh/golang-school-project/handler/web"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/service/trello"
+
+	columnsUseCase "github.com/Boobuh/golang-school-project/service/columns"
+	commentUseCase "github.com/Boobuh/golang-school-project/service/comments"
+	projectUseCase "github.com/Boobuh/golang-school-project/service/projects"
+	taskUseCase "github.com/Boobuh/golang-school-project/service/tasks"
+)
+
+const (
+	projectsTag = "projects"
+	columnsTag  = "columns"
+	tasksTag    = "tasks"
+	commentsTag = "comments"
+)
+
+// v1Routes returns the routes of the first API version, the ones served
+// without a prefix before versioning.
+func v1Routes(repo dal.Repository, idempotent *idempotency.Middleware, logger logging.Logger) []Route {
+	handle := func(fn web.HandlerFunc) http.HandlerFunc {
+		return web.Handle(fn, logger)
+	}
+
+	projectService := projectUseCase.NewUseCase(repo, logger)
+	projectHandler := projects.NewHandler(projectService, logger)
+
+	columnService := columnsUseCase.NewUseCase(repo, logger)
+	columnHandler := columns.NewHandler(columnService, logger)
+
+	taskService := taskUseCase.NewUseCase(repo, logger)
+	taskHandler := tasks.NewHandler(taskService, logger)
+
+	commentService := commentUseCase.NewUseCase(repo, logger)
+	commentHandler := comments.NewHandler(commentService, logger)
+
+	return []Route{
+		{http.MethodGet, "/projects/", handle(projectHandler.GetAll), openapi.Op{
+			ID: "listProjects", Summary: "List the projects", Tag: projectsTag,
+			Response: []dal.Project{},
+		}},
+		{http.MethodGet, "/projects/{id}", handle(projectHandler.Get), openapi.Op{
+			ID: "getProject", Summary: "Get a project with its columns, tasks and comments", Tag: projectsTag,
+			Response: dal.ExtendedProjectEntities{},
+		}},
+		{http.MethodPost, "/projects/", idempotent.Wrap(handle(projectHandler.Create)), openapi.Op{
+			ID: "createProject", Summary: "Create a project with a default column", Tag: projectsTag,
+			Body: dal.Project{}, Status: http.StatusCreated, Response: dal.Project{},
+		}},
+		{http.MethodDelete, "/projects/{id}", handle(projectHandler.Delete), openapi.Op{
+			ID: "deleteProject", Summary: "Delete a project with its columns, tasks and comments", Tag: projectsTag,
+			Status: http.StatusNoContent,
+		}},
+		{http.MethodPut, "/projects/{id}", handle(projectHandler.Update), openapi.Op{
+			ID: "updateProject", Summary: "Rename a project or change its description", Tag: projectsTag,
+			Body: dal.Project{}, Response: map[string]string{},
+		}},
+		{http.MethodGet, "/projects/{id}/export", handle(projectHandler.Export), openapi.Op{
+			ID: "exportProject", Summary: "Export a project as an archive the import takes", Tag: projectsTag,
+			Response: dal.ProjectArchive{},
+		}},
+		{http.MethodGet, "/projects/{id}/export.{format}", handle(projectHandler.Render), openapi.Op{
+			ID: "renderProject", Summary: "Render a project as a Markdown (md), Mermaid (mmd) or Graphviz (dot) document", Tag: projectsTag,
+			Response: "", ResponseTypes: []string{"text/markdown", "text/vnd.mermaid", "text/vnd.graphviz"},
+		}},
+		{http.MethodPost, "/projects/import", idempotent.Wrap(handle(projectHandler.Import)), openapi.Op{
+			ID: "importProject", Summary: "Import a project archive as a new project, renaming columns whose names are taken", Tag: projectsTag,
+			Body: dal.ProjectArchive{}, Status: http.StatusCreated, Response: dal.ImportReport{},
+		}},
+		{http.MethodPost, "/projects/import/trello", idempotent.Wrap(handle(projectHandler.ImportTrello)), openapi.Op{
+			ID: "importTrelloBoard", Summary: "Import a Trello board export as a new project", Tag: projectsTag,
+			Body: trello.Board{}, Status: http.StatusCreated, Response: dal.ImportReport{},
+		}},
+		{http.MethodPost, "/projects/{id}/calendar", idempotent.Wrap(handle(projectHandler.CreateCalendarFeed)), openapi.Op{
+			ID: "createCalendarFeed", Summary: "Issue a new calendar feed token for a project, revoking the previous one", Tag: projectsTag,
+			Status: http.StatusCreated, Response: dal.CalendarFeed{},
+		}},
+		{http.MethodGet, "/calendar/{token}.ics", handle(projectHandler.CalendarFeed), openapi.Op{
+			ID: "getCalendarFeed", Summary: "Download a calendar feed", Tag: projectsTag,
+			Query: []openapi.Parameter{{Name: "component", In: "query", Description: "vevent (the default) or vtodo",
+				Schema: &openapi.Schema{Type: "string", Enum: []string{"vevent", "vtodo"}}}},
+			Response: "", ResponseType: "text/calendar",
+		}},
+
+		{http.MethodGet, "/columns/", handle(columnHandler.GetAllColumns), openapi.Op{
+			ID: "listColumns", Summary: "List the columns of every project", Tag: columnsTag,
+			Response: []dal.Column{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/", handle(columnHandler.GetAllByProjectID), openapi.Op{
+			ID: "listProjectColumns", Summary: "List the columns of a project with their tasks", Tag: columnsTag,
+			Response: []dal.ExtendedColumn{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/{columnID}", handle(columnHandler.GetColumn), openapi.Op{
+			ID: "getColumn", Summary: "Get a column with its tasks", Tag: columnsTag,
+			Response: dal.ExtendedColumn{},
+		}},
+		{http.MethodPost, "/projects/{projectID}/columns/", idempotent.Wrap(handle(columnHandler.CreateColumn)), openapi.Op{
+			ID: "createColumn", Summary: "Create a column", Tag: columnsTag,
+			Body: dal.Column{}, Status: http.StatusCreated,
+		}},
+		{http.MethodDelete, "/projects/{projectID}/columns/{columnID}", handle(columnHandler.DeleteColumn), openapi.Op{
+			ID: "deleteColumn", Summary: "Delete a column; its tasks are kept", Tag: columnsTag,
+			Status: http.StatusNoContent,
+		}},
+		{http.MethodPut, "/projects/{projectID}/columns/{columnID}", handle(columnHandler.UpdateColumn), openapi.Op{
+			ID: "updateColumn", Summary: "Rename or move a column", Tag: columnsTag,
+			Body: dal.Column{},
+		}},
+
+		{http.MethodGet, "/tasks/", handle(taskHandler.GetAllTasks), openapi.Op{
+			ID: "listTasks", Summary: "List the tasks of every project", Tag: tasksTag,
+			Response: []dal.Task{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/{columnID}/tasks/", handle(taskHandler.GetAllByColumnID), openapi.Op{
+			ID: "listColumnTasks", Summary: "List the tasks of a column with their comments", Tag: tasksTag,
+			Response: []dal.ExtendedTask{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}", handle(taskHandler.GetTask), openapi.Op{
+			ID: "getTask", Summary: "Get a task with its comments", Tag: tasksTag,
+			Response: dal.ExtendedTask{},
+		}},
+		{http.MethodPost, "/projects/{projectID}/columns/{columnID}/tasks/", idempotent.Wrap(handle(taskHandler.CreateTask)), openapi.Op{
+			ID: "createTask", Summary: "Create a task", Tag: tasksTag,
+			Body: dal.Task{}, Status: http.StatusCreated,
+		}},
+		{http.MethodDelete, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}", handle(taskHandler.DeleteTask), openapi.Op{
+			ID: "deleteTask", Summary: "Delete a task; its comments are kept", Tag: tasksTag,
+			Status: http.StatusNoContent,
+		}},
+		{http.MethodPut, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}", handle(taskHandler.UpdateTask), openapi.Op{
+			ID: "updateTask", Summary: "Change a task", Tag: tasksTag,
+			Body: dal.Task{},
+		}},
+		{http.MethodPost, "/projects/{projectID}/tasks:batch", idempotent.Wrap(handle(taskHandler.Batch)), openapi.Op{
+			ID: "batchTasks", Summary: "Create, update, move and delete tasks in one request", Tag: tasksTag,
+			Body: dal.TaskBatch{}, Also: []int{http.StatusMultiStatus, http.StatusBadRequest}, Response: []dal.TaskOperationResult{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/tasks.csv", handle(taskHandler.ExportCSV), openapi.Op{
+			ID: "exportTasksCSV", Summary: "Export the tasks of a project as CSV", Tag: tasksTag,
+			Response: "", ResponseType: "text/csv",
+		}},
+		{http.MethodPost, "/projects/{projectID}/tasks.csv", idempotent.Wrap(handle(taskHandler.ImportCSV)), openapi.Op{
+			ID: "importTasksCSV", Summary: "Import tasks from CSV, creating missing columns", Tag: tasksTag,
+			Query: []openapi.Parameter{{Name: "dry_run", In: "query", Description: "check the rows without importing them",
+				Schema: &openapi.Schema{Type: "boolean"}}},
+			Body: "", BodyType: "text/csv",
+			Status: http.StatusCreated, Also: []int{http.StatusOK, http.StatusBadRequest}, Response: dal.TaskImportReport{},
+		}},
+
+		{http.MethodGet, "/comments/", handle(commentHandler.GetAllComments), openapi.Op{
+			ID: "listComments", Summary: "List the comments of every task", Tag: commentsTag,
+			Response: []dal.Comment{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", handle(commentHandler.GetComment), openapi.Op{
+			ID: "getComment", Summary: "Get a comment", Tag: commentsTag,
+			Response: dal.Comment{},
+		}},
+		{http.MethodGet, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", handle(commentHandler.GetAllByTaskID), openapi.Op{
+			ID: "listTaskComments", Summary: "List the comments of a task", Tag: commentsTag,
+			Response: []dal.Comment{},
+		}},
+		{http.MethodPost, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/", idempotent.Wrap(handle(commentHandler.CreateComment)), openapi.Op{
+			ID: "createComment", Summary: "Comment on a task", Tag: commentsTag,
+			Body: dal.Comment{}, Status: http.StatusCreated,
+		}},
+		{http.MethodDelete, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", handle(commentHandler.DeleteComment), openapi.Op{
+			ID: "deleteComment", Summary: "Delete a comment", Tag: commentsTag,
+			Status: http.StatusNoContent,
+		}},
+		{http.MethodPut, "/projects/{projectID}/columns/{columnID}/tasks/{taskID}/comments/{commentID}", handle(commentHandler.UpdateComment), openapi.Op{
+			ID: "updateComment", Summary: "Change a comment", Tag: commentsTag,
+			Body: dal.Comment{},
+		}},
+	}
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+const (
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json; charset=utf-8"
+)
+
+// HandlerFunc answers a request with a response or an error, Handle writes
+// either of them.
+type HandlerFunc func(r *http.Request) (*Response, error)
+
+// Response is written by Handle: JSON is marshalled into the body, Raw is
+// written as it is. A response without either has an empty body.
+type Response struct {
+	Status int
+	Header http.Header
+	JSON   interface{}
+	Raw    []byte
+}
+
+// JSON responds with v marshalled to JSON.
+func JSON(status int, v interface{}) *Response {
+	return &Response{Status: status, Header: http.Header{}, JSON: v}
+}
+
+// Raw responds with body as it is, e.g. a rendered document.
+func Raw(status int, contentType string, body []byte) *Response {
+	header := http.Header{}
+	header.Set(contentTypeHeader, contentType)
+	return &Response{Status: status, Header: header, Raw: body}
+}
+
+// Empty responds with status and no body.
+func Empty(status int) *Response {
+	return &Response{Status: status, Header: http.Header{}}
+}
+
+// WithHeader adds a header to the response.
+func (r *Response) WithHeader(name, value string) *Response {
+	r.Header.Set(name, value)
+	return r
+}
+
+//===========================================================================//
+
+// Error is an error that calls for a response status of its own.
+type Error struct {
+	Status int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns an Error with status and a formatted message.
+func Errorf(status int, format string, args ...interface{}) error {
+	return &Error{Status: status, Err: fmt.Errorf(format, args...)}
+}
+
+// ErrorStatus returns the response status for err. Errors without a status of
+// their own, most of them from the services, are answered with 400 as the API
+// always has; errors reading the body with the status request.Status picks.
+func ErrorStatus(err error) int {
+	var webErr *Error
+	if errors.As(err, &webErr) {
+		return webErr.Status
+	}
+	return request.Status(err)
+}
+
+//===========================================================================//
+
+// Handle adapts fn to an http.HandlerFunc. An error is written as a plain text
+// message with the status ErrorStatus picks and logged, at warn level when the
+// client is to blame for it.
+func Handle(fn HandlerFunc, logger logging.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := fn(r)
+		if err != nil {
+			writeError(w, r, err, logger)
+			return
+		}
+
+		body := response.Raw
+		if response.JSON != nil {
+			body, err = json.Marshal(response.JSON)
+			if err != nil {
+				writeError(w, r, Errorf(http.StatusInternalServerError, "can't marshal response: %w", err), logger)
+				return
+			}
+			w.Header().Set(contentTypeHeader, jsonContentType)
+		}
+		for name, values := range response.Header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(response.Status)
+		w.Write(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error, logger logging.Logger) {
+	status := ErrorStatus(err)
+	var webErr *Error
+	var requestErr *request.Error
+	if status >= http.StatusInternalServerError || !(errors.As(err, &webErr) || errors.As(err, &requestErr) || request.TooLarge(err)) {
+		logger.Ctx(r.Context()).Error("error handling request", "status", status, "error", err)
+	} else {
+		logger.Ctx(r.Context()).Warn("invalid request", "status", status, "error", err)
+	}
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,100 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/handler/request"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestHandle(t *testing.T) {
+	type expected struct {
+		code   int
+		header map[string]string
+		body   string
+	}
+
+	tests := []struct {
+		name     string
+		fn       HandlerFunc
+		expected expected
+	}{
+		{
+			name: "json",
+			fn: func(r *http.Request) (*Response, error) {
+				return JSON(http.StatusCreated, map[string]int{"id": 1}).WithHeader("Location", "/projects/1"), nil
+			},
+			expected: expected{
+				code:   http.StatusCreated,
+				header: map[string]string{"Content-Type": jsonContentType, "Location": "/projects/1"},
+				body:   `{"id":1}`,
+			},
+		},
+		{
+			name: "raw",
+			fn: func(r *http.Request) (*Response, error) {
+				return Raw(http.StatusOK, "text/csv; charset=utf-8", []byte("id\n1\n")), nil
+			},
+			expected: expected{
+				code:   http.StatusOK,
+				header: map[string]string{"Content-Type": "text/csv; charset=utf-8"},
+				body:   "id\n1\n",
+			},
+		},
+		{
+			name: "empty",
+			fn: func(r *http.Request) (*Response, error) {
+				return Empty(http.StatusNoContent), nil
+			},
+			expected: expected{code: http.StatusNoContent, header: map[string]string{"Content-Type": ""}},
+		},
+		{
+			name: "error with a status",
+			fn: func(r *http.Request) (*Response, error) {
+				return nil, Errorf(http.StatusNotFound, "project %d not found", 1)
+			},
+			expected: expected{code: http.StatusNotFound, body: "project 1 not found\n"},
+		},
+		{
+			name: "service error",
+			fn: func(r *http.Request) (*Response, error) {
+				return nil, errors.New("failed")
+			},
+			expected: expected{code: http.StatusBadRequest, body: "failed\n"},
+		},
+		{
+			name: "request error",
+			fn: func(r *http.Request) (*Response, error) {
+				return nil, &request.Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("content type must be application/json")}
+			},
+			expected: expected{code: http.StatusUnsupportedMediaType, body: "content type must be application/json\n"},
+		},
+		{
+			name: "unmarshalable response",
+			fn: func(r *http.Request) (*Response, error) {
+				return JSON(http.StatusOK, func() {}), nil
+			},
+			expected: expected{code: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			Handle(tt.fn, logging.Nop()).ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected.code, recorder.Code)
+			for name, value := range tt.expected.header {
+				assert.Equal(t, value, recorder.Header().Get(name), name)
+			}
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, recorder.Body.String())
+			}
+		})
+	}
+}
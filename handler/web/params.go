@@ -0,0 +1,70 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// Parameters reads path and query parameters of a request. The first parameter
+// that is missing or invalid is remembered and returned by Err, so that a
+// handler can read all of them before checking once:
+//
+//	params := web.Params(r)
+//	projectID := params.Int("projectID")
+//	columnID := params.Int("columnID")
+//	if err := params.Err(); err != nil {
+//		return nil, err
+//	}
+type Parameters struct {
+	r    *http.Request
+	vars map[string]string
+	err  error
+}
+
+// Params returns the parameters of r.
+func Params(r *http.Request) *Parameters {
+	return &Parameters{r: r, vars: mux.Vars(r)}
+}
+
+// Err is a 400 Error naming the first parameter that couldn't be read.
+func (p *Parameters) Err() error {
+	return p.err
+}
+
+// String returns the path parameter name.
+func (p *Parameters) String(name string) string {
+	value, ok := p.vars[name]
+	if !ok && p.err == nil {
+		p.err = Errorf(http.StatusBadRequest, "%s is missing in parameters", name)
+	}
+	return value
+}
+
+// Int returns the path parameter name as an int.
+func (p *Parameters) Int(name string) int {
+	raw := p.String(name)
+	if p.err != nil {
+		return 0
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		p.err = Errorf(http.StatusBadRequest, "%s %q isn't a number", name, raw)
+	}
+	return value
+}
+
+// QueryBool returns the query parameter name as a bool, false when it is
+// missing.
+func (p *Parameters) QueryBool(name string) bool {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil && p.err == nil {
+		p.err = Errorf(http.StatusBadRequest, "%s %q isn't true or false", name, raw)
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameters(t *testing.T) {
+	type expected struct {
+		projectID int
+		columnID  int
+		dryRun    bool
+		err       string
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		vars     map[string]string
+		expected expected
+	}{
+		{
+			name:     "valid",
+			url:      "/?dry_run=true",
+			vars:     map[string]string{"projectID": "1", "columnID": "2"},
+			expected: expected{projectID: 1, columnID: 2, dryRun: true},
+		},
+		{
+			name:     "missing",
+			url:      "/",
+			vars:     map[string]string{"projectID": "1"},
+			expected: expected{projectID: 1, err: "columnID is missing in parameters"},
+		},
+		{
+			name:     "not a number",
+			url:      "/",
+			vars:     map[string]string{"projectID": "abc", "columnID": "x"},
+			expected: expected{err: `projectID "abc" isn't a number`},
+		},
+		{
+			name:     "not a bool",
+			url:      "/?dry_run=maybe",
+			vars:     map[string]string{"projectID": "1", "columnID": "2"},
+			expected: expected{projectID: 1, columnID: 2, err: `dry_run "maybe" isn't true or false`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, tt.url, nil), tt.vars)
+			params := Params(req)
+			projectID := params.Int("projectID")
+			columnID := params.Int("columnID")
+			dryRun := params.QueryBool("dry_run")
+
+			assert.Equal(t, tt.expected.projectID, projectID)
+			assert.Equal(t, tt.expected.columnID, columnID)
+			assert.Equal(t, tt.expected.dryRun, dryRun)
+			if tt.expected.err == "" {
+				assert.NoError(t, params.Err())
+				return
+			}
+			assert.EqualError(t, params.Err(), tt.expected.err)
+			assert.Equal(t, http.StatusBadRequest, ErrorStatus(params.Err()))
+		})
+	}
+}
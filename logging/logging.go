@@ -0,0 +1,271 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger writes leveled, structured log lines. Every method takes a message
+// followed by alternating keys and values:
+//
+//	logger.Error("can't save task", "task_id", task.ID, "error", err)
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+	// With returns a logger that adds the given fields to every line.
+	With(keysAndValues ...interface{}) Logger
+	// Ctx returns a logger that adds the fields stored in ctx by WithFields,
+	// such as the request ID, to every line.
+	Ctx(ctx context.Context) Logger
+}
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{LevelDebug: "debug", LevelInfo: "info", LevelWarn: "warn", LevelError: "error"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+func ParseLevel(s string) (Level, error) {
+	for level, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", s)
+}
+
+// Formats a logger can write lines in.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+//=======================================================================================//
+
+type fieldsKey struct{}
+
+// WithFields returns a context carrying fields that Logger.Ctx adds to log
+// lines, on top of the fields already in ctx.
+func WithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields := append(fieldsFromContext(ctx), keysAndValues...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+func fieldsFromContext(ctx context.Context) []interface{} {
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	// copied so that contexts derived from the same parent don't share an array
+	return append([]interface{}(nil), fields...)
+}
+
+//=======================================================================================//
+
+// New returns a logger writing lines of at least level to w, either as one JSON
+// object per line or as "time level message key=value" text.
+func New(w io.Writer, level Level, format string) (Logger, error) {
+	if format != FormatJSON && format != FormatText {
+		return nil, fmt.Errorf("unknown log format %q, expected json or text", format)
+	}
+	return &logger{out: &output{w: w, now: time.Now}, level: level, text: format == FormatText}, nil
+}
+
+// Nop returns a logger that discards everything, for tests and tools.
+func Nop() Logger {
+	return &logger{out: &output{w: io.Discard, now: time.Now}, level: LevelError + 1}
+}
+
+// output is shared by a logger and everything derived from it through With
+// and Ctx, so that concurrent lines don't interleave.
+type output struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+type logger struct {
+	out    *output
+	level  Level
+	text   bool
+	fields []interface{}
+}
+
+func (l *logger) Debug(msg string, keysAndValues ...interface{}) {
+	l.write(LevelDebug, msg, keysAndValues)
+}
+
+func (l *logger) Info(msg string, keysAndValues ...interface{}) {
+	l.write(LevelInfo, msg, keysAndValues)
+}
+
+func (l *logger) Warn(msg string, keysAndValues ...interface{}) {
+	l.write(LevelWarn, msg, keysAndValues)
+}
+
+func (l *logger) Error(msg string, keysAndValues ...interface{}) {
+	l.write(LevelError, msg, keysAndValues)
+}
+
+func (l *logger) With(keysAndValues ...interface{}) Logger {
+	child := *l
+	child.fields = append(append([]interface{}(nil), l.fields...), keysAndValues...)
+	return &child
+}
+
+func (l *logger) Ctx(ctx context.Context) Logger {
+	fields := fieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
+//---------------------------------------------------------------------------//
+
+func (l *logger) write(level Level, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+	fields := append(append([]interface{}(nil), l.fields...), keysAndValues...)
+
+	var line bytes.Buffer
+	if l.text {
+		l.formatText(&line, level, msg, fields)
+	} else {
+		l.formatJSON(&line, level, msg, fields)
+	}
+	line.WriteByte('\n')
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	l.out.w.Write(line.Bytes())
+}
+
+func (l *logger) formatJSON(buf *bytes.Buffer, level Level, msg string, fields []interface{}) {
+	buf.WriteString(`{"time":`)
+	writeJSON(buf, l.out.now().UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSON(buf, level.String())
+	buf.WriteString(`,"msg":`)
+	writeJSON(buf, msg)
+	for _, field := range pairs(fields) {
+		buf.WriteByte(',')
+		writeJSON(buf, field.key)
+		buf.WriteByte(':')
+		writeJSON(buf, field.value)
+	}
+	buf.WriteByte('}')
+}
+
+func (l *logger) formatText(buf *bytes.Buffer, level Level, msg string, fields []interface{}) {
+	buf.WriteString(l.out.now().Format("2006-01-02T15:04:05.000Z07:00"))
+	buf.WriteByte(' ')
+	buf.WriteString(strings.ToUpper(level.String()))
+	buf.WriteByte(' ')
+	buf.WriteString(msg)
+	for _, field := range pairs(fields) {
+		buf.WriteByte(' ')
+		buf.WriteString(field.key)
+		buf.WriteByte('=')
+		value := fmt.Sprint(field.value)
+		if strings.ContainsAny(value, " \t\n\"=") || value == "" {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(value)
+	}
+}
+
+//---------------------------------------------------------------------------//
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// pairs turns alternating keys and values into fields. Later fields replace
+// earlier ones with the same key, so a line never has duplicate keys; a
+// trailing key without a value is kept under "!BADKEY".
+func pairs(keysAndValues []interface{}) []field {
+	var fields []field
+	index := map[string]int{}
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		var value interface{}
+		switch {
+		case i+1 >= len(keysAndValues):
+			key, value = "!BADKEY", keysAndValues[i]
+		case !ok:
+			key, value = "!BADKEY", fmt.Sprintf("%v=%v", keysAndValues[i], keysAndValues[i+1])
+		default:
+			value = keysAndValues[i+1]
+		}
+		switch v := value.(type) {
+		case error:
+			value = v.Error()
+		case fmt.Stringer:
+			value = v.String()
+		}
+		if j, ok := index[key]; ok {
+			fields[j].value = value
+			continue
+		}
+		index[key] = len(fields)
+		fields = append(fields, field{key: key, value: value})
+	}
+	return fields
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprint(v))
+	}
+	buf.Write(encoded)
+}
+
+//=======================================================================================//
+
+// StdLogger adapts logger for APIs that need a *log.Logger, such as
+// http.Server.ErrorLog. Every line is logged at level.
+func StdLogger(logger Logger, level Level) *log.Logger {
+	return log.New(&stdWriter{logger: logger, level: level}, "", 0)
+}
+
+type stdWriter struct {
+	logger Logger
+	level  Level
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	switch w.level {
+	case LevelDebug:
+		w.logger.Debug(msg)
+	case LevelInfo:
+		w.logger.Info(msg)
+	case LevelWarn:
+		w.logger.Warn(msg)
+	default:
+		w.logger.Error(msg)
+	}
+	return len(p), nil
+}
+
+//=======================================================================================//
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(t *testing.T, level Level, format string) (Logger, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	l, err := New(&buf, level, format)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	l.(*logger).out.now = func() time.Time { return time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC) }
+	return l, &buf
+}
+
+func TestLogger_JSON(t *testing.T) {
+	l, buf := newTestLogger(t, LevelInfo, FormatJSON)
+
+	l.Debug("hidden")
+	l.With("component", "test").Info("saved", "id", 7, "latency", 1500*time.Millisecond)
+	l.Error("failed", "error", errors.New(`bad "input"`), "dangling")
+
+	assert.Equal(t,
+		`{"time":"2021-09-01T12:00:00Z","level":"info","msg":"saved","component":"test","id":7,"latency":"1.5s"}`+"\n"+
+			`{"time":"2021-09-01T12:00:00Z","level":"error","msg":"failed","error":"bad \"input\"","!BADKEY":"dangling"}`+"\n",
+		buf.String())
+}
+
+func TestLogger_Text(t *testing.T) {
+	l, buf := newTestLogger(t, LevelDebug, FormatText)
+
+	l.Debug("new request", "route", "/projects/{id}", "note", "two words")
+
+	assert.Equal(t, `2021-09-01T12:00:00.000Z DEBUG new request route=/projects/{id} note="two words"`+"\n", buf.String())
+}
+
+func TestLogger_Ctx(t *testing.T) {
+	l, buf := newTestLogger(t, LevelDebug, FormatJSON)
+
+	ctx := WithFields(context.Background(), "request_id", "abc", "method", "GET")
+	first := WithFields(ctx, "route", "/a")
+	second := WithFields(ctx, "route", "/b")
+
+	l.Ctx(first).Info("one")
+	l.Ctx(second).Info("two", "method", "POST")
+	l.Ctx(context.Background()).Info("three")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		`{"time":"2021-09-01T12:00:00Z","level":"info","msg":"one","request_id":"abc","method":"GET","route":"/a"}`,
+		`{"time":"2021-09-01T12:00:00Z","level":"info","msg":"two","request_id":"abc","method":"POST","route":"/b"}`,
+		`{"time":"2021-09-01T12:00:00Z","level":"info","msg":"three"}`,
+	}, lines)
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	assert.NoError(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestStdLogger(t *testing.T) {
+	l, buf := newTestLogger(t, LevelDebug, FormatJSON)
+
+	StdLogger(l, LevelWarn).Printf("http: TLS handshake error from %s", "10.0.0.1:1234")
+
+	assert.Equal(t, `{"time":"2021-09-01T12:00:00Z","level":"warn","msg":"http: TLS handshake error from 10.0.0.1:1234"}`+"\n", buf.String())
+}
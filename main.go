@@ -1,38 +1,117 @@
 package main
 
 import (
-	"bytes"
-	"log"
-	"net/http"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/gorilla/handlers"
-
+	"github.com/Boobuh/golang-school-project/config"
 	"github.com/Boobuh/golang-school-project/dal"
 
 	"github.com/Boobuh/golang-school-project/handler"
+	"github.com/Boobuh/golang-school-project/handler/health"
+	"github.com/Boobuh/golang-school-project/handler/idempotency"
+	"github.com/Boobuh/golang-school-project/handler/ratelimit"
+	"github.com/Boobuh/golang-school-project/handler/rpc"
+	"github.com/Boobuh/golang-school-project/handler/security"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/metrics"
+	"github.com/Boobuh/golang-school-project/server"
+	"github.com/Boobuh/golang-school-project/tracing"
 )
 
 func main() {
-	var (
-		buf    bytes.Buffer
-		logger = log.New(&buf, "logger: ", log.Lshortfile)
-	)
-	f, err := os.OpenFile("testlogfile", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0777)
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run serves the API until SIGINT or SIGTERM and returns once in-flight
+// requests are drained and the database is closed.
+func run(cfg *config.Config) error {
+	var output io.Writer = os.Stderr
+	if cfg.Log.File != "" {
+		mode, _ := cfg.Log.Mode()
+		f, err := os.OpenFile(cfg.Log.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, mode)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	logger, err := logging.New(output, level, cfg.Log.Format)
 	if err != nil {
-		logger.Fatalf("error opening file: %v", err)
+		return err
 	}
-	defer f.Close()
 
-	logger.SetOutput(f)
-	repo := dal.NewRepository()
-	router := handler.NewRouter(repo, logger)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
+	if err != nil {
+		return err
+	}
 
-	allowedOrigin := "*"
+	// the gRPC port is taken up front, so that a port in use fails the start
+	var grpcListener net.Listener
+	if cfg.Server.GRPCAddr != "" {
+		grpcListener, err = net.Listen("tcp", cfg.Server.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("can't serve gRPC: %w", err)
+		}
+	}
 
-	originsOk := handlers.AllowedOrigins([]string{allowedOrigin})
-	headersOk := handlers.AllowedHeaders([]string{"X-Requested-With"})
-	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
+	m := metrics.New()
+	repo := dal.NewRepository(cfg.Database.Path, logger, dal.QueryMetrics(m.QueryDuration), dal.QueryTracing())
+	m.RegisterStats(repo, logger)
+	probes := health.NewHandler(logger)
+	probes.AddCheck("database", repo.Ping)
+	probes.AddCheck("migrations", func(ctx context.Context) error { return dal.CheckSchema(ctx, repo) })
+	limits := ratelimit.NewStore(cfg.RateLimit.Store, repo)
+	router := handler.NewRouter(cfg, repo, limits, m, probes, logger)
+	secure := security.NewMiddleware(cfg.Security)
+
+	srv := server.New(cfg.Server, secure.Wrap(handler.CORS(cfg.CORS)(router)), logger)
+	// closers run in reverse, the spans of the last requests are flushed at the very end
+	srv.OnShutdown(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+		defer cancel()
+		return shutdownTracing(ctx)
+	})
+	srv.Go(func(ctx context.Context) {
+		idempotency.PurgeExpired(ctx, repo, idempotency.PurgeInterval, logger)
+	})
+	if grpcListener != nil {
+		grpcServer := rpc.NewServer(repo, time.Duration(cfg.Server.RequestTimeout), logger)
+		srv.Go(func(ctx context.Context) {
+			rpc.Serve(ctx, grpcServer, grpcListener, time.Duration(cfg.Server.ShutdownTimeout), logger)
+		})
+	}
+	if cfg.RateLimit.Enabled {
+		srv.Go(func(ctx context.Context) {
+			ratelimit.PurgeIdle(ctx, limits, ratelimit.PurgeInterval, logger)
+		})
+	}
+	srv.OnShutdown(repo.Close)
+	probes.AddCheck("workers", srv.CheckWorkers)
+	probes.AddCheck("shutdown", srv.CheckServing)
 
-	log.Fatal(http.ListenAndServe("127.0.0.1:4040", handlers.CORS(originsOk, headersOk, methodsOk)(router)))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return srv.ListenAndServe(ctx)
 }
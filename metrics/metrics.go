@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+const (
+	namespace = "projects"
+
+	// unmatchedRoute labels requests that didn't match any route, so that
+	// scanners probing random paths can't blow up the number of series.
+	unmatchedRoute = "none"
+
+	// statsTimeout bounds the queries run for the business gauges on a scrape.
+	statsTimeout = 5 * time.Second
+)
+
+// StatsSource is the part of the repository the business gauges are read from.
+type StatsSource interface {
+	GetStats(ctx context.Context) (*dal.Stats, error)
+}
+
+// Metrics holds the collectors of the API together with the registry they are
+// served from. A dedicated registry keeps tests independent of each other.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	// QueryDuration is observed by the dal.QueryMetrics GORM plugin.
+	QueryDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests served, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests, by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		QueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Time taken by database statements, by operation and table.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation", "table"}),
+	}
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.QueryDuration,
+	)
+	return m
+}
+
+// RegisterStats adds gauges for the number of projects, tasks and open tasks.
+// They are counted in the database on every scrape; when that fails the error
+// is logged and the gauges are left out of the scrape.
+func (m *Metrics) RegisterStats(source StatsSource, logger logging.Logger) {
+	m.registry.MustRegister(&statsCollector{
+		source: source,
+		logger: logger,
+		projects: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "projects"),
+			"Projects stored.", nil, nil),
+		tasks: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "tasks"),
+			"Tasks stored.", nil, nil),
+		openTasks: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "open_tasks"),
+			"Tasks that aren't done yet.", nil, nil),
+	})
+}
+
+//===========================================================================//
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Wrap is a mux.MiddlewareFunc counting requests and observing their latency.
+// Routes are matched before it runs, so requests are labelled by the route
+// template instead of the path.
+func (m *Metrics) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := unmatchedRoute
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		labels := prometheus.Labels{"method": r.Method, "route": route, "status": strconv.Itoa(recorder.status())}
+		m.requests.With(labels).Inc()
+		m.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+//---------------------------------------------------------------------------//
+
+type statsCollector struct {
+	source StatsSource
+	logger logging.Logger
+
+	projects, tasks, openTasks *prometheus.Desc
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.projects
+	ch <- c.tasks
+	ch <- c.openTasks
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+	stats, err := c.source.GetStats(ctx)
+	if err != nil {
+		c.logger.Error("error collecting business metrics", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.projects, prometheus.GaugeValue, float64(stats.Projects))
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(stats.Tasks))
+	ch <- prometheus.MustNewConstMetric(c.openTasks, prometheus.GaugeValue, float64(stats.OpenTasks))
+}
+
+//---------------------------------------------------------------------------//
+
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *statusRecorder) status() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
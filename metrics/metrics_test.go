@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestMetrics_Wrap(t *testing.T) {
+	m := New()
+	router := mux.NewRouter()
+	router.Use(m.Wrap)
+	router.NotFoundHandler = m.Wrap(http.NotFoundHandler())
+	router.HandleFunc("/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] == "0" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("{}"))
+	}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/projects/1", "/projects/2", "/projects/0", "/wp-login.php"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		labels   prometheus.Labels
+		expected float64
+	}{
+		{labels: prometheus.Labels{"method": "GET", "route": "/projects/{id}", "status": "200"}, expected: 2},
+		{labels: prometheus.Labels{"method": "GET", "route": "/projects/{id}", "status": "400"}, expected: 1},
+		{labels: prometheus.Labels{"method": "GET", "route": unmatchedRoute, "status": "404"}, expected: 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, testutil.ToFloat64(m.requests.With(tt.labels)), "requests %v", tt.labels)
+	}
+	assert.Equal(t, 3, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestMetrics_RegisterStats(t *testing.T) {
+	type fields struct {
+		repo func(ctrl *gomock.Controller) *mocks.MockRepository
+	}
+	type expected struct {
+		present []string
+		absent  []string
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		expected expected
+	}{
+		{
+			name: "gauges from the database",
+			fields: fields{
+				repo: func(ctrl *gomock.Controller) *mocks.MockRepository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetStats(gomock.Any()).Return(&dal.Stats{Projects: 2, Tasks: 7, OpenTasks: 3}, nil).Times(1)
+					return repo
+				},
+			},
+			expected: expected{present: []string{"projects_projects 2\n", "projects_tasks 7\n", "projects_open_tasks 3\n"}},
+		},
+		{
+			name: "gauges left out when counting fails",
+			fields: fields{
+				repo: func(ctrl *gomock.Controller) *mocks.MockRepository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetStats(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				},
+			},
+			expected: expected{present: []string{"go_goroutines"}, absent: []string{"projects_tasks"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := New()
+			m.RegisterStats(tt.fields.repo(ctrl), logging.Nop())
+
+			recorder := httptest.NewRecorder()
+			m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			body := recorder.Body.String()
+			for _, want := range tt.expected.present {
+				assert.True(t, strings.Contains(body, want), "missing %q", want)
+			}
+			for _, unwanted := range tt.expected.absent {
+				assert.False(t, strings.Contains(body, unwanted), "unexpected %q", unwanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// ErrShuttingDown is reported by CheckServing once a shutdown has begun.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Server runs the HTTP API together with its background workers and stops all
+// of them in order when its context is cancelled: readiness fails for the
+// drain delay, the listener is closed, in-flight requests are drained, workers
+// are stopped and finally the closers (e.g. the database) are called.
+type Server struct {
+	logger          logging.Logger
+	http            *http.Server
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	certFile        string
+	keyFile         string
+
+	workers []func(ctx context.Context)
+	closers []func() error
+
+	// accessed atomically, they are read by the readiness checks
+	running  int32
+	draining int32
+}
+
+func New(cfg config.Server, handler http.Handler, logger logging.Logger) *Server {
+	return &Server{
+		logger: logger,
+		http: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout),
+			ReadTimeout:       time.Duration(cfg.ReadTimeout),
+			WriteTimeout:      time.Duration(cfg.WriteTimeout),
+			IdleTimeout:       time.Duration(cfg.IdleTimeout),
+			ErrorLog:          logging.StdLogger(logger, logging.LevelWarn),
+		},
+		shutdownTimeout: time.Duration(cfg.ShutdownTimeout),
+		drainDelay:      time.Duration(cfg.DrainDelay),
+		certFile:        cfg.TLSCertFile,
+		keyFile:         cfg.TLSKeyFile,
+	}
+}
+
+// Go registers a worker that runs for the lifetime of the server. Its context
+// is cancelled once in-flight requests are drained, and shutdown waits for it
+// to return.
+func (s *Server) Go(worker func(ctx context.Context)) {
+	s.workers = append(s.workers, worker)
+}
+
+// OnShutdown registers fn to be called after the workers have stopped. Closers
+// run in reverse order of registration.
+func (s *Server) OnShutdown(fn func() error) {
+	s.closers = append(s.closers, fn)
+}
+
+// CheckServing fails once the server has begun to shut down, so that load
+// balancers stop sending it new requests while it still answers them.
+func (s *Server) CheckServing(context.Context) error {
+	if atomic.LoadInt32(&s.draining) != 0 {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+// CheckWorkers fails unless every registered worker is running.
+func (s *Server) CheckWorkers(context.Context) error {
+	if running := int(atomic.LoadInt32(&s.running)); running != len(s.workers) {
+		return fmt.Errorf("%d of %d background workers running", running, len(s.workers))
+	}
+	return nil
+}
+
+//=======================================================================================//
+
+// ListenAndServe listens on the configured address and calls Serve.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve handles requests on listener until ctx is done or the listener fails,
+// then shuts down gracefully. With a certificate configured the connections
+// are served over TLS; a certificate that can't be loaded fails Serve before
+// anything is started. In-flight requests get the configured shutdown
+// timeout to finish; the error of an unclean shutdown is returned, but the
+// workers and closers are stopped in any case.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	if s.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("can't load TLS certificate: %w", err)
+		}
+		s.http.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	for _, worker := range s.workers {
+		workers.Add(1)
+		go func(worker func(ctx context.Context)) {
+			defer workers.Done()
+			atomic.AddInt32(&s.running, 1)
+			defer atomic.AddInt32(&s.running, -1)
+			worker(workerCtx)
+		}(worker)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if s.http.TLSConfig != nil {
+			serveErr <- s.http.ServeTLS(listener, "", "")
+			return
+		}
+		serveErr <- s.http.Serve(listener)
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+		s.logger.Error("server stopped unexpectedly", "error", err)
+	case <-ctx.Done():
+		atomic.StoreInt32(&s.draining, 1)
+		if s.drainDelay > 0 {
+			s.logger.Info("shutting down, still serving while readiness fails", "delay", s.drainDelay)
+			time.Sleep(s.drainDelay)
+		}
+		s.logger.Info("shutting down, waiting for in-flight requests", "timeout", s.shutdownTimeout)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+		err = s.http.Shutdown(shutdownCtx)
+		cancel()
+		if err != nil {
+			s.logger.Error("error draining in-flight requests", "error", err)
+			s.http.Close()
+		}
+		if serveErr := <-serveErr; !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+			err = serveErr
+		}
+	}
+
+	stopWorkers()
+	workers.Wait()
+
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if closeErr := s.closers[i](); closeErr != nil {
+			s.logger.Error("error on shutdown", "error", closeErr)
+			if err == nil {
+				err = closeErr
+			}
+		}
+	}
+	return err
+}
+
+//=======================================================================================//
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Boobuh/golang-school-project/config"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+// slowHandler answers once release is closed and reports on started when a
+// request has reached it.
+func slowHandler(started chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		io.WriteString(w, "done")
+	})
+}
+
+func startServer(t *testing.T, srv *Server) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, listener) }()
+	return "http://" + listener.Addr().String(), cancel, done
+}
+
+func TestServer_DrainsInFlightRequests(t *testing.T) {
+	cfg := config.Default().Server
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	srv := New(cfg, slowHandler(started, release), logging.Nop())
+
+	var events []string
+	workerStopped := make(chan struct{})
+	srv.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		events = append(events, "worker stopped")
+		close(workerStopped)
+	})
+	srv.OnShutdown(func() error { events = append(events, "database closed"); return nil })
+	srv.OnShutdown(func() error { events = append(events, "cache closed"); return nil })
+
+	url, stop, done := startServer(t, srv)
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{body: string(body), err: err}
+	}()
+	<-started
+
+	stop()
+	select {
+	case err := <-done:
+		t.Fatalf("Serve() returned while a request was in flight: %v", err)
+	case <-workerStopped:
+		t.Fatal("workers were stopped while a request was in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	got := <-responses
+	assert.NoError(t, got.err)
+	assert.Equal(t, "done", got.body)
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"worker stopped", "cache closed", "database closed"}, events)
+
+	_, err := http.Get(url)
+	assert.Error(t, err, "server still accepts connections after shutdown")
+}
+
+func TestServer_ShutdownDeadline(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.ShutdownTimeout = config.Duration(50 * time.Millisecond)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	srv := New(cfg, slowHandler(started, release), logging.Nop())
+
+	closed := false
+	srv.OnShutdown(func() error { closed = true; return nil })
+
+	url, stop, done := startServer(t, srv)
+	go http.Get(url)
+	<-started
+
+	stop()
+	select {
+	case err := <-done:
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "Serve() error = %v, want deadline exceeded", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() didn't give up on a request that outlived the shutdown timeout")
+	}
+	assert.True(t, closed, "closers must run even when draining failed")
+}
+
+func TestServer_ReadinessDuringDrain(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.DrainDelay = config.Duration(200 * time.Millisecond)
+	srv := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}), logging.Nop())
+	srv.Go(func(ctx context.Context) { <-ctx.Done() })
+
+	assert.EqualError(t, srv.CheckWorkers(context.Background()), "0 of 1 background workers running")
+	url, stop, done := startServer(t, srv)
+	assert.Eventually(t, func() bool { return srv.CheckWorkers(context.Background()) == nil }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, srv.CheckServing(context.Background()))
+
+	stop()
+	assert.Eventually(t, func() bool {
+		return errors.Is(srv.CheckServing(context.Background()), ErrShuttingDown)
+	}, time.Second, 5*time.Millisecond)
+	resp, err := http.Get(url)
+	if assert.NoError(t, err, "requests must still be served during the drain delay") {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.NoError(t, <-done)
+}
+
+func TestServer_CheckWorkers_StoppedWorker(t *testing.T) {
+	srv := New(config.Default().Server, http.NotFoundHandler(), logging.Nop())
+	srv.Go(func(ctx context.Context) { <-ctx.Done() })
+	srv.Go(func(ctx context.Context) {})
+
+	_, stop, done := startServer(t, srv)
+	defer func() { stop(); <-done }()
+	assert.Eventually(t, func() bool {
+		err := srv.CheckWorkers(context.Background())
+		return err != nil && err.Error() == "1 of 2 background workers running"
+	}, time.Second, 5*time.Millisecond)
+}
+
+// writeCertificate writes a self-signed certificate for 127.0.0.1 and its key
+// as PEM files and returns their paths with the parsed certificate.
+func writeCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("can't generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("can't create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("can't marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile, cert
+}
+
+func TestServer_TLS(t *testing.T) {
+	cfg := config.Default().Server
+	var cert *x509.Certificate
+	cfg.TLSCertFile, cfg.TLSKeyFile, cert = writeCertificate(t)
+	srv := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	}), logging.Nop())
+
+	url, stop, done := startServer(t, srv)
+	defer func() { stop(); <-done }()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}, ForceAttemptHTTP2: true}}
+	resp, err := client.Get("https" + url[len("http"):])
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "HTTP/2.0", string(body))
+
+	// net/http answers plain HTTP on a TLS connection with 400
+	resp, err = http.Get(url)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestServer_TLS_InvalidCertificate(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.TLSCertFile, _, _ = writeCertificate(t)
+	cfg.TLSKeyFile = cfg.TLSCertFile
+	srv := New(cfg, http.NotFoundHandler(), logging.Nop())
+	started := false
+	srv.Go(func(ctx context.Context) { started = true })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen: %v", err)
+	}
+	err = srv.Serve(context.Background(), listener)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can't load TLS certificate")
+	assert.False(t, started, "workers must not start without a certificate")
+}
@@ -0,0 +1,178 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Components a dated task can be published as. Events show up in the calendar
+// view of most clients, to-dos in their task lists.
+const (
+	ComponentEvent = "VEVENT"
+	ComponentTodo  = "VTODO"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	productID = "-//golang-school-project//Task due dates//EN"
+	uidDomain = "golang-school-project"
+
+	dateTimeLayout = "20060102T150405Z"
+	// maxLineOctets is the longest content line RFC 5545 allows before folding.
+	maxLineOctets = 75
+)
+
+var (
+	ErrFeedNotFound     = errors.New("calendar feed not found")
+	ErrUnknownComponent = errors.New("unknown calendar component, expected VEVENT or VTODO")
+)
+
+//=======================================================================================//
+
+// ParseComponent maps the component query parameter of a feed request to a
+// component name, defaulting to events.
+func ParseComponent(raw string) (string, error) {
+	switch strings.ToUpper(raw) {
+	case "", "VEVENT", "EVENT":
+		return ComponentEvent, nil
+	case "VTODO", "TODO":
+		return ComponentTodo, nil
+	}
+	return "", ErrUnknownComponent
+}
+
+// UID identifies a task across feed downloads, so that clients update the entry
+// of a task instead of adding a new one every time they refresh.
+func UID(taskID int) string {
+	return fmt.Sprintf("task-%d@%s", taskID, uidDomain)
+}
+
+//---------------------------------------------------------------------------//
+
+// Write renders the dated tasks of a project as an RFC 5545 calendar with one
+// component per task. Tasks without a due date are left out. stamp is used as
+// the DTSTAMP of every component.
+func Write(w io.Writer, project *dal.ExtendedProjectEntities, component string, stamp time.Time) error {
+	if component != ComponentEvent && component != ComponentTodo {
+		return ErrUnknownComponent
+	}
+	cw := &contentWriter{w: bufio.NewWriter(w)}
+
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", productID)
+	cw.line("CALSCALE", "GREGORIAN")
+	cw.line("METHOD", "PUBLISH")
+	cw.line("X-WR-CALNAME", escape(project.Name))
+	for _, task := range datedTasks(project) {
+		due := task.DueDate.UTC().Format(dateTimeLayout)
+		cw.line("BEGIN", component)
+		cw.line("UID", UID(task.ID))
+		cw.line("DTSTAMP", stamp.UTC().Format(dateTimeLayout))
+		cw.line("SUMMARY", escape(task.Name))
+		if task.Description != "" {
+			cw.line("DESCRIPTION", escape(task.Description))
+		}
+		if component == ComponentTodo {
+			cw.line("DUE", due)
+			if task.Status {
+				cw.line("STATUS", "COMPLETED")
+			} else {
+				cw.line("STATUS", "NEEDS-ACTION")
+			}
+		} else {
+			cw.line("DTSTART", due)
+			cw.line("TRANSP", "TRANSPARENT")
+		}
+		cw.line("END", component)
+	}
+	cw.line("END", "VCALENDAR")
+
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+//---------------------------------------------------------------------------//
+
+// datedTasks returns the tasks of a project that have a due date, soonest first.
+func datedTasks(project *dal.ExtendedProjectEntities) []dal.Task {
+	var tasks []dal.Task
+	for _, column := range project.Columns {
+		for _, task := range column.Tasks {
+			if task.DueDate != nil {
+				tasks = append(tasks, task.Task)
+			}
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(*tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+// escape quotes a TEXT value as described in RFC 5545 section 3.3.11.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+//---------------------------------------------------------------------------//
+
+// contentWriter writes CRLF terminated content lines, folding them so that no
+// line is longer than 75 octets and no UTF-8 sequence is split.
+type contentWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (cw *contentWriter) line(name, value string) {
+	if cw.err != nil {
+		return
+	}
+	// invalid UTF-8, e.g. from an imported task, is replaced so that every
+	// line can be cut at the start of a rune
+	rest := name + ":" + strings.ToValidUTF8(value, "\uFFFD")
+	limit := maxLineOctets
+	for len(rest) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(rest[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = limit
+		}
+		cw.write(rest[:cut] + "\r\n ")
+		rest = rest[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	cw.write(rest + "\r\n")
+}
+
+func (cw *contentWriter) write(s string) {
+	if cw.err == nil {
+		_, cw.err = cw.w.WriteString(s)
+	}
+}
+
+//=======================================================================================//
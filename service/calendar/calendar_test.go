@@ -0,0 +1,188 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+func due(day, hour int) *time.Time {
+	d := time.Date(2021, time.October, day, hour, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	return &d
+}
+
+var board = &dal.ExtendedProjectEntities{
+	Project: dal.Project{ID: 1, Name: "Website, relaunch"},
+	Columns: []dal.ExtendedColumn{
+		{
+			Column: dal.Column{ID: 2, Name: "To Do", OrderNum: 1},
+			Tasks: []dal.ExtendedTask{
+				{Task: dal.Task{ID: 5, Name: "Write copy; v2", Description: "Short\nand friendly", DueDate: due(3, 11)}},
+				{Task: dal.Task{ID: 6, Name: "Someday"}},
+			},
+		},
+		{
+			Column: dal.Column{ID: 3, Name: "Done", OrderNum: 2},
+			Tasks:  []dal.ExtendedTask{{Task: dal.Task{ID: 7, Name: "Ship", Status: true, DueDate: due(1, 9)}}},
+		},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	stamp := time.Date(2021, time.September, 30, 12, 0, 0, 0, time.UTC)
+	header := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//golang-school-project//Task due dates//EN\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"METHOD:PUBLISH\r\n" +
+		"X-WR-CALNAME:Website\\, relaunch\r\n"
+
+	tests := []struct {
+		name      string
+		component string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "events",
+			component: ComponentEvent,
+			want: header +
+				"BEGIN:VEVENT\r\n" +
+				"UID:task-7@golang-school-project\r\n" +
+				"DTSTAMP:20210930T120000Z\r\n" +
+				"SUMMARY:Ship\r\n" +
+				"DTSTART:20211001T070000Z\r\n" +
+				"TRANSP:TRANSPARENT\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:task-5@golang-school-project\r\n" +
+				"DTSTAMP:20210930T120000Z\r\n" +
+				"SUMMARY:Write copy\\; v2\r\n" +
+				"DESCRIPTION:Short\\nand friendly\r\n" +
+				"DTSTART:20211003T090000Z\r\n" +
+				"TRANSP:TRANSPARENT\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			name:      "todos",
+			component: ComponentTodo,
+			want: header +
+				"BEGIN:VTODO\r\n" +
+				"UID:task-7@golang-school-project\r\n" +
+				"DTSTAMP:20210930T120000Z\r\n" +
+				"SUMMARY:Ship\r\n" +
+				"DUE:20211001T070000Z\r\n" +
+				"STATUS:COMPLETED\r\n" +
+				"END:VTODO\r\n" +
+				"BEGIN:VTODO\r\n" +
+				"UID:task-5@golang-school-project\r\n" +
+				"DTSTAMP:20210930T120000Z\r\n" +
+				"SUMMARY:Write copy\\; v2\r\n" +
+				"DESCRIPTION:Short\\nand friendly\r\n" +
+				"DUE:20211003T090000Z\r\n" +
+				"STATUS:NEEDS-ACTION\r\n" +
+				"END:VTODO\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			name:      "unknown component",
+			component: "VJOURNAL",
+			wantErr:   ErrUnknownComponent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			err := Write(&got, board, tt.component, stamp)
+			if err != tt.wantErr {
+				t.Fatalf("Write() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Write() got = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestContentWriter_Folding(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &contentWriter{w: bufio.NewWriter(&buf)}
+	cw.line("SUMMARY", strings.Repeat("ä", 100))
+	cw.w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), buf.String())
+	}
+	var unfolded string
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets long", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d doesn't start with a space: %q", i, line)
+			}
+			line = line[1:]
+		}
+		unfolded += line
+	}
+	if want := "SUMMARY:" + strings.Repeat("ä", 100); unfolded != want {
+		t.Errorf("unfolded line = %q, want %q", unfolded, want)
+	}
+}
+
+func TestContentWriter_FoldingInvalidUTF8(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &contentWriter{w: bufio.NewWriter(&buf)}
+	done := make(chan struct{})
+	go func() {
+		// continuation bytes without a start byte
+		cw.line("SUMMARY", strings.Repeat("\x80", 200)+strings.Repeat("b", 100))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("line() didn't return")
+	}
+	cw.w.Flush()
+
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets long", i, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d isn't valid UTF-8: %q", i, line)
+		}
+	}
+	if want := "SUMMARY:" + "\uFFFD" + strings.Repeat("b", 100); strings.Replace(buf.String(), "\r\n ", "", -1) != want+"\r\n" {
+		t.Errorf("unfolded line = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseComponent(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{raw: "", want: ComponentEvent},
+		{raw: "vevent", want: ComponentEvent},
+		{raw: "VTODO", want: ComponentTodo},
+		{raw: "todo", want: ComponentTodo},
+		{raw: "journal", wantErr: ErrUnknownComponent},
+	}
+	for _, tt := range tests {
+		got, err := ParseComponent(tt.raw)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("ParseComponent(%q) = %q, %v, want %q, %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
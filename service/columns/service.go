@@ -1,33 +1,52 @@
 package columns
 
 import (
+	"context"
 	"errors"
-	"log"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
-func NewUseCase(repo dal.Repository, logger *log.Logger) *UseCase {
+var tracer = otel.Tracer("github.com/Boobuh/golang-school-project/service/columns")
+
+func NewUseCase(repo dal.Repository, logger logging.Logger) *UseCase {
 	return &UseCase{repo: repo, logger: logger}
 }
 
 type UseCase struct {
 	repo   dal.Repository
-	logger *log.Logger
+	logger logging.Logger
 }
 
 //=======================================================================================//
 
-func (c *UseCase) GetColumns() ([]dal.Column, error) {
-	return c.repo.GetColumns()
+func (c *UseCase) GetColumns(ctx context.Context) ([]dal.Column, error) {
+	ctx, span := tracer.Start(ctx, "columns.GetColumns")
+	defer span.End()
+	return c.repo.GetColumns(ctx)
+}
+
+// GetByProjectIDs gets the columns of all the projects at once, without their
+// tasks.
+func (c *UseCase) GetByProjectIDs(ctx context.Context, projectIDs []int) ([]dal.Column, error) {
+	ctx, span := tracer.Start(ctx, "columns.GetByProjectIDs")
+	defer span.End()
+	return c.repo.GetColumnsByProjectIDs(ctx, projectIDs)
 }
 
-func (c *UseCase) GetColumn(id int) (*dal.ExtendedColumn, error) {
-	return c.repo.GetColumn(id)
+func (c *UseCase) GetColumn(ctx context.Context, id int) (*dal.ExtendedColumn, error) {
+	ctx, span := tracer.Start(ctx, "columns.GetColumn")
+	defer span.End()
+	return c.repo.GetColumn(ctx, id)
 }
-func (c *UseCase) GetProjectColumn(projectID, columnID int) (*dal.ExtendedColumn, error) {
+func (c *UseCase) GetProjectColumn(ctx context.Context, projectID, columnID int) (*dal.ExtendedColumn, error) {
+	ctx, span := tracer.Start(ctx, "columns.GetProjectColumn")
+	defer span.End()
 
-	column, err := c.repo.GetColumn(columnID)
+	column, err := c.repo.GetColumn(ctx, columnID)
 	if err != nil {
 		return nil, err
 
@@ -38,26 +57,34 @@ func (c *UseCase) GetProjectColumn(projectID, columnID int) (*dal.ExtendedColumn
 	return column, nil
 }
 
-func (c *UseCase) CreateColumn(column *dal.Column) error {
-	return c.repo.CreateColumn(column)
+func (c *UseCase) CreateColumn(ctx context.Context, column *dal.Column) error {
+	ctx, span := tracer.Start(ctx, "columns.CreateColumn")
+	defer span.End()
+	return c.repo.CreateColumn(ctx, column)
 }
 
-func (c *UseCase) DeleteColumn(projectID, columnID int) error {
-	return c.repo.DeleteColumn(projectID, columnID)
+func (c *UseCase) DeleteColumn(ctx context.Context, projectID, columnID int) error {
+	ctx, span := tracer.Start(ctx, "columns.DeleteColumn")
+	defer span.End()
+	return c.repo.DeleteColumn(ctx, projectID, columnID)
 }
 
-func (c *UseCase) UpdateColumn(updatedColumn *dal.Column) error {
-	_, err := c.repo.GetColumn(updatedColumn.ID)
+func (c *UseCase) UpdateColumn(ctx context.Context, updatedColumn *dal.Column) error {
+	ctx, span := tracer.Start(ctx, "columns.UpdateColumn")
+	defer span.End()
+	_, err := c.repo.GetColumn(ctx, updatedColumn.ID)
 	if err != nil {
 
 		return err
 	}
-	err = c.repo.UpdateColumn(updatedColumn)
+	err = c.repo.UpdateColumn(ctx, updatedColumn)
 	return err
 }
 
-func (c *UseCase) GetAllByProjectID(projectID int) ([]dal.ExtendedColumn, error) {
-	project, err := c.repo.GetProject(projectID)
+func (c *UseCase) GetAllByProjectID(ctx context.Context, projectID int) ([]dal.ExtendedColumn, error) {
+	ctx, span := tracer.Start(ctx, "columns.GetAllByProjectID")
+	defer span.End()
+	project, err := c.repo.GetProject(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,8 @@
 package columns
 
 import (
+	"context"
 	"errors"
-	"log"
 	"reflect"
 	"testing"
 
@@ -11,6 +11,7 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
 func TestUseCase_GetColumns(t *testing.T) {
@@ -19,7 +20,7 @@ func TestUseCase_GetColumns(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	tests := []struct {
 		name    string
@@ -31,10 +32,10 @@ func TestUseCase_GetColumns(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumns().Return([]dal.Column{}, nil).Times(1)
+					repo.EXPECT().GetColumns(gomock.Any()).Return([]dal.Column{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -44,10 +45,10 @@ func TestUseCase_GetColumns(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumns().Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -61,7 +62,7 @@ func TestUseCase_GetColumns(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetColumns()
+			got, err := c.GetColumns(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetColumns() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -79,7 +80,7 @@ func TestUseCase_GetColumn(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		id int
@@ -95,10 +96,10 @@ func TestUseCase_GetColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(&dal.ExtendedColumn{}, nil).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(&dal.ExtendedColumn{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -111,10 +112,10 @@ func TestUseCase_GetColumn(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -131,7 +132,7 @@ func TestUseCase_GetColumn(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetColumn(tt.args.id)
+			got, err := c.GetColumn(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetColumn() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -149,7 +150,7 @@ func TestUseCase_GetProjectColumn(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -166,10 +167,10 @@ func TestUseCase_GetProjectColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(&dal.ExtendedColumn{}, nil).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(&dal.ExtendedColumn{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -183,10 +184,10 @@ func TestUseCase_GetProjectColumn(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -204,7 +205,7 @@ func TestUseCase_GetProjectColumn(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetProjectColumn(tt.args.projectID, tt.args.columnID)
+			got, err := c.GetProjectColumn(context.Background(), tt.args.projectID, tt.args.columnID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetProjectColumn() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -222,7 +223,7 @@ func TestUseCase_CreateColumn(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		column *dal.Column
@@ -237,10 +238,10 @@ func TestUseCase_CreateColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateColumn(&dal.Column{1, "", 1, 1, ""}).Return(nil).Times(1)
+					repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{ID: 1, ProjectID: 1, OrderNum: 1}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -258,10 +259,10 @@ func TestUseCase_CreateColumn(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateColumn(&dal.Column{0, "", 0, 0, ""}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -283,7 +284,7 @@ func TestUseCase_CreateColumn(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.CreateColumn(tt.args.column); (err != nil) != tt.wantErr {
+			if err := c.CreateColumn(context.Background(), tt.args.column); (err != nil) != tt.wantErr {
 				t.Errorf("CreateColumn() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -296,7 +297,7 @@ func TestUseCase_DeleteColumn(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -312,10 +313,10 @@ func TestUseCase_DeleteColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteColumn(1, 1).Return(nil).Times(1)
+					repo.EXPECT().DeleteColumn(gomock.Any(), 1, 1).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -328,10 +329,10 @@ func TestUseCase_DeleteColumn(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteColumn(0, 0).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().DeleteColumn(gomock.Any(), 0, 0).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -348,7 +349,7 @@ func TestUseCase_DeleteColumn(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.DeleteColumn(tt.args.projectID, tt.args.columnID); (err != nil) != tt.wantErr {
+			if err := c.DeleteColumn(context.Background(), tt.args.projectID, tt.args.columnID); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteColumn() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -361,7 +362,7 @@ func TestUseCase_UpdateColumn(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		updatedColumn *dal.Column
@@ -376,11 +377,11 @@ func TestUseCase_UpdateColumn(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateColumn(&dal.Column{ID: 1, Name: "success", ProjectID: 1}).Return(nil).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateColumn(gomock.Any(), &dal.Column{ID: 1, Name: "success", ProjectID: 1}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -396,11 +397,11 @@ func TestUseCase_UpdateColumn(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(0).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateColumn(&dal.Column{}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 0).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateColumn(gomock.Any(), &dal.Column{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -416,7 +417,7 @@ func TestUseCase_UpdateColumn(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.UpdateColumn(tt.args.updatedColumn); (err != nil) != tt.wantErr {
+			if err := c.UpdateColumn(context.Background(), tt.args.updatedColumn); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateColumn() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -429,7 +430,7 @@ func TestUseCase_GetAllByProjectID(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -447,10 +448,10 @@ func TestUseCase_GetAllByProjectID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
 					secondCall = dal.ExtendedProjectEntities{}.Columns
 					return repo
 				}(),
@@ -464,10 +465,10 @@ func TestUseCase_GetAllByProjectID(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(0).Return(&dal.ExtendedProjectEntities{}, errors.New("failed")).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 0).Return(&dal.ExtendedProjectEntities{}, errors.New("failed")).Times(1)
 					secondCall = dal.ExtendedProjectEntities{}.Columns
 					return repo
 				}(),
@@ -485,7 +486,7 @@ func TestUseCase_GetAllByProjectID(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetAllByProjectID(tt.args.projectID)
+			got, err := c.GetAllByProjectID(context.Background(), tt.args.projectID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetAllByProjectID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -496,3 +497,65 @@ func TestUseCase_GetAllByProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_GetByProjectIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		ids     []int
+		want    []dal.Column
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumnsByProjectIDs(gomock.Any(), []int{1, 2}).Return([]dal.Column{{ProjectID: 1}, {ProjectID: 2}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1, 2},
+			want:    []dal.Column{{ProjectID: 1}, {ProjectID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumnsByProjectIDs(gomock.Any(), []int{1}).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.GetByProjectIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetByProjectIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByProjectIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
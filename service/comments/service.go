@@ -1,49 +1,73 @@
 package comments
 
 import (
-	"log"
+	"context"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
+var tracer = otel.Tracer("github.com/Boobuh/golang-school-project/service/comments")
+
 type UseCase struct {
 	repo   dal.Repository
-	logger *log.Logger
+	logger logging.Logger
 }
 
-func NewUseCase(repo dal.Repository, logger *log.Logger) *UseCase {
+func NewUseCase(repo dal.Repository, logger logging.Logger) *UseCase {
 	return &UseCase{repo: repo, logger: logger}
 }
 
-func (c *UseCase) GetComments() ([]dal.Comment, error) {
-	return c.repo.GetComments()
+func (c *UseCase) GetComments(ctx context.Context) ([]dal.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comments.GetComments")
+	defer span.End()
+	return c.repo.GetComments(ctx)
+}
+
+// GetByTaskIDs gets the comments of all the tasks at once.
+func (c *UseCase) GetByTaskIDs(ctx context.Context, taskIDs []int) ([]dal.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comments.GetByTaskIDs")
+	defer span.End()
+	return c.repo.GetCommentsByTaskIDs(ctx, taskIDs)
 }
 
-func (c *UseCase) GetComment(projectID, columnID, taskID, commentID int) (*dal.Comment, error) {
-	return c.repo.GetComment(commentID)
+func (c *UseCase) GetComment(ctx context.Context, projectID, columnID, taskID, commentID int) (*dal.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comments.GetComment")
+	defer span.End()
+	return c.repo.GetComment(ctx, commentID)
 }
 
-func (c *UseCase) CreateComment(comment *dal.Comment) error {
+func (c *UseCase) CreateComment(ctx context.Context, comment *dal.Comment) error {
+	ctx, span := tracer.Start(ctx, "comments.CreateComment")
+	defer span.End()
 	comment = &dal.Comment{TaskID: comment.TaskID}
-	return c.repo.CreateComment(comment)
+	return c.repo.CreateComment(ctx, comment)
 }
 
-func (c *UseCase) DeleteComment(projectID, columnID, taskID, commentID int) error {
-	return c.repo.DeleteComment(projectID, columnID, taskID, commentID)
+func (c *UseCase) DeleteComment(ctx context.Context, projectID, columnID, taskID, commentID int) error {
+	ctx, span := tracer.Start(ctx, "comments.DeleteComment")
+	defer span.End()
+	return c.repo.DeleteComment(ctx, projectID, columnID, taskID, commentID)
 }
 
-func (c *UseCase) UpdateComment(comment *dal.Comment) error {
-	_, err := c.repo.GetComment(comment.ID)
+func (c *UseCase) UpdateComment(ctx context.Context, comment *dal.Comment) error {
+	ctx, span := tracer.Start(ctx, "comments.UpdateComment")
+	defer span.End()
+	_, err := c.repo.GetComment(ctx, comment.ID)
 	if err != nil {
 
 		return err
 	}
-	err = c.repo.UpdateComment(comment)
+	err = c.repo.UpdateComment(ctx, comment)
 	return err
 }
 
-func (c *UseCase) GetAllByTaskID(taskID int) ([]dal.Comment, error) {
-	task, err := c.repo.GetTask(taskID)
+func (c *UseCase) GetAllByTaskID(ctx context.Context, taskID int) ([]dal.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comments.GetAllByTaskID")
+	defer span.End()
+	task, err := c.repo.GetTask(ctx, taskID)
 	if err != nil {
 		return nil, err
 	}
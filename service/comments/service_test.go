@@ -1,14 +1,15 @@
 package comments
 
 import (
+	"context"
 	"errors"
-	"log"
 	"reflect"
 	"testing"
 
 	"github.com/Boobuh/golang-school-project/dal/mocks"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 	"github.com/golang/mock/gomock"
 )
 
@@ -18,7 +19,7 @@ func TestUseCase_GetComments(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	tests := []struct {
 		name    string
@@ -30,10 +31,10 @@ func TestUseCase_GetComments(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComments().Return([]dal.Comment{}, nil).Times(1)
+					repo.EXPECT().GetComments(gomock.Any()).Return([]dal.Comment{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -43,10 +44,10 @@ func TestUseCase_GetComments(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComments().Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetComments(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -60,7 +61,7 @@ func TestUseCase_GetComments(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetComments()
+			got, err := c.GetComments(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetComments() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -78,7 +79,7 @@ func TestUseCase_GetComment(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -97,10 +98,10 @@ func TestUseCase_GetComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComment(1).Return(&dal.Comment{}, nil).Times(1)
+					repo.EXPECT().GetComment(gomock.Any(), 1).Return(&dal.Comment{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -116,10 +117,10 @@ func TestUseCase_GetComment(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComment(0).Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetComment(gomock.Any(), 0).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -139,7 +140,7 @@ func TestUseCase_GetComment(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetComment(tt.args.projectID, tt.args.columnID, tt.args.taskID, tt.args.commentID)
+			got, err := c.GetComment(context.Background(), tt.args.projectID, tt.args.columnID, tt.args.taskID, tt.args.commentID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetComment() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -157,7 +158,7 @@ func TestUseCase_CreateComment(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		comment *dal.Comment
@@ -172,10 +173,10 @@ func TestUseCase_CreateComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateComment(&dal.Comment{Description: "", TaskID: 1, ID: 0}).Return(nil).Times(1)
+					repo.EXPECT().CreateComment(gomock.Any(), &dal.Comment{Description: "", TaskID: 1, ID: 0}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -191,10 +192,10 @@ func TestUseCase_CreateComment(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateComment(&dal.Comment{Description: "", TaskID: 0, ID: 0}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().CreateComment(gomock.Any(), &dal.Comment{Description: "", TaskID: 0, ID: 0}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -214,7 +215,7 @@ func TestUseCase_CreateComment(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.CreateComment(tt.args.comment); (err != nil) != tt.wantErr {
+			if err := c.CreateComment(context.Background(), tt.args.comment); (err != nil) != tt.wantErr {
 				t.Errorf("CreateComment() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -227,7 +228,7 @@ func TestUseCase_DeleteComment(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -245,10 +246,10 @@ func TestUseCase_DeleteComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteComment(1, 1, 1, 1).Return(nil).Times(1)
+					repo.EXPECT().DeleteComment(gomock.Any(), 1, 1, 1, 1).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -263,10 +264,10 @@ func TestUseCase_DeleteComment(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteComment(0, 0, 0, 0).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().DeleteComment(gomock.Any(), 0, 0, 0, 0).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -285,7 +286,7 @@ func TestUseCase_DeleteComment(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.DeleteComment(tt.args.projectID, tt.args.columnID, tt.args.taskID, tt.args.commentID); (err != nil) != tt.wantErr {
+			if err := c.DeleteComment(context.Background(), tt.args.projectID, tt.args.columnID, tt.args.taskID, tt.args.commentID); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteComment() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -298,7 +299,7 @@ func TestUseCase_UpdateComment(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		comment *dal.Comment
@@ -313,11 +314,11 @@ func TestUseCase_UpdateComment(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComment(1).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateComment(&dal.Comment{ID: 1}).Return(nil).Times(1)
+					repo.EXPECT().GetComment(gomock.Any(), 1).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateComment(gomock.Any(), &dal.Comment{ID: 1}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -331,11 +332,11 @@ func TestUseCase_UpdateComment(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetComment(0).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateComment(&dal.Comment{}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().GetComment(gomock.Any(), 0).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateComment(gomock.Any(), &dal.Comment{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -351,7 +352,7 @@ func TestUseCase_UpdateComment(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.UpdateComment(tt.args.comment); (err != nil) != tt.wantErr {
+			if err := c.UpdateComment(context.Background(), tt.args.comment); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -364,7 +365,7 @@ func TestUseCase_GetAllByTaskID(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		taskID int
@@ -381,10 +382,10 @@ func TestUseCase_GetAllByTaskID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(1).Return(&dal.ExtendedTask{}, nil).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 1).Return(&dal.ExtendedTask{}, nil).Times(1)
 					secondCall = dal.ExtendedTask{}.Comments
 					return repo
 				}(),
@@ -398,10 +399,10 @@ func TestUseCase_GetAllByTaskID(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(0).Return(&dal.ExtendedTask{}, errors.New("failed")).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 0).Return(&dal.ExtendedTask{}, errors.New("failed")).Times(1)
 					secondCall = dal.ExtendedTask{}.Comments
 					return repo
 				}(),
@@ -419,7 +420,7 @@ func TestUseCase_GetAllByTaskID(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetAllByTaskID(tt.args.taskID)
+			got, err := c.GetAllByTaskID(context.Background(), tt.args.taskID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetAllByTaskID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -430,3 +431,65 @@ func TestUseCase_GetAllByTaskID(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_GetByTaskIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		ids     []int
+		want    []dal.Comment
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetCommentsByTaskIDs(gomock.Any(), []int{1, 2}).Return([]dal.Comment{{TaskID: 1}, {TaskID: 2}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1, 2},
+			want:    []dal.Comment{{TaskID: 1}, {TaskID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetCommentsByTaskIDs(gomock.Any(), []int{1}).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.GetByTaskIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetByTaskIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByTaskIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
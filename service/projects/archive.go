@@ -0,0 +1,142 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+var ErrArchiveVersion = fmt.Errorf("unsupported archive schema version, expected %d", dal.ArchiveSchemaVersion)
+
+//=======================================================================================//
+
+func (c *UseCase) ExportProject(ctx context.Context, id int) (*dal.ProjectArchive, error) {
+	ctx, span := tracer.Start(ctx, "projects.ExportProject")
+	defer span.End()
+	project, err := c.repo.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &dal.ProjectArchive{
+		SchemaVersion: dal.ArchiveSchemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		Project:       *project,
+	}, nil
+}
+
+// ImportProject recreates an exported project with fresh IDs. Relationships are
+// taken from the nesting of the archive, the IDs stored in it are ignored. Column
+// names are unique across all projects, so columns named like an existing one get
+// a numeric suffix and are reported as converted. The whole tree is created in one
+// transaction.
+func (c *UseCase) ImportProject(ctx context.Context, archive *dal.ProjectArchive) (*dal.ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "projects.ImportProject")
+	defer span.End()
+	report := &dal.ImportReport{Converted: []dal.ImportReportItem{}, Skipped: []dal.ImportReportItem{}}
+	project, err := c.importArchive(ctx, archive, "column", report)
+	if err != nil {
+		return nil, err
+	}
+	report.Project = project
+	for _, extColumn := range archive.Project.Columns {
+		report.Columns++
+		for _, extTask := range extColumn.Tasks {
+			report.Tasks++
+			report.Comments += len(extTask.Comments)
+		}
+	}
+	return report, nil
+}
+
+// importArchive creates the project of an archive, renaming the columns whose
+// names are taken. The renames are reported as converted items of kind.
+func (c *UseCase) importArchive(ctx context.Context, archive *dal.ProjectArchive, kind string, report *dal.ImportReport) (*dal.Project, error) {
+	if archive.SchemaVersion != dal.ArchiveSchemaVersion {
+		return nil, ErrArchiveVersion
+	}
+	if err := c.renameTakenColumns(ctx, archive, kind, report); err != nil {
+		return nil, err
+	}
+
+	var created *dal.Project
+	err := c.repo.Transaction(ctx, func(tx dal.Repository) error {
+		project, err := tx.CreateProject(ctx, &dal.Project{
+			Name:        archive.Project.Name,
+			Description: archive.Project.Description,
+		})
+		if err != nil {
+			return err
+		}
+		for _, extColumn := range archive.Project.Columns {
+			column := &dal.Column{
+				Name:      extColumn.Name,
+				ProjectID: project.ID,
+				OrderNum:  extColumn.OrderNum,
+				Status:    extColumn.Status,
+			}
+			if err := tx.CreateColumn(ctx, column); err != nil {
+				return fmt.Errorf("column %q: %w", extColumn.Name, err)
+			}
+			for _, extTask := range extColumn.Tasks {
+				task := &dal.Task{
+					Name:        extTask.Name,
+					Status:      extTask.Status,
+					Description: extTask.Description,
+					ColumnID:    column.ID,
+					DueDate:     extTask.DueDate,
+				}
+				if err := tx.CreateTask(ctx, task); err != nil {
+					return fmt.Errorf("task %q: %w", extTask.Name, err)
+				}
+				for _, comment := range extTask.Comments {
+					if err := tx.CreateComment(ctx, &dal.Comment{Description: comment.Description, TaskID: task.ID}); err != nil {
+						return fmt.Errorf("comment on task %q: %w", extTask.Name, err)
+					}
+				}
+			}
+		}
+		created = project
+		return nil
+	})
+	if err != nil {
+		c.logger.Ctx(ctx).Error("error importing project", "project", archive.Project.Name, "error", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+//---------------------------------------------------------------------------//
+
+// renameTakenColumns gives the columns of an archive named like an existing
+// column, or like an earlier column of the archive, the first free "Name (n)".
+func (c *UseCase) renameTakenColumns(ctx context.Context, archive *dal.ProjectArchive, kind string, report *dal.ImportReport) error {
+	existing, err := c.repo.GetColumns(ctx)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(existing))
+	for _, column := range existing {
+		names[column.Name] = true
+	}
+	for i := range archive.Project.Columns {
+		column := &archive.Project.Columns[i].Column
+		name := column.Name
+		for n := 2; names[name]; n++ {
+			name = fmt.Sprintf("%s (%d)", column.Name, n)
+		}
+		if name != column.Name {
+			report.Converted = append(report.Converted, dal.ImportReportItem{
+				Kind:   kind,
+				Name:   column.Name,
+				Reason: fmt.Sprintf("renamed to %q because the name is already used", name),
+			})
+			column.Name = name
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+//=======================================================================================//
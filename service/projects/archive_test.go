@@ -0,0 +1,232 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestUseCase_ExportProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    dal.ExtendedProjectEntities
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{Project: dal.Project{ID: 1, Name: "board"}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			want: dal.ExtendedProjectEntities{Project: dal.Project{ID: 1, Name: "board"}},
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.ExportProject(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExportProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.SchemaVersion != dal.ArchiveSchemaVersion {
+				t.Errorf("ExportProject() schema version = %d, want %d", got.SchemaVersion, dal.ArchiveSchemaVersion)
+			}
+			if !reflect.DeepEqual(got.Project, tt.want) {
+				t.Errorf("ExportProject() got = %v, want %v", got.Project, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCase_ImportProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	type args struct {
+		archive *dal.ProjectArchive
+	}
+
+	// the import renames columns in the archive, so every case gets its own
+	newArchive := func() *dal.ProjectArchive {
+		return &dal.ProjectArchive{
+			SchemaVersion: dal.ArchiveSchemaVersion,
+			Project: dal.ExtendedProjectEntities{
+				Project: dal.Project{ID: 10, Name: "board", Description: "exported"},
+				Columns: []dal.ExtendedColumn{{
+					Column: dal.Column{ID: 20, Name: "todo", ProjectID: 10, OrderNum: 1},
+					Tasks: []dal.ExtendedTask{{
+						Task:     dal.Task{ID: 30, Name: "write docs", Status: true, ColumnID: 20},
+						Comments: []dal.Comment{{ID: 40, TaskID: 30, Description: "soon"}},
+					}},
+				}},
+			},
+		}
+	}
+	// importing expects the tree of newArchive to be created with the column
+	// named column
+	importing := func(repo *mocks.MockRepository, column string) {
+		repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(dal.Repository) error) error {
+			return fn(repo)
+		}).Times(1)
+		repo.EXPECT().CreateProject(gomock.Any(), &dal.Project{Name: "board", Description: "exported"}).Return(&dal.Project{ID: 2, Name: "board", Description: "exported"}, nil).Times(1)
+		repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{Name: column, ProjectID: 2, OrderNum: 1}).DoAndReturn(func(_ context.Context, column *dal.Column) error {
+			column.ID = 3
+			return nil
+		}).Times(1)
+		repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "write docs", Status: true, ColumnID: 3}).DoAndReturn(func(_ context.Context, task *dal.Task) error {
+			task.ID = 4
+			return nil
+		}).Times(1)
+		repo.EXPECT().CreateComment(gomock.Any(), &dal.Comment{TaskID: 4, Description: "soon"}).Return(nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *dal.ImportReport
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return([]dal.Column{{ID: 1, Name: "backlog"}}, nil).Times(1)
+					importing(repo, "todo")
+					return repo
+				}(),
+			},
+			args: args{archive: newArchive()},
+			want: &dal.ImportReport{
+				Project:   &dal.Project{ID: 2, Name: "board", Description: "exported"},
+				Columns:   1,
+				Tasks:     1,
+				Comments:  1,
+				Converted: []dal.ImportReportItem{},
+				Skipped:   []dal.ImportReportItem{},
+			},
+		},
+		{
+			name: "column name already used",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return([]dal.Column{{ID: 1, Name: "todo"}, {ID: 2, Name: "todo (2)"}}, nil).Times(1)
+					importing(repo, "todo (3)")
+					return repo
+				}(),
+			},
+			args: args{archive: newArchive()},
+			want: &dal.ImportReport{
+				Project:  &dal.Project{ID: 2, Name: "board", Description: "exported"},
+				Columns:  1,
+				Tasks:    1,
+				Comments: 1,
+				Converted: []dal.ImportReportItem{
+					{Kind: "column", Name: "todo", Reason: `renamed to "todo (3)" because the name is already used`},
+				},
+				Skipped: []dal.ImportReportItem{},
+			},
+		},
+		{
+			name: "unsupported version",
+			fields: fields{
+				logger: logging.Nop(),
+				repo:   mocks.NewMockRepository(ctrl),
+			},
+			args:    args{archive: &dal.ProjectArchive{SchemaVersion: 99}},
+			wantErr: true,
+		},
+		{
+			name: "columns can't be read",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			args:    args{archive: newArchive()},
+			wantErr: true,
+		},
+		{
+			name: "transaction failed",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(nil, nil).Times(1)
+					repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(dal.Repository) error) error {
+						return fn(repo)
+					}).Times(1)
+					repo.EXPECT().CreateProject(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			args:    args{archive: newArchive()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.ImportProject(context.Background(), tt.args.archive)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ImportProject() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
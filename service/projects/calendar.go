@@ -0,0 +1,61 @@
+package projects
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/service/calendar"
+)
+
+// feedTokenBytes is the amount of randomness in a feed token. The token is the
+// only thing protecting a feed, so it has to be impossible to guess.
+const feedTokenBytes = 32
+
+//=======================================================================================//
+
+// CreateCalendarFeed issues a new feed token for the project. A project has a
+// single feed, creating another one revokes the previous token.
+func (c *UseCase) CreateCalendarFeed(ctx context.Context, projectID int) (*dal.CalendarFeed, error) {
+	ctx, span := tracer.Start(ctx, "projects.CreateCalendarFeed")
+	defer span.End()
+	if _, err := c.repo.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	feed := &dal.CalendarFeed{Token: hex.EncodeToString(raw), ProjectID: projectID, CreatedAt: time.Now().UTC()}
+	if err := c.repo.SaveCalendarFeed(ctx, feed); err != nil {
+		c.logger.Ctx(ctx).Error("error saving calendar feed", "project_id", projectID, "error", err)
+		return nil, err
+	}
+	return feed, nil
+}
+
+//---------------------------------------------------------------------------//
+
+// RenderCalendarFeed writes the dated tasks of the project the token belongs to
+// as an iCalendar document. calendar.ErrFeedNotFound is returned for unknown tokens.
+func (c *UseCase) RenderCalendarFeed(ctx context.Context, token, component string, w io.Writer) error {
+	ctx, span := tracer.Start(ctx, "projects.RenderCalendarFeed")
+	defer span.End()
+	feed, err := c.repo.GetCalendarFeed(ctx, token)
+	if err != nil {
+		return err
+	}
+	if feed == nil {
+		return calendar.ErrFeedNotFound
+	}
+	project, err := c.repo.GetProject(ctx, feed.ProjectID)
+	if err != nil {
+		return err
+	}
+	return calendar.Write(w, project, component, time.Now())
+}
+
+//=======================================================================================//
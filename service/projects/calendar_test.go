@@ -0,0 +1,170 @@
+package projects
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/service/calendar"
+)
+
+func TestUseCase_CreateCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{Project: dal.Project{ID: 1}}, nil).Times(1)
+					repo.EXPECT().SaveCalendarFeed(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+		},
+		{
+			name: "unknown project",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("record not found")).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{Project: dal.Project{ID: 1}}, nil).Times(1)
+					repo.EXPECT().SaveCalendarFeed(gomock.Any(), gomock.Any()).Return(errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.CreateCalendarFeed(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateCalendarFeed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ProjectID != 1 || len(got.Token) != 2*feedTokenBytes {
+				t.Errorf("CreateCalendarFeed() got = %+v", got)
+			}
+		})
+	}
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		repo := mocks.NewMockRepository(ctrl)
+		repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{Project: dal.Project{ID: 1}}, nil).Times(2)
+		repo.EXPECT().SaveCalendarFeed(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		c := &UseCase{repo: repo, logger: logging.Nop()}
+		first, _ := c.CreateCalendarFeed(context.Background(), 1)
+		second, _ := c.CreateCalendarFeed(context.Background(), 1)
+		if first.Token == second.Token {
+			t.Errorf("CreateCalendarFeed() issued the same token twice: %s", first.Token)
+		}
+	})
+}
+
+func TestUseCase_RenderCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	due := time.Date(2021, time.October, 1, 9, 0, 0, 0, time.UTC)
+	project := &dal.ExtendedProjectEntities{
+		Project: dal.Project{ID: 1, Name: "board"},
+		Columns: []dal.ExtendedColumn{{
+			Column: dal.Column{ID: 2, Name: "todo"},
+			Tasks:  []dal.ExtendedTask{{Task: dal.Task{ID: 5, Name: "write", DueDate: &due}}},
+		}},
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		wantBody []string
+		wantErr  error
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetCalendarFeed(gomock.Any(), "secret").Return(&dal.CalendarFeed{Token: "secret", ProjectID: 1}, nil).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(project, nil).Times(1)
+					return repo
+				}(),
+			},
+			wantBody: []string{"BEGIN:VTODO\r\n", "UID:task-5@golang-school-project\r\n", "DUE:20211001T090000Z\r\n"},
+		},
+		{
+			name: "unknown token",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetCalendarFeed(gomock.Any(), "secret").Return(nil, nil).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: calendar.ErrFeedNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			var body bytes.Buffer
+			err := c.RenderCalendarFeed(context.Background(), "secret", calendar.ComponentTodo, &body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RenderCalendarFeed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body.String(), want) {
+					t.Errorf("RenderCalendarFeed() body = %q, want it to contain %q", body.String(), want)
+				}
+			}
+		})
+	}
+}
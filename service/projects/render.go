@@ -0,0 +1,32 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Boobuh/golang-school-project/service/render"
+)
+
+//=======================================================================================//
+
+// RenderProject writes the project in one of the formats registered in the render
+// package and returns the content type of the written document.
+func (c *UseCase) RenderProject(ctx context.Context, id int, format string, w io.Writer) (string, error) {
+	ctx, span := tracer.Start(ctx, "projects.RenderProject")
+	defer span.End()
+	renderer, ok := render.Lookup(format)
+	if !ok {
+		return "", fmt.Errorf("unknown export format %q, supported formats are %v", format, render.Formats())
+	}
+	project, err := c.repo.GetProject(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if err := renderer.Render(w, project); err != nil {
+		return "", err
+	}
+	return renderer.ContentType(), nil
+}
+
+//=======================================================================================//
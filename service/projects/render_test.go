@@ -0,0 +1,92 @@
+package projects
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestUseCase_RenderProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	type args struct {
+		format string
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		args            args
+		wantContentType string
+		wantBody        string
+		wantErr         bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{Project: dal.Project{ID: 1, Name: "board"}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			args:            args{format: "md"},
+			wantContentType: "text/markdown; charset=utf-8",
+			wantBody:        "# board\n",
+		},
+		{
+			name: "unknown format",
+			fields: fields{
+				logger: logging.Nop(),
+				repo:   mocks.NewMockRepository(ctrl),
+			},
+			args:    args{format: "pdf"},
+			wantErr: true,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			args:    args{format: "mmd"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			var body bytes.Buffer
+			got, err := c.RenderProject(context.Background(), 1, tt.args.format, &body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenderProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantContentType {
+				t.Errorf("RenderProject() got = %v, want %v", got, tt.wantContentType)
+			}
+			if body.String() != tt.wantBody {
+				t.Errorf("RenderProject() body = %q, want %q", body.String(), tt.wantBody)
+			}
+		})
+	}
+}
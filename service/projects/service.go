@@ -1,54 +1,76 @@
 package projects
 
 import (
-	"fmt"
-	"log"
+	"context"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
+var tracer = otel.Tracer("github.com/Boobuh/golang-school-project/service/projects")
+
 type UseCase struct {
 	repo   dal.Repository
-	logger *log.Logger
+	logger logging.Logger
 }
 
-func NewUseCase(repo dal.Repository, logger *log.Logger) *UseCase {
+func NewUseCase(repo dal.Repository, logger logging.Logger) *UseCase {
 	return &UseCase{repo: repo, logger: logger}
 }
 
 //=======================================================================================//
 
-func (c *UseCase) UpdateProject(updatedProject *dal.Project) error {
-	_, err := c.repo.GetProject(updatedProject.ID)
+func (c *UseCase) UpdateProject(ctx context.Context, updatedProject *dal.Project) error {
+	ctx, span := tracer.Start(ctx, "projects.UpdateProject")
+	defer span.End()
+	_, err := c.repo.GetProject(ctx, updatedProject.ID)
 	if err != nil {
-		fmt.Printf("project not found by id %s\n", err)
+		c.logger.Ctx(ctx).Warn("project not found", "project_id", updatedProject.ID, "error", err)
 		return err
 	}
-	err = c.repo.UpdateProject(updatedProject)
+	err = c.repo.UpdateProject(ctx, updatedProject)
 	return err
 }
 
-func (c *UseCase) GetProjects() ([]dal.Project, error) {
-	return c.repo.GetProjects()
+func (c *UseCase) GetProjects(ctx context.Context) ([]dal.Project, error) {
+	ctx, span := tracer.Start(ctx, "projects.GetProjects")
+	defer span.End()
+	return c.repo.GetProjects(ctx)
+}
+
+// GetProjectsByIDs gets the projects with any of ids at once, without their
+// columns.
+func (c *UseCase) GetProjectsByIDs(ctx context.Context, ids []int) ([]dal.Project, error) {
+	ctx, span := tracer.Start(ctx, "projects.GetProjectsByIDs")
+	defer span.End()
+	return c.repo.GetProjectsByIDs(ctx, ids)
 }
 
-func (c *UseCase) GetProject(id int) (*dal.ExtendedProjectEntities, error) {
-	return c.repo.GetProject(id)
+func (c *UseCase) GetProject(ctx context.Context, id int) (*dal.ExtendedProjectEntities, error) {
+	ctx, span := tracer.Start(ctx, "projects.GetProject")
+	defer span.End()
+	return c.repo.GetProject(ctx, id)
 }
 
-func (c *UseCase) CreateProject(project *dal.Project) error {
-	project, err := c.repo.CreateProject(project)
+func (c *UseCase) CreateProject(ctx context.Context, project *dal.Project) error {
+	ctx, span := tracer.Start(ctx, "projects.CreateProject")
+	defer span.End()
+	project, err := c.repo.CreateProject(ctx, project)
 	if err != nil {
 		return err
 	}
-	_, err = c.repo.GetProject(project.ID)
+	_, err = c.repo.GetProject(ctx, project.ID)
 	if err != nil {
 		return err
 	}
 	column := &dal.Column{ProjectID: project.ID, Name: project.Name + "_default"}
-	return c.repo.CreateColumn(column)
+	return c.repo.CreateColumn(ctx, column)
 }
 
-func (c *UseCase) DeleteProject(id int) error {
-	return c.repo.DeleteProject(id)
+func (c *UseCase) DeleteProject(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "projects.DeleteProject")
+	defer span.End()
+	return c.repo.DeleteProject(ctx, id)
 }
@@ -1,8 +1,8 @@
 package projects
 
 import (
+	"context"
 	"errors"
-	"log"
 	"reflect"
 	"testing"
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Boobuh/golang-school-project/dal"
 	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
 func TestUseCase_UpdateProject(t *testing.T) {
@@ -18,7 +19,7 @@ func TestUseCase_UpdateProject(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 
 	type args struct {
@@ -35,11 +36,11 @@ func TestUseCase_UpdateProject(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(1).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateProject(&dal.Project{ID: 1, Name: "success", Description: "success"}).Return(nil).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateProject(gomock.Any(), &dal.Project{ID: 1, Name: "success", Description: "success"}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -55,11 +56,11 @@ func TestUseCase_UpdateProject(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(0).Return(nil, nil).Times(1)
-					repo.EXPECT().UpdateProject(&dal.Project{}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 0).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateProject(gomock.Any(), &dal.Project{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -75,7 +76,7 @@ func TestUseCase_UpdateProject(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.UpdateProject(tt.args.body); (err != nil) != tt.wantErr {
+			if err := c.UpdateProject(context.Background(), tt.args.body); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateProject() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -88,7 +89,7 @@ func TestUseCase_GetProjects(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	tests := []struct {
 		name    string
@@ -100,10 +101,10 @@ func TestUseCase_GetProjects(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProjects().Return([]dal.Project{}, nil).Times(1)
+					repo.EXPECT().GetProjects(gomock.Any()).Return([]dal.Project{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -113,10 +114,10 @@ func TestUseCase_GetProjects(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProjects().Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetProjects(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -130,7 +131,7 @@ func TestUseCase_GetProjects(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetProjects()
+			got, err := c.GetProjects(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetProjects() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -148,7 +149,7 @@ func TestUseCase_GetProject(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		id int
@@ -164,10 +165,10 @@ func TestUseCase_GetProject(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -180,10 +181,10 @@ func TestUseCase_GetProject(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetProject(1).Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -200,7 +201,7 @@ func TestUseCase_GetProject(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetProject(tt.args.id)
+			got, err := c.GetProject(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetProject() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -218,7 +219,7 @@ func TestUseCase_CreateProject(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		project *dal.Project
@@ -234,12 +235,12 @@ func TestUseCase_CreateProject(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					firstCall := repo.EXPECT().CreateProject(&dal.Project{ID: 1, Name: "success", Description: "success"}).Return(&dal.Project{}, nil).Times(1)
-					secondCall := repo.EXPECT().GetProject(dal.Project{}.ID).Return(&dal.ExtendedProjectEntities{}, nil).Times(1).After(firstCall)
-					repo.EXPECT().CreateColumn(&dal.Column{0, "_default", 0, 0, ""}).Return(nil).Times(1).After(secondCall)
+					firstCall := repo.EXPECT().CreateProject(gomock.Any(), &dal.Project{ID: 1, Name: "success", Description: "success"}).Return(&dal.Project{}, nil).Times(1)
+					secondCall := repo.EXPECT().GetProject(gomock.Any(), dal.Project{}.ID).Return(&dal.ExtendedProjectEntities{}, nil).Times(1).After(firstCall)
+					repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{Name: "_default"}).Return(nil).Times(1).After(secondCall)
 					return repo
 				}(),
 			},
@@ -257,12 +258,12 @@ func TestUseCase_CreateProject(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					firstCall := repo.EXPECT().CreateProject(&dal.Project{ID: 1, Name: "success", Description: "success"}).Return(&dal.Project{}, nil).Times(1)
-					secondCall := repo.EXPECT().GetProject(dal.Project{}.ID).Return(&dal.ExtendedProjectEntities{}, nil).Times(1).After(firstCall)
-					repo.EXPECT().CreateColumn(&dal.Column{0, "_default", 0, 0, ""}).Return(errors.New("failed")).Times(1).After(secondCall)
+					firstCall := repo.EXPECT().CreateProject(gomock.Any(), &dal.Project{ID: 1, Name: "success", Description: "success"}).Return(&dal.Project{}, nil).Times(1)
+					secondCall := repo.EXPECT().GetProject(gomock.Any(), dal.Project{}.ID).Return(&dal.ExtendedProjectEntities{}, nil).Times(1).After(firstCall)
+					repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{Name: "_default"}).Return(errors.New("failed")).Times(1).After(secondCall)
 					return repo
 				}(),
 			},
@@ -284,7 +285,7 @@ func TestUseCase_CreateProject(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.CreateProject(tt.args.project); (err != nil) != tt.wantErr {
+			if err := c.CreateProject(context.Background(), tt.args.project); (err != nil) != tt.wantErr {
 				t.Errorf("CreateProject() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -297,7 +298,7 @@ func TestUseCase_DeleteProject(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		id int
@@ -312,10 +313,10 @@ func TestUseCase_DeleteProject(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteProject(1).Return(nil).Times(1)
+					repo.EXPECT().DeleteProject(gomock.Any(), 1).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -327,10 +328,10 @@ func TestUseCase_DeleteProject(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteProject(1).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().DeleteProject(gomock.Any(), 1).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -346,9 +347,71 @@ func TestUseCase_DeleteProject(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.DeleteProject(tt.args.id); (err != nil) != tt.wantErr {
+			if err := c.DeleteProject(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteProject() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestUseCase_GetProjectsByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		ids     []int
+		want    []dal.Project
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProjectsByIDs(gomock.Any(), []int{1, 2}).Return([]dal.Project{{ID: 1}, {ID: 2}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1, 2},
+			want:    []dal.Project{{ID: 1}, {ID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProjectsByIDs(gomock.Any(), []int{1}).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.GetProjectsByIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProjectsByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetProjectsByIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
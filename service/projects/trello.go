@@ -0,0 +1,26 @@
+package projects
+
+import (
+	"context"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/service/trello"
+)
+
+//=======================================================================================//
+
+// ImportTrelloBoard creates a project from a Trello board export. Lists named
+// like an existing column get a numeric suffix, as columns of an archive do.
+func (c *UseCase) ImportTrelloBoard(ctx context.Context, board *trello.Board) (*dal.ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "projects.ImportTrelloBoard")
+	defer span.End()
+	archive, report := trello.Convert(board)
+	project, err := c.importArchive(ctx, archive, "list", report)
+	if err != nil {
+		return nil, err
+	}
+	report.Project = project
+	return report, nil
+}
+
+//=======================================================================================//
@@ -0,0 +1,106 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+	"github.com/Boobuh/golang-school-project/service/trello"
+)
+
+func TestUseCase_ImportTrelloBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	raw, err := os.ReadFile("../trello/testdata/board.json")
+	if err != nil {
+		t.Fatalf("can't read sample export: %v", err)
+	}
+	var board trello.Board
+	if err := json.Unmarshal(raw, &board); err != nil {
+		t.Fatalf("can't decode sample export: %v", err)
+	}
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+
+	var createdColumns []string
+	tests := []struct {
+		name        string
+		fields      fields
+		wantColumns []string
+		wantErr     bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return([]dal.Column{{ID: 1, Name: "To Do"}, {ID: 2, Name: "To Do (2)"}}, nil).Times(1)
+					repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(dal.Repository) error) error {
+						return fn(repo)
+					}).Times(1)
+					repo.EXPECT().CreateProject(gomock.Any(), &dal.Project{Name: "Website relaunch", Description: "Everything for the autumn relaunch"}).Return(&dal.Project{ID: 5, Name: "Website relaunch"}, nil).Times(1)
+					repo.EXPECT().CreateColumn(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, column *dal.Column) error {
+						createdColumns = append(createdColumns, column.Name)
+						return nil
+					}).Times(3)
+					repo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+					repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+					return repo
+				}(),
+			},
+			wantColumns: []string{"To Do (3)", "Doing", "Done"},
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdColumns = nil
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.ImportTrelloBoard(context.Background(), &board)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportTrelloBoard() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Project == nil || got.Project.ID != 5 {
+				t.Errorf("ImportTrelloBoard() project = %v, want id 5", got.Project)
+			}
+			if !reflect.DeepEqual(createdColumns, tt.wantColumns) {
+				t.Errorf("ImportTrelloBoard() created columns = %v, want %v", createdColumns, tt.wantColumns)
+			}
+			renamed := got.Converted[len(got.Converted)-1]
+			if renamed.Kind != "list" || renamed.Reason != `renamed to "To Do (3)" because the name is already used` {
+				t.Errorf("ImportTrelloBoard() last converted item = %+v, want renamed list", renamed)
+			}
+		})
+	}
+}
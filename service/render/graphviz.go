@@ -0,0 +1,57 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Graphviz renders the same diagram as Mermaid in the DOT language: a cluster
+// per column holding its tasks, and edges between consecutive columns.
+type Graphviz struct{}
+
+func (Graphviz) ContentType() string {
+	return "text/vnd.graphviz; charset=utf-8"
+}
+
+func (Graphviz) Render(w io.Writer, project *dal.ExtendedProjectEntities) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "digraph project_%d {\n", project.ID)
+	fmt.Fprintln(out, "    rankdir=LR;")
+	fmt.Fprintln(out, "    compound=true;")
+	fmt.Fprintf(out, "    label=%s;\n", dotString(project.Name))
+	fmt.Fprintln(out, "    node [shape=box, style=rounded];")
+
+	columns := sortedColumns(project)
+	for _, column := range columns {
+		fmt.Fprintf(out, "    subgraph cluster_column_%d {\n", column.ID)
+		fmt.Fprintf(out, "        label=%s;\n", dotString(column.Name))
+		// Edges can't start at a cluster, so every column gets an invisible anchor.
+		fmt.Fprintf(out, "        column_%d [shape=point, style=invis];\n", column.ID)
+		for _, task := range column.Tasks {
+			attributes := ""
+			if task.Status {
+				attributes = `, style="rounded,filled", fillcolor="#d4edda"`
+			}
+			fmt.Fprintf(out, "        task_%d [label=%s%s];\n", task.ID, dotString(task.Name), attributes)
+		}
+		fmt.Fprintln(out, "    }")
+	}
+	for i := 1; i < len(columns); i++ {
+		fmt.Fprintf(out, "    column_%d -> column_%d [ltail=cluster_column_%d, lhead=cluster_column_%d];\n",
+			columns[i-1].ID, columns[i].ID, columns[i-1].ID, columns[i].ID)
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+//---------------------------------------------------------------------------//
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func dotString(s string) string {
+	return `"` + dotEscaper.Replace(singleLine(s)) + `"`
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Markdown renders a project as a document with a heading per column and a
+// checklist item per task, done tasks being checked. Task descriptions and
+// comments are nested under their task.
+type Markdown struct{}
+
+func (Markdown) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (Markdown) Render(w io.Writer, project *dal.ExtendedProjectEntities) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "# %s\n", singleLine(project.Name))
+	if project.Description != "" {
+		fmt.Fprintf(out, "\n%s\n", strings.TrimSpace(project.Description))
+	}
+	for _, column := range sortedColumns(project) {
+		fmt.Fprintf(out, "\n## %s\n\n", singleLine(column.Name))
+		if len(column.Tasks) == 0 {
+			fmt.Fprint(out, "_No tasks_\n")
+			continue
+		}
+		for _, task := range column.Tasks {
+			mark := " "
+			if task.Status {
+				mark = "x"
+			}
+			fmt.Fprintf(out, "- [%s] %s\n", mark, singleLine(task.Name))
+			if task.Description != "" {
+				fmt.Fprintf(out, "\n%s\n\n", indent(strings.TrimSpace(task.Description), "  "))
+			}
+			for _, comment := range task.Comments {
+				fmt.Fprintf(out, "  - %s\n", strings.ReplaceAll(strings.TrimSpace(comment.Description), "\n", "\n    "))
+			}
+		}
+	}
+	return out.Flush()
+}
+
+//---------------------------------------------------------------------------//
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Mermaid renders the columns of a project as a left-to-right flowchart, each
+// column being a subgraph holding its tasks. Done tasks get the "done" class.
+// Tasks have no dependencies in this API, so the only edges are the column flow.
+type Mermaid struct{}
+
+func (Mermaid) ContentType() string {
+	return "text/vnd.mermaid; charset=utf-8"
+}
+
+func (Mermaid) Render(w io.Writer, project *dal.ExtendedProjectEntities) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "flowchart LR")
+	fmt.Fprintln(out, "    classDef done fill:#d4edda,stroke:#28a745")
+
+	columns := sortedColumns(project)
+	var done []string
+	for _, column := range columns {
+		fmt.Fprintf(out, "    subgraph column_%d[\"%s\"]\n", column.ID, mermaidLabel(column.Name))
+		for _, task := range column.Tasks {
+			fmt.Fprintf(out, "        task_%d[\"%s\"]\n", task.ID, mermaidLabel(task.Name))
+			if task.Status {
+				done = append(done, fmt.Sprintf("task_%d", task.ID))
+			}
+		}
+		fmt.Fprintln(out, "    end")
+	}
+	for i := 1; i < len(columns); i++ {
+		fmt.Fprintf(out, "    column_%d --> column_%d\n", columns[i-1].ID, columns[i].ID)
+	}
+	if len(done) > 0 {
+		fmt.Fprintf(out, "    class %s done\n", strings.Join(done, ","))
+	}
+	return out.Flush()
+}
+
+//---------------------------------------------------------------------------//
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;")
+
+func mermaidLabel(s string) string {
+	return mermaidEscaper.Replace(singleLine(s))
+}
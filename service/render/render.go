@@ -0,0 +1,60 @@
+package render
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Renderer turns a project tree into a document format such as Markdown or a
+// diagram description. Renderers are registered under the file extension they
+// are served with.
+type Renderer interface {
+	ContentType() string
+	Render(w io.Writer, project *dal.ExtendedProjectEntities) error
+}
+
+var renderers = map[string]Renderer{}
+
+func init() {
+	Register("md", Markdown{})
+	Register("mmd", Mermaid{})
+	Register("dot", Graphviz{})
+}
+
+// Register makes a renderer available under format, replacing any renderer
+// previously registered for it.
+func Register(format string, renderer Renderer) {
+	renderers[format] = renderer
+}
+
+func Lookup(format string) (Renderer, bool) {
+	renderer, ok := renderers[format]
+	return renderer, ok
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+//---------------------------------------------------------------------------//
+
+// sortedColumns returns the columns of a project in board order.
+func sortedColumns(project *dal.ExtendedProjectEntities) []dal.ExtendedColumn {
+	columns := append([]dal.ExtendedColumn(nil), project.Columns...)
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].OrderNum < columns[j].OrderNum })
+	return columns
+}
+
+// singleLine collapses line breaks so that a value fits into a heading, a list
+// item or a diagram label.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,133 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+var board = &dal.ExtendedProjectEntities{
+	Project: dal.Project{ID: 1, Name: `Website "relaunch"`, Description: "Autumn release"},
+	Columns: []dal.ExtendedColumn{
+		{
+			Column: dal.Column{ID: 3, Name: "Done", OrderNum: 2},
+			Tasks:  []dal.ExtendedTask{{Task: dal.Task{ID: 7, Name: "Ship", Status: true}}},
+		},
+		{
+			Column: dal.Column{ID: 2, Name: "To Do", OrderNum: 1},
+			Tasks: []dal.ExtendedTask{{
+				Task:     dal.Task{ID: 5, Name: "Write copy", Description: "Short\nand friendly"},
+				Comments: []dal.Comment{{Description: "First draft"}, {Description: "two\nlines"}},
+			}},
+		},
+		{
+			Column: dal.Column{ID: 4, Name: "Later", OrderNum: 3},
+		},
+	},
+}
+
+func TestRenderers(t *testing.T) {
+	tests := []struct {
+		format      string
+		contentType string
+		want        string
+	}{
+		{
+			format:      "md",
+			contentType: "text/markdown; charset=utf-8",
+			want: `# Website "relaunch"
+
+Autumn release
+
+## To Do
+
+- [ ] Write copy
+
+  Short
+  and friendly
+
+  - First draft
+  - two
+    lines
+
+## Done
+
+- [x] Ship
+
+## Later
+
+_No tasks_
+`,
+		},
+		{
+			format:      "mmd",
+			contentType: "text/vnd.mermaid; charset=utf-8",
+			want: `flowchart LR
+    classDef done fill:#d4edda,stroke:#28a745
+    subgraph column_2["To Do"]
+        task_5["Write copy"]
+    end
+    subgraph column_3["Done"]
+        task_7["Ship"]
+    end
+    subgraph column_4["Later"]
+    end
+    column_2 --> column_3
+    column_3 --> column_4
+    class task_7 done
+`,
+		},
+		{
+			format:      "dot",
+			contentType: "text/vnd.graphviz; charset=utf-8",
+			want: `digraph project_1 {
+    rankdir=LR;
+    compound=true;
+    label="Website \"relaunch\"";
+    node [shape=box, style=rounded];
+    subgraph cluster_column_2 {
+        label="To Do";
+        column_2 [shape=point, style=invis];
+        task_5 [label="Write copy"];
+    }
+    subgraph cluster_column_3 {
+        label="Done";
+        column_3 [shape=point, style=invis];
+        task_7 [label="Ship", style="rounded,filled", fillcolor="#d4edda"];
+    }
+    subgraph cluster_column_4 {
+        label="Later";
+        column_4 [shape=point, style=invis];
+    }
+    column_2 -> column_3 [ltail=cluster_column_2, lhead=cluster_column_3];
+    column_3 -> column_4 [ltail=cluster_column_3, lhead=cluster_column_4];
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			renderer, ok := Lookup(tt.format)
+			if !ok {
+				t.Fatalf("Lookup(%q) found no renderer", tt.format)
+			}
+			if renderer.ContentType() != tt.contentType {
+				t.Errorf("ContentType() = %q, want %q", renderer.ContentType(), tt.contentType)
+			}
+			var got bytes.Buffer
+			if err := renderer.Render(&got, board); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Render() got:\n%s\nwant:\n%s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMermaidLabel(t *testing.T) {
+	if got := mermaidLabel("say \"hi\"\nthere"); got != "say #quot;hi#quot; there" {
+		t.Errorf("mermaidLabel() = %q", got)
+	}
+}
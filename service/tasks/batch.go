@@ -0,0 +1,178 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+const MaxBatchOperations = 100
+
+var (
+	ErrBatchEmpty       = errors.New("batch has no operations")
+	ErrBatchTooLarge    = fmt.Errorf("batch has more than %d operations", MaxBatchOperations)
+	ErrBatchUnknownMode = errors.New("batch mode must be atomic or per_item")
+	ErrBatchFailed      = errors.New("batch failed and was rolled back")
+)
+
+//=======================================================================================//
+
+// Batch applies every operation inside one transaction. In atomic mode the first
+// failing operation rolls back the whole batch and ErrBatchFailed is returned
+// together with the results. In per_item mode each operation gets its own
+// savepoint, so failed operations are reported without undoing the others.
+func (c *UseCase) Batch(ctx context.Context, projectID int, batch *dal.TaskBatch) ([]dal.TaskOperationResult, error) {
+	ctx, span := tracer.Start(ctx, "tasks.Batch")
+	defer span.End()
+	mode := batch.Mode
+	if mode == "" {
+		mode = dal.BatchModeAtomic
+	}
+	if mode != dal.BatchModeAtomic && mode != dal.BatchModePerItem {
+		return nil, ErrBatchUnknownMode
+	}
+	if len(batch.Operations) == 0 {
+		return nil, ErrBatchEmpty
+	}
+	if len(batch.Operations) > MaxBatchOperations {
+		return nil, ErrBatchTooLarge
+	}
+
+	results := make([]dal.TaskOperationResult, len(batch.Operations))
+	for i, operation := range batch.Operations {
+		results[i] = dal.TaskOperationResult{Index: i, Op: operation.Op, Status: dal.TaskOpStatusSkipped}
+	}
+	err := c.repo.Transaction(ctx, func(tx dal.Repository) error {
+		applier := &batchApplier{repo: tx, projectID: projectID, columns: map[int]bool{}}
+		for i, operation := range batch.Operations {
+			var task *dal.Task
+			var err error
+			if mode == dal.BatchModePerItem {
+				err = tx.Transaction(ctx, func(item dal.Repository) error {
+					task, err = applier.withRepo(item).apply(ctx, operation)
+					return err
+				})
+			} else {
+				task, err = applier.apply(ctx, operation)
+			}
+			if err != nil {
+				results[i].Status = dal.TaskOpStatusFailed
+				results[i].Error = err.Error()
+				if mode == dal.BatchModeAtomic {
+					return err
+				}
+				continue
+			}
+			results[i].Status = dal.TaskOpStatusOK
+			results[i].Task = task
+		}
+		return nil
+	})
+	if err != nil && mode == dal.BatchModeAtomic {
+		for i := range results {
+			if results[i].Status == dal.TaskOpStatusOK {
+				results[i].Status = dal.TaskOpStatusRolledBack
+				results[i].Task = nil
+			}
+		}
+		c.logger.Ctx(ctx).Warn("task batch rolled back", "project_id", projectID, "error", err)
+		return results, ErrBatchFailed
+	}
+	return results, err
+}
+
+//---------------------------------------------------------------------------//
+
+type batchApplier struct {
+	repo      dal.Repository
+	projectID int
+	// columns caches which column IDs were already checked to belong to the project.
+	columns map[int]bool
+}
+
+func (a *batchApplier) withRepo(repo dal.Repository) *batchApplier {
+	return &batchApplier{repo: repo, projectID: a.projectID, columns: a.columns}
+}
+
+func (a *batchApplier) apply(ctx context.Context, operation dal.TaskOperation) (*dal.Task, error) {
+	task := operation.Task
+	switch operation.Op {
+	case dal.TaskOpCreate:
+		if err := a.checkColumn(ctx, task.ColumnID); err != nil {
+			return nil, err
+		}
+		task.ID = 0
+		if err := a.repo.CreateTask(ctx, &task); err != nil {
+			return nil, err
+		}
+		return &task, nil
+	case dal.TaskOpUpdate:
+		if _, err := a.existingTask(ctx, task.ID); err != nil {
+			return nil, err
+		}
+		if err := a.checkColumn(ctx, task.ColumnID); err != nil {
+			return nil, err
+		}
+		if err := a.repo.UpdateTask(ctx, &task); err != nil {
+			return nil, err
+		}
+		return &task, nil
+	case dal.TaskOpMove:
+		existing, err := a.existingTask(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.checkColumn(ctx, task.ColumnID); err != nil {
+			return nil, err
+		}
+		existing.ColumnID = task.ColumnID
+		if err := a.repo.UpdateTask(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	case dal.TaskOpDelete:
+		existing, err := a.existingTask(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.repo.DeleteTask(ctx, a.projectID, existing.ColumnID, existing.ID); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", operation.Op)
+}
+
+func (a *batchApplier) existingTask(ctx context.Context, taskID int) (*dal.Task, error) {
+	if taskID == 0 {
+		return nil, errors.New("task id is missing")
+	}
+	extTask, err := a.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task %d: %w", taskID, err)
+	}
+	if err := a.checkColumn(ctx, extTask.ColumnID); err != nil {
+		return nil, fmt.Errorf("task %d: %w", taskID, err)
+	}
+	task := extTask.Task
+	return &task, nil
+}
+
+func (a *batchApplier) checkColumn(ctx context.Context, columnID int) error {
+	if a.columns[columnID] {
+		return nil
+	}
+	column, err := a.repo.GetColumnRow(ctx, columnID)
+	if err != nil {
+		return fmt.Errorf("column %d: %w", columnID, err)
+	}
+	if column.ProjectID != a.projectID {
+		return fmt.Errorf("column %d doesn't belong to project %d", columnID, a.projectID)
+	}
+	a.columns[columnID] = true
+	return nil
+}
+
+//=======================================================================================//
@@ -0,0 +1,163 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestUseCase_Batch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	type args struct {
+		projectID int
+		batch     *dal.TaskBatch
+	}
+
+	inTransaction := func(repo *mocks.MockRepository) {
+		repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(dal.Repository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []dal.TaskOperationResult
+		wantErr error
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetColumnRow(gomock.Any(), 2).Return(&dal.Column{ID: 2, ProjectID: 1}, nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "new", ColumnID: 2}).Return(nil).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 5).Return(&dal.ExtendedTask{Task: dal.Task{ID: 5, Name: "old", ColumnID: 2}}, nil).Times(1)
+					repo.EXPECT().UpdateTask(gomock.Any(), &dal.Task{ID: 5, Name: "old", Status: false, ColumnID: 2}).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				projectID: 1,
+				batch: &dal.TaskBatch{Operations: []dal.TaskOperation{
+					{Op: dal.TaskOpCreate, Task: dal.Task{Name: "new", ColumnID: 2}},
+					{Op: dal.TaskOpMove, Task: dal.Task{ID: 5, ColumnID: 2}},
+				}},
+			},
+			want: []dal.TaskOperationResult{
+				{Index: 0, Op: dal.TaskOpCreate, Status: dal.TaskOpStatusOK, Task: &dal.Task{Name: "new", ColumnID: 2}},
+				{Index: 1, Op: dal.TaskOpMove, Status: dal.TaskOpStatusOK, Task: &dal.Task{ID: 5, Name: "old", ColumnID: 2}},
+			},
+		},
+		{
+			name: "atomic batch is rolled back",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetTask(gomock.Any(), 5).Return(&dal.ExtendedTask{Task: dal.Task{ID: 5, ColumnID: 2}}, nil).Times(1)
+					repo.EXPECT().GetColumnRow(gomock.Any(), 2).Return(&dal.Column{ID: 2, ProjectID: 1}, nil).Times(1)
+					repo.EXPECT().DeleteTask(gomock.Any(), 1, 2, 5).Return(nil).Times(1)
+					repo.EXPECT().GetColumnRow(gomock.Any(), 3).Return(&dal.Column{ID: 3, ProjectID: 7}, nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				projectID: 1,
+				batch: &dal.TaskBatch{Operations: []dal.TaskOperation{
+					{Op: dal.TaskOpDelete, Task: dal.Task{ID: 5}},
+					{Op: dal.TaskOpCreate, Task: dal.Task{Name: "foreign", ColumnID: 3}},
+					{Op: dal.TaskOpDelete, Task: dal.Task{ID: 6}},
+				}},
+			},
+			want: []dal.TaskOperationResult{
+				{Index: 0, Op: dal.TaskOpDelete, Status: dal.TaskOpStatusRolledBack},
+				{Index: 1, Op: dal.TaskOpCreate, Status: dal.TaskOpStatusFailed, Error: "column 3 doesn't belong to project 1"},
+				{Index: 2, Op: dal.TaskOpDelete, Status: dal.TaskOpStatusSkipped},
+			},
+			wantErr: ErrBatchFailed,
+		},
+		{
+			name: "per item batch keeps successful operations",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo)
+					repo.EXPECT().GetTask(gomock.Any(), 5).Return(nil, errors.New("record not found")).Times(1)
+					repo.EXPECT().GetColumnRow(gomock.Any(), 2).Return(&dal.Column{ID: 2, ProjectID: 1}, nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "new", ColumnID: 2}).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				projectID: 1,
+				batch: &dal.TaskBatch{Mode: dal.BatchModePerItem, Operations: []dal.TaskOperation{
+					{Op: dal.TaskOpUpdate, Task: dal.Task{ID: 5, ColumnID: 2}},
+					{Op: dal.TaskOpCreate, Task: dal.Task{Name: "new", ColumnID: 2}},
+				}},
+			},
+			want: []dal.TaskOperationResult{
+				{Index: 0, Op: dal.TaskOpUpdate, Status: dal.TaskOpStatusFailed, Error: "task 5: record not found"},
+				{Index: 1, Op: dal.TaskOpCreate, Status: dal.TaskOpStatusOK, Task: &dal.Task{Name: "new", ColumnID: 2}},
+			},
+		},
+		{
+			name: "unknown mode",
+			fields: fields{
+				logger: logging.Nop(),
+				repo:   mocks.NewMockRepository(ctrl),
+			},
+			args: args{
+				projectID: 1,
+				batch:     &dal.TaskBatch{Mode: "eventually", Operations: []dal.TaskOperation{{Op: dal.TaskOpDelete}}},
+			},
+			wantErr: ErrBatchUnknownMode,
+		},
+		{
+			name: "empty",
+			fields: fields{
+				logger: logging.Nop(),
+				repo:   mocks.NewMockRepository(ctrl),
+			},
+			args: args{
+				projectID: 1,
+				batch:     &dal.TaskBatch{},
+			},
+			wantErr: ErrBatchEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.Batch(context.Background(), tt.args.projectID, tt.args.batch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Batch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Batch() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package tasks
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// CSVHeader is the header row written by ExportCSV. ImportCSV reads the same
+// header, but only the column name, task name, description and status are used.
+var CSVHeader = []string{
+	"project_id", "project_name",
+	"column_id", "column_name",
+	"task_id", "task_name", "task_description", "task_status",
+	"comment_count",
+}
+
+// csvImportFields maps accepted header names to the task field they fill, so that
+// both exported files and hand-written sheets with short headers can be imported.
+var csvImportFields = map[string]string{
+	"column_name":      "column",
+	"column":           "column",
+	"task_name":        "name",
+	"name":             "name",
+	"task_description": "description",
+	"description":      "description",
+	"task_status":      "status",
+	"status":           "status",
+}
+
+var (
+	ErrCSVNoColumn = errors.New("csv header has no column or column_name field")
+	ErrCSVNoName   = errors.New("csv header has no name or task_name field")
+	ErrCSVRows     = errors.New("csv import has invalid rows, nothing was imported")
+
+	errDryRun = errors.New("dry run")
+)
+
+//=======================================================================================//
+
+// ExportCSV writes one row per task of the project, ordered by column.
+func (c *UseCase) ExportCSV(ctx context.Context, projectID int, w io.Writer) error {
+	ctx, span := tracer.Start(ctx, "tasks.ExportCSV")
+	defer span.End()
+	project, err := c.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	columns := append([]dal.ExtendedColumn(nil), project.Columns...)
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].OrderNum < columns[j].OrderNum })
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		for _, task := range column.Tasks {
+			err := writer.Write([]string{
+				strconv.Itoa(project.ID), project.Name,
+				strconv.Itoa(column.ID), column.Name,
+				strconv.Itoa(task.ID), task.Name, task.Description, strconv.FormatBool(task.Status),
+				strconv.Itoa(len(task.Comments)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+//---------------------------------------------------------------------------//
+
+// ImportCSV creates a task for every row, in the column named by the row. Columns
+// that don't exist in the project yet are created after the existing ones. Rows
+// are numbered from 1, the header being row 1. If any row is invalid nothing is
+// written and ErrCSVRows is returned along with the report. A dry run does all
+// the work in a transaction that is rolled back at the end.
+func (c *UseCase) ImportCSV(ctx context.Context, projectID int, r io.Reader, dryRun bool) (*dal.TaskImportReport, error) {
+	ctx, span := tracer.Start(ctx, "tasks.ImportCSV")
+	defer span.End()
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("can't read csv header: %w", err)
+	}
+	fields := map[string]int{}
+	for i, name := range header {
+		if field, ok := csvImportFields[strings.ToLower(strings.TrimSpace(name))]; ok {
+			fields[field] = i
+		}
+	}
+	if _, ok := fields["column"]; !ok {
+		return nil, ErrCSVNoColumn
+	}
+	if _, ok := fields["name"]; !ok {
+		return nil, ErrCSVNoName
+	}
+
+	report := &dal.TaskImportReport{DryRun: dryRun, CreatedColumns: []string{}, Errors: []dal.TaskImportRowError{}}
+	err = c.repo.Transaction(ctx, func(tx dal.Repository) error {
+		columns, nextOrder, err := projectColumns(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+		foreign, err := foreignColumnNames(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+
+		for row := 2; ; row++ {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				report.Errors = append(report.Errors, dal.TaskImportRowError{Row: row, Error: err.Error()})
+				continue
+			}
+			value := func(field string) string {
+				i, ok := fields[field]
+				if !ok || i >= len(record) {
+					return ""
+				}
+				return strings.TrimSpace(record[i])
+			}
+
+			task := dal.Task{Name: value("name"), Description: value("description")}
+			if task.Name == "" {
+				report.Errors = append(report.Errors, dal.TaskImportRowError{Row: row, Error: "task name is empty"})
+				continue
+			}
+			if status := value("status"); status != "" {
+				task.Status, err = strconv.ParseBool(status)
+				if err != nil {
+					report.Errors = append(report.Errors, dal.TaskImportRowError{Row: row, Error: fmt.Sprintf("status %q isn't a boolean", status)})
+					continue
+				}
+			}
+
+			columnName := value("column")
+			if columnName == "" {
+				report.Errors = append(report.Errors, dal.TaskImportRowError{Row: row, Error: "column name is empty"})
+				continue
+			}
+			columnID, ok := columns[columnName]
+			if !ok {
+				if foreign[columnName] {
+					report.Errors = append(report.Errors, dal.TaskImportRowError{Row: row, Error: fmt.Sprintf("column %q belongs to another project", columnName)})
+					continue
+				}
+				column := &dal.Column{Name: columnName, ProjectID: projectID, OrderNum: nextOrder}
+				if err := tx.CreateColumn(ctx, column); err != nil {
+					return fmt.Errorf("row %d: can't create column %q: %w", row, columnName, err)
+				}
+				nextOrder++
+				columns[columnName] = column.ID
+				columnID = column.ID
+				report.CreatedColumns = append(report.CreatedColumns, columnName)
+			}
+
+			task.ColumnID = columnID
+			if err := tx.CreateTask(ctx, &task); err != nil {
+				return fmt.Errorf("row %d: can't create task: %w", row, err)
+			}
+			report.Created++
+		}
+
+		if len(report.Errors) > 0 {
+			return ErrCSVRows
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	switch {
+	case err == nil, errors.Is(err, errDryRun):
+		return report, nil
+	case errors.Is(err, ErrCSVRows):
+		return report, err
+	}
+	c.logger.Ctx(ctx).Error("error importing csv", "project_id", projectID, "error", err)
+	return nil, err
+}
+
+//---------------------------------------------------------------------------//
+
+// projectColumns returns the IDs of the project's columns by name and the order
+// number a new column should get to be placed last.
+func projectColumns(ctx context.Context, repo dal.Repository, projectID int) (map[string]int, int, error) {
+	project, err := repo.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	columns := make(map[string]int, len(project.Columns))
+	nextOrder := 1
+	for _, column := range project.Columns {
+		columns[column.Name] = column.ID
+		if column.OrderNum >= nextOrder {
+			nextOrder = column.OrderNum + 1
+		}
+	}
+	return columns, nextOrder, nil
+}
+
+// foreignColumnNames returns the names used by columns of other projects. Column
+// names are unique across all projects, so rows naming them can't be imported.
+func foreignColumnNames(ctx context.Context, repo dal.Repository, projectID int) (map[string]bool, error) {
+	all, err := repo.GetColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, column := range all {
+		if column.ProjectID != projectID {
+			names[column.Name] = true
+		}
+	}
+	return names, nil
+}
+
+//=======================================================================================//
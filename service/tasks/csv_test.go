@@ -0,0 +1,236 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/dal/mocks"
+	"github.com/Boobuh/golang-school-project/logging"
+)
+
+func TestUseCase_ExportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(&dal.ExtendedProjectEntities{
+						Project: dal.Project{ID: 1, Name: "board"},
+						Columns: []dal.ExtendedColumn{
+							{
+								Column: dal.Column{ID: 3, Name: "done", OrderNum: 2},
+								Tasks:  []dal.ExtendedTask{{Task: dal.Task{ID: 7, Name: "ship", Status: true, ColumnID: 3}}},
+							},
+							{
+								Column: dal.Column{ID: 2, Name: "todo", OrderNum: 1},
+								Tasks: []dal.ExtendedTask{{
+									Task:     dal.Task{ID: 5, Name: "write, test", Description: "line one\nline two", ColumnID: 2},
+									Comments: []dal.Comment{{ID: 1}, {ID: 2}},
+								}},
+							},
+						},
+					}, nil).Times(1)
+					return repo
+				}(),
+			},
+			want: "project_id,project_name,column_id,column_name,task_id,task_name,task_description,task_status,comment_count\n" +
+				"1,board,2,todo,5,\"write, test\",\"line one\nline two\",false,2\n" +
+				"1,board,3,done,7,ship,,true,0\n",
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			var got bytes.Buffer
+			err := c.ExportCSV(context.Background(), 1, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExportCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ExportCSV() got = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCase_ImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	type args struct {
+		csv    string
+		dryRun bool
+	}
+
+	project := &dal.ExtendedProjectEntities{
+		Project: dal.Project{ID: 1, Name: "board"},
+		Columns: []dal.ExtendedColumn{{Column: dal.Column{ID: 2, Name: "todo", ProjectID: 1, OrderNum: 1}}},
+	}
+	allColumns := []dal.Column{{ID: 2, Name: "todo", ProjectID: 1}, {ID: 9, Name: "elsewhere", ProjectID: 4}}
+
+	inTransaction := func(repo *mocks.MockRepository, wantErr error) {
+		repo.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(dal.Repository) error) error {
+			err := fn(repo)
+			if !errors.Is(err, wantErr) {
+				t.Errorf("transaction error = %v, want %v", err, wantErr)
+			}
+			return err
+		}).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *dal.TaskImportReport
+		wantErr error
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo, nil)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(project, nil).Times(1)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(allColumns, nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "write", Description: "docs", ColumnID: 2}).Return(nil).Times(1)
+					repo.EXPECT().CreateColumn(gomock.Any(), &dal.Column{Name: "review", ProjectID: 1, OrderNum: 2}).DoAndReturn(func(_ context.Context, column *dal.Column) error {
+						column.ID = 3
+						return nil
+					}).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "check", Status: true, ColumnID: 3}).Return(nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "again", ColumnID: 3}).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				csv: "Column,Name,Description,Status\n" +
+					"todo,write,docs,\n" +
+					"review,check,,true\n" +
+					"review,again,,false\n",
+			},
+			want: &dal.TaskImportReport{Created: 3, CreatedColumns: []string{"review"}, Errors: []dal.TaskImportRowError{}},
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo, errDryRun)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(project, nil).Times(1)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(allColumns, nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "write", ColumnID: 2}).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				csv:    "column_name,task_name\ntodo,write\n",
+				dryRun: true,
+			},
+			want: &dal.TaskImportReport{DryRun: true, Created: 1, CreatedColumns: []string{}, Errors: []dal.TaskImportRowError{}},
+		},
+		{
+			name: "invalid rows",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					inTransaction(repo, ErrCSVRows)
+					repo.EXPECT().GetProject(gomock.Any(), 1).Return(project, nil).Times(1)
+					repo.EXPECT().GetColumns(gomock.Any()).Return(allColumns, nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Name: "fine", ColumnID: 2}).Return(nil).Times(1)
+					return repo
+				}(),
+			},
+			args: args{
+				csv: "column,name,status\n" +
+					"todo,,\n" +
+					"todo,fine,\n" +
+					",orphan,\n" +
+					"todo,broken,maybe\n" +
+					"elsewhere,foreign,\n",
+			},
+			want: &dal.TaskImportReport{
+				Created:        1,
+				CreatedColumns: []string{},
+				Errors: []dal.TaskImportRowError{
+					{Row: 2, Error: "task name is empty"},
+					{Row: 4, Error: "column name is empty"},
+					{Row: 5, Error: `status "maybe" isn't a boolean`},
+					{Row: 6, Error: `column "elsewhere" belongs to another project`},
+				},
+			},
+			wantErr: ErrCSVRows,
+		},
+		{
+			name: "missing header",
+			fields: fields{
+				logger: logging.Nop(),
+				repo:   mocks.NewMockRepository(ctrl),
+			},
+			args: args{
+				csv: "name,description\nwrite,docs\n",
+			},
+			wantErr: ErrCSVNoColumn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.ImportCSV(context.Background(), 1, strings.NewReader(tt.args.csv), tt.args.dryRun)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ImportCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ImportCSV() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
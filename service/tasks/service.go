@@ -1,49 +1,73 @@
 package tasks
 
 import (
-	"log"
+	"context"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 )
 
+var tracer = otel.Tracer("github.com/Boobuh/golang-school-project/service/tasks")
+
 type UseCase struct {
 	repo   dal.Repository
-	logger *log.Logger
+	logger logging.Logger
 }
 
-func NewUseCase(repo dal.Repository, logger *log.Logger) *UseCase {
+func NewUseCase(repo dal.Repository, logger logging.Logger) *UseCase {
 	return &UseCase{repo: repo, logger: logger}
 }
 
-func (c *UseCase) GetTasks() ([]dal.Task, error) {
-	return c.repo.GetTasks()
+func (c *UseCase) GetTasks(ctx context.Context) ([]dal.Task, error) {
+	ctx, span := tracer.Start(ctx, "tasks.GetTasks")
+	defer span.End()
+	return c.repo.GetTasks(ctx)
+}
+
+// GetByColumnIDs gets the tasks of all the columns at once, without their
+// comments.
+func (c *UseCase) GetByColumnIDs(ctx context.Context, columnIDs []int) ([]dal.Task, error) {
+	ctx, span := tracer.Start(ctx, "tasks.GetByColumnIDs")
+	defer span.End()
+	return c.repo.GetTasksByColumnIDs(ctx, columnIDs)
 }
 
-func (c *UseCase) GetTask(projectID, columnID, taskID int) (*dal.ExtendedTask, error) {
-	return c.repo.GetTask(taskID)
+func (c *UseCase) GetTask(ctx context.Context, projectID, columnID, taskID int) (*dal.ExtendedTask, error) {
+	ctx, span := tracer.Start(ctx, "tasks.GetTask")
+	defer span.End()
+	return c.repo.GetTask(ctx, taskID)
 }
 
-func (c *UseCase) CreateTask(task *dal.Task) error {
-	task = &dal.Task{ColumnID: task.ColumnID}
-	return c.repo.CreateTask(task)
+func (c *UseCase) CreateTask(ctx context.Context, task *dal.Task) error {
+	ctx, span := tracer.Start(ctx, "tasks.CreateTask")
+	defer span.End()
+	task.ID = 0
+	return c.repo.CreateTask(ctx, task)
 }
 
-func (c *UseCase) DeleteTask(projectID, columnID, taskID int) error {
-	return c.repo.DeleteTask(projectID, columnID, taskID)
+func (c *UseCase) DeleteTask(ctx context.Context, projectID, columnID, taskID int) error {
+	ctx, span := tracer.Start(ctx, "tasks.DeleteTask")
+	defer span.End()
+	return c.repo.DeleteTask(ctx, projectID, columnID, taskID)
 }
 
-func (c *UseCase) UpdateTask(task *dal.Task) error {
-	_, err := c.repo.GetTask(task.ID)
+func (c *UseCase) UpdateTask(ctx context.Context, task *dal.Task) error {
+	ctx, span := tracer.Start(ctx, "tasks.UpdateTask")
+	defer span.End()
+	_, err := c.repo.GetTask(ctx, task.ID)
 	if err != nil {
 
 		return err
 	}
-	err = c.repo.CreateTask(task)
-	return err
+	return c.repo.UpdateTask(ctx, task)
 }
 
-func (c *UseCase) GetAllByColumnID(columnID int) ([]dal.ExtendedTask, error) {
-	column, err := c.repo.GetColumn(columnID)
+func (c *UseCase) GetAllByColumnID(ctx context.Context, columnID int) ([]dal.ExtendedTask, error) {
+	ctx, span := tracer.Start(ctx, "tasks.GetAllByColumnID")
+	defer span.End()
+	column, err := c.repo.GetColumn(ctx, columnID)
 	if err != nil {
 		return nil, err
 	}
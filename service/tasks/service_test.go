@@ -1,14 +1,15 @@
 package tasks
 
 import (
+	"context"
 	"errors"
-	"log"
 	"reflect"
 	"testing"
 
 	"github.com/Boobuh/golang-school-project/dal/mocks"
 
 	"github.com/Boobuh/golang-school-project/dal"
+	"github.com/Boobuh/golang-school-project/logging"
 	"github.com/golang/mock/gomock"
 )
 
@@ -18,7 +19,7 @@ func TestUseCase_GetTasks(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	tests := []struct {
 		name    string
@@ -30,10 +31,10 @@ func TestUseCase_GetTasks(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTasks().Return([]dal.Task{}, nil).Times(1)
+					repo.EXPECT().GetTasks(gomock.Any()).Return([]dal.Task{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -43,10 +44,10 @@ func TestUseCase_GetTasks(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTasks().Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetTasks(gomock.Any()).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -60,7 +61,7 @@ func TestUseCase_GetTasks(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetTasks()
+			got, err := c.GetTasks(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTasks() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -78,7 +79,7 @@ func TestUseCase_GetTask(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -96,10 +97,10 @@ func TestUseCase_GetTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(1).Return(&dal.ExtendedTask{}, nil).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 1).Return(&dal.ExtendedTask{}, nil).Times(1)
 					return repo
 				}(),
 			},
@@ -114,10 +115,10 @@ func TestUseCase_GetTask(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(0).Return(nil, errors.New("failed")).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 0).Return(nil, errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -136,7 +137,7 @@ func TestUseCase_GetTask(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetTask(tt.args.projectID, tt.args.columnID, tt.args.taskID)
+			got, err := c.GetTask(context.Background(), tt.args.projectID, tt.args.columnID, tt.args.taskID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTask() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -154,7 +155,7 @@ func TestUseCase_CreateTask(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		task *dal.Task
@@ -169,10 +170,10 @@ func TestUseCase_CreateTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateTask(&dal.Task{0, "", false, "", 1}).Return(nil).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{Status: true, Description: "success", ColumnID: 1}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -190,10 +191,10 @@ func TestUseCase_CreateTask(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().CreateTask(&dal.Task{0, "", false, "", 0}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().CreateTask(gomock.Any(), &dal.Task{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -215,7 +216,7 @@ func TestUseCase_CreateTask(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.CreateTask(tt.args.task); (err != nil) != tt.wantErr {
+			if err := c.CreateTask(context.Background(), tt.args.task); (err != nil) != tt.wantErr {
 				t.Errorf("CreateTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -228,7 +229,7 @@ func TestUseCase_DeleteTask(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		projectID int
@@ -245,10 +246,10 @@ func TestUseCase_DeleteTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteTask(1, 1, 1).Return(nil).Times(1)
+					repo.EXPECT().DeleteTask(gomock.Any(), 1, 1, 1).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -262,10 +263,10 @@ func TestUseCase_DeleteTask(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().DeleteTask(0, 0, 0).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().DeleteTask(gomock.Any(), 0, 0, 0).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -283,7 +284,7 @@ func TestUseCase_DeleteTask(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.DeleteTask(tt.args.projectID, tt.args.columnID, tt.args.taskID); (err != nil) != tt.wantErr {
+			if err := c.DeleteTask(context.Background(), tt.args.projectID, tt.args.columnID, tt.args.taskID); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -296,7 +297,7 @@ func TestUseCase_UpdateTask(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		task *dal.Task
@@ -311,11 +312,11 @@ func TestUseCase_UpdateTask(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(1).Return(nil, nil).Times(1)
-					repo.EXPECT().CreateTask(&dal.Task{ID: 1, Name: "success", ColumnID: 1}).Return(nil).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 1).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateTask(gomock.Any(), &dal.Task{ID: 1, Name: "success", ColumnID: 1}).Return(nil).Times(1)
 					return repo
 				}(),
 			},
@@ -331,11 +332,11 @@ func TestUseCase_UpdateTask(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetTask(0).Return(nil, nil).Times(1)
-					repo.EXPECT().CreateTask(&dal.Task{}).Return(errors.New("failed")).Times(1)
+					repo.EXPECT().GetTask(gomock.Any(), 0).Return(nil, nil).Times(1)
+					repo.EXPECT().UpdateTask(gomock.Any(), &dal.Task{}).Return(errors.New("failed")).Times(1)
 					return repo
 				}(),
 			},
@@ -351,7 +352,7 @@ func TestUseCase_UpdateTask(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			if err := c.UpdateTask(tt.args.task); (err != nil) != tt.wantErr {
+			if err := c.UpdateTask(context.Background(), tt.args.task); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -364,7 +365,7 @@ func TestUseCase_GetAllByColumnID(t *testing.T) {
 
 	type fields struct {
 		repo   dal.Repository
-		logger *log.Logger
+		logger logging.Logger
 	}
 	type args struct {
 		columnID int
@@ -382,10 +383,10 @@ func TestUseCase_GetAllByColumnID(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(1).Return(&dal.ExtendedColumn{}, nil).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 1).Return(&dal.ExtendedColumn{}, nil).Times(1)
 					secondCall = dal.ExtendedColumn{}.Tasks
 					return repo
 				}(),
@@ -399,10 +400,10 @@ func TestUseCase_GetAllByColumnID(t *testing.T) {
 		{
 			name: "fail",
 			fields: fields{
-				logger: log.Default(),
+				logger: logging.Nop(),
 				repo: func() dal.Repository {
 					repo := mocks.NewMockRepository(ctrl)
-					repo.EXPECT().GetColumn(0).Return(&dal.ExtendedColumn{}, errors.New("failed")).Times(1)
+					repo.EXPECT().GetColumn(gomock.Any(), 0).Return(&dal.ExtendedColumn{}, errors.New("failed")).Times(1)
 					secondCall = dal.ExtendedColumn{}.Tasks
 					return repo
 				}(),
@@ -420,7 +421,7 @@ func TestUseCase_GetAllByColumnID(t *testing.T) {
 				repo:   tt.fields.repo,
 				logger: tt.fields.logger,
 			}
-			got, err := c.GetAllByColumnID(tt.args.columnID)
+			got, err := c.GetAllByColumnID(context.Background(), tt.args.columnID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetAllByColumnID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -431,3 +432,65 @@ func TestUseCase_GetAllByColumnID(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_GetByColumnIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo   dal.Repository
+		logger logging.Logger
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		ids     []int
+		want    []dal.Task
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetTasksByColumnIDs(gomock.Any(), []int{1, 2}).Return([]dal.Task{{ColumnID: 1}, {ColumnID: 2}}, nil).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1, 2},
+			want:    []dal.Task{{ColumnID: 1}, {ColumnID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "fail",
+			fields: fields{
+				logger: logging.Nop(),
+				repo: func() dal.Repository {
+					repo := mocks.NewMockRepository(ctrl)
+					repo.EXPECT().GetTasksByColumnIDs(gomock.Any(), []int{1}).Return(nil, errors.New("failed")).Times(1)
+					return repo
+				}(),
+			},
+			ids:     []int{1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UseCase{
+				repo:   tt.fields.repo,
+				logger: tt.fields.logger,
+			}
+			got, err := c.GetByColumnIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetByColumnIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByColumnIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
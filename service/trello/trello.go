@@ -0,0 +1,238 @@
+package trello
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+// Board is the subset of a Trello board export ("Menu > Print, export and share >
+// Export as JSON") that can be mapped onto projects.
+type Board struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Desc       string      `json:"desc"`
+	Lists      []List      `json:"lists"`
+	Cards      []Card      `json:"cards"`
+	Checklists []Checklist `json:"checklists"`
+	Actions    []Action    `json:"actions"`
+}
+
+type List struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type Card struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Desc        string       `json:"desc"`
+	IDList      string       `json:"idList"`
+	Closed      bool         `json:"closed"`
+	Pos         float64      `json:"pos"`
+	Due         *time.Time   `json:"due"`
+	DueComplete bool         `json:"dueComplete"`
+	Labels      []Label      `json:"labels"`
+	IDMembers   []string     `json:"idMembers"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type Attachment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type Checklist struct {
+	ID         string      `json:"id"`
+	IDCard     string      `json:"idCard"`
+	Name       string      `json:"name"`
+	Pos        float64     `json:"pos"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+type CheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+type Action struct {
+	ID            string     `json:"id"`
+	Type          string     `json:"type"`
+	Date          time.Time  `json:"date"`
+	Data          ActionData `json:"data"`
+	MemberCreator struct {
+		FullName string `json:"fullName"`
+	} `json:"memberCreator"`
+}
+
+type ActionData struct {
+	Text string `json:"text"`
+	Card struct {
+		ID string `json:"id"`
+	} `json:"card"`
+}
+
+const commentAction = "commentCard"
+
+//=======================================================================================//
+
+// Convert maps open lists to columns, open cards to tasks and comment actions to
+// comments. Due dates are kept as task due dates. Labels and checklists have no
+// counterpart on dal.Task, so they are appended to the task description;
+// everything else that can't be represented is listed in the report as skipped.
+func Convert(board *Board) (*dal.ProjectArchive, *dal.ImportReport) {
+	report := &dal.ImportReport{Converted: []dal.ImportReportItem{}, Skipped: []dal.ImportReportItem{}}
+	archive := &dal.ProjectArchive{
+		SchemaVersion: dal.ArchiveSchemaVersion,
+		Project: dal.ExtendedProjectEntities{
+			Project: dal.Project{Name: board.Name, Description: board.Desc},
+		},
+	}
+
+	lists := append([]List(nil), board.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	columns := map[string]int{}
+	for _, list := range lists {
+		if list.Closed {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "list", ID: list.ID, Name: list.Name, Reason: "list is archived"})
+			continue
+		}
+		columns[list.ID] = len(archive.Project.Columns)
+		archive.Project.Columns = append(archive.Project.Columns, dal.ExtendedColumn{
+			Column: dal.Column{Name: list.Name, OrderNum: len(archive.Project.Columns) + 1},
+		})
+	}
+
+	checklists := map[string][]Checklist{}
+	for _, checklist := range board.Checklists {
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], checklist)
+	}
+
+	cards := append([]Card(nil), board.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	type taskRef struct{ column, task int }
+	tasks := map[string]taskRef{}
+	for _, card := range cards {
+		if card.Closed {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "card", ID: card.ID, Name: card.Name, Reason: "card is archived"})
+			continue
+		}
+		column, ok := columns[card.IDList]
+		if !ok {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "card", ID: card.ID, Name: card.Name, Reason: "card belongs to an archived or unknown list"})
+			continue
+		}
+		extColumn := &archive.Project.Columns[column]
+		tasks[card.ID] = taskRef{column: column, task: len(extColumn.Tasks)}
+		extColumn.Tasks = append(extColumn.Tasks, dal.ExtendedTask{
+			Task: dal.Task{
+				Name:        card.Name,
+				Status:      card.DueComplete,
+				DueDate:     card.Due,
+				Description: describeCard(card, checklists[card.ID], report),
+			},
+		})
+		if len(card.IDMembers) > 0 {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "members", ID: card.ID, Name: card.Name, Reason: "projects have no users to assign"})
+		}
+		for _, attachment := range card.Attachments {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "attachment", ID: attachment.ID, Name: attachment.Name, Reason: "attachments aren't supported"})
+		}
+	}
+
+	// Trello lists actions newest first, comments are imported in the order they were written.
+	skippedActions := map[string]int{}
+	for i := len(board.Actions) - 1; i >= 0; i-- {
+		action := board.Actions[i]
+		if action.Type != commentAction {
+			skippedActions[action.Type]++
+			continue
+		}
+		ref, ok := tasks[action.Data.Card.ID]
+		if !ok {
+			report.Skipped = append(report.Skipped, dal.ImportReportItem{Kind: "comment", ID: action.ID, Name: action.MemberCreator.FullName, Reason: "comment belongs to a card that wasn't imported"})
+			continue
+		}
+		extTask := &archive.Project.Columns[ref.column].Tasks[ref.task]
+		text := action.Data.Text
+		if action.MemberCreator.FullName != "" {
+			text = action.MemberCreator.FullName + ": " + text
+		}
+		extTask.Comments = append(extTask.Comments, dal.Comment{Description: text})
+	}
+	actionTypes := make([]string, 0, len(skippedActions))
+	for actionType := range skippedActions {
+		actionTypes = append(actionTypes, actionType)
+	}
+	sort.Strings(actionTypes)
+	for _, actionType := range actionTypes {
+		report.Skipped = append(report.Skipped, dal.ImportReportItem{
+			Kind:   "action",
+			Name:   actionType,
+			Reason: fmt.Sprintf("%d activity entries of this type aren't imported", skippedActions[actionType]),
+		})
+	}
+
+	for _, extColumn := range archive.Project.Columns {
+		report.Columns++
+		for _, extTask := range extColumn.Tasks {
+			report.Tasks++
+			report.Comments += len(extTask.Comments)
+		}
+	}
+	return archive, report
+}
+
+//---------------------------------------------------------------------------//
+
+func describeCard(card Card, checklists []Checklist, report *dal.ImportReport) string {
+	var sections []string
+	if card.Desc != "" {
+		sections = append(sections, card.Desc)
+	}
+
+	if len(card.Labels) > 0 {
+		names := make([]string, 0, len(card.Labels))
+		for _, label := range card.Labels {
+			name := label.Name
+			if name == "" {
+				name = label.Color
+			}
+			names = append(names, name)
+		}
+		sections = append(sections, "Labels: "+strings.Join(names, ", "))
+		report.Converted = append(report.Converted, dal.ImportReportItem{Kind: "labels", ID: card.ID, Name: card.Name, Reason: "appended to the task description"})
+	}
+
+	sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	for _, checklist := range checklists {
+		items := append([]CheckItem(nil), checklist.CheckItems...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		lines := []string{checklist.Name + ":"}
+		for _, item := range items {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s", mark, item.Name))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+		report.Converted = append(report.Converted, dal.ImportReportItem{Kind: "checklist", ID: checklist.ID, Name: checklist.Name, Reason: "appended to the task description"})
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+//=======================================================================================//
@@ -0,0 +1,130 @@
+package trello
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Boobuh/golang-school-project/dal"
+)
+
+func loadBoard(t *testing.T, path string) *Board {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("can't read sample export: %v", err)
+	}
+	var board Board
+	if err := json.Unmarshal(raw, &board); err != nil {
+		t.Fatalf("can't decode sample export: %v", err)
+	}
+	return &board
+}
+
+func date(year int, month time.Month, day, hour int) *time.Time {
+	d := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantColumn []dal.ExtendedColumn
+		wantReport *dal.ImportReport
+	}{
+		{
+			name: "sample board",
+			path: "testdata/board.json",
+			wantColumn: []dal.ExtendedColumn{
+				{
+					Column: dal.Column{Name: "To Do", OrderNum: 1},
+					Tasks: []dal.ExtendedTask{
+						{
+							Task: dal.Task{
+								Name:        "Fix broken footer links",
+								Description: "Labels: bug, yellow",
+							},
+						},
+						{
+							Task: dal.Task{
+								Name:        "Write landing page copy",
+								Description: "Short and friendly, max 200 words.\n\nLabels: ready\n\nSections:\n- [x] Hero\n- [ ] Pricing",
+								DueDate:     date(2021, 10, 1, 9),
+							},
+							Comments: []dal.Comment{
+								{Description: "Dana Example: First draft is in the doc."},
+								{Description: "Dana Example: Second draft is in the doc."},
+							},
+						},
+					},
+				},
+				{
+					Column: dal.Column{Name: "Doing", OrderNum: 2},
+				},
+				{
+					Column: dal.Column{Name: "Done", OrderNum: 3},
+					Tasks: []dal.ExtendedTask{
+						{
+							Task: dal.Task{
+								Name:    "Set up analytics",
+								Status:  true,
+								DueDate: date(2021, 9, 15, 17),
+							},
+						},
+					},
+				},
+			},
+			wantReport: &dal.ImportReport{
+				Columns:  3,
+				Tasks:    3,
+				Comments: 2,
+				Converted: []dal.ImportReportItem{
+					{Kind: "labels", ID: "5f1a2b3c4d5e6f7081920c02", Name: "Fix broken footer links", Reason: "appended to the task description"},
+					{Kind: "labels", ID: "5f1a2b3c4d5e6f7081920c01", Name: "Write landing page copy", Reason: "appended to the task description"},
+					{Kind: "checklist", ID: "5f1a2b3c4d5e6f7081920f01", Name: "Sections", Reason: "appended to the task description"},
+				},
+				Skipped: []dal.ImportReportItem{
+					{Kind: "list", ID: "5f1a2b3c4d5e6f7081920b04", Name: "Ideas (old)", Reason: "list is archived"},
+					{Kind: "members", ID: "5f1a2b3c4d5e6f7081920c01", Name: "Write landing page copy", Reason: "projects have no users to assign"},
+					{Kind: "attachment", ID: "5f1a2b3c4d5e6f7081920a01", Name: "wireframe.png", Reason: "attachments aren't supported"},
+					{Kind: "card", ID: "5f1a2b3c4d5e6f7081920c04", Name: "Old banner", Reason: "card is archived"},
+					{Kind: "card", ID: "5f1a2b3c4d5e6f7081920c05", Name: "Dark mode", Reason: "card belongs to an archived or unknown list"},
+					{Kind: "comment", ID: "5f1a2b3c4d5e6f7081921a03", Name: "Dana Example", Reason: "comment belongs to a card that wasn't imported"},
+					{Kind: "action", Name: "createBoard", Reason: "1 activity entries of this type aren't imported"},
+					{Kind: "action", Name: "createCard", Reason: "1 activity entries of this type aren't imported"},
+					{Kind: "action", Name: "updateCard", Reason: "1 activity entries of this type aren't imported"},
+				},
+			},
+		},
+		{
+			name: "empty board",
+			path: "testdata/empty_board.json",
+			wantReport: &dal.ImportReport{
+				Converted: []dal.ImportReportItem{},
+				Skipped:   []dal.ImportReportItem{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := loadBoard(t, tt.path)
+			archive, report := Convert(board)
+
+			if archive.SchemaVersion != dal.ArchiveSchemaVersion {
+				t.Errorf("Convert() schema version = %d, want %d", archive.SchemaVersion, dal.ArchiveSchemaVersion)
+			}
+			if archive.Project.Name != board.Name || archive.Project.Description != board.Desc {
+				t.Errorf("Convert() project = %+v, want name %q", archive.Project.Project, board.Name)
+			}
+			if !reflect.DeepEqual(archive.Project.Columns, tt.wantColumn) {
+				t.Errorf("Convert() columns = %+v, want %+v", archive.Project.Columns, tt.wantColumn)
+			}
+			if !reflect.DeepEqual(report, tt.wantReport) {
+				t.Errorf("Convert() report = %+v, want %+v", report, tt.wantReport)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Boobuh/golang-school-project/config"
+)
+
+func TestSetup_Stdout(t *testing.T) {
+	cfg := config.Default().Tracing
+	cfg.Exporter = config.TracingExporterStdout
+	var out bytes.Buffer
+
+	shutdown, err := Setup(context.Background(), cfg, &out)
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	_, span := otel.Tracer("test").Start(context.Background(), "load board")
+	span.End()
+	assert.NoError(t, shutdown(context.Background()))
+
+	assert.Contains(t, out.String(), `"Name":"load board"`)
+	assert.Contains(t, out.String(), `"Value":"golang-school-project"`)
+
+	header := http.Header{}
+	otel.GetTextMapPropagator().Inject(trace.ContextWithSpanContext(context.Background(), span.SpanContext()), propagation.HeaderCarrier(header))
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", header.Get("traceparent"))
+}
+
+func TestNewProvider_Sampling(t *testing.T) {
+	cfg := config.Default().Tracing
+	cfg.SampleRatio = 0
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewProvider(cfg, sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, own := tracer.Start(context.Background(), "own trace")
+	own.End()
+
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, child := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), "caller's trace")
+	child.End()
+
+	ended := recorder.Ended()
+	if assert.Len(t, ended, 1, "only the span of the sampled caller is recorded") {
+		assert.Equal(t, "caller's trace", ended[0].Name())
+		assert.Equal(t, remote.TraceID(), ended[0].SpanContext().TraceID())
+	}
+}
@@ -0,0 +1,17 @@
+package version
+
+import "runtime"
+
+// Commit and BuildTime describe the build. They are set by the linker:
+//
+//	go build -ldflags "-X github.com/Boobuh/golang-school-project/version.Commit=$(git rev-parse HEAD) \
+//	    -X github.com/Boobuh/golang-school-project/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// GoVersion is the Go release the binary was built with.
+func GoVersion() string {
+	return runtime.Version()
+}